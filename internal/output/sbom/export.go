@@ -0,0 +1,24 @@
+package sbom
+
+import (
+	"bytes"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// ToCycloneDX encodes the given packages, which are keyed by their package-url as they
+// are when grouped with purl.Group, as the components of a CycloneDX 1.5 SBOM in JSON,
+// with the locations of each package being the occurrences in the evidence of its component
+func ToCycloneDX(packages map[string]models.PackageVulns) ([]byte, error) {
+	bom := ToCycloneDX15Bom(packages, nil)
+
+	var buf bytes.Buffer
+
+	encoder := cyclonedx.NewBOMEncoder(&buf, cyclonedx.BOMFileFormatJSON)
+	if err := encoder.EncodeVersion(bom, bom.SpecVersion); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,73 @@
+package sbom_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/google/osv-scanner/internal/output/sbom"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestToCycloneDX(t *testing.T) {
+	t.Parallel()
+
+	packages := map[string]models.PackageVulns{
+		"pkg:npm/left-pad@1.3.0": {
+			Package: models.PackageInfo{
+				Name:      "left-pad",
+				Version:   "1.3.0",
+				Ecosystem: "npm",
+			},
+			Locations: []models.PackageLocations{
+				{
+					Block: models.PackageLocation{
+						Filename:    "/dir/package-lock.json",
+						LineStart:   10,
+						LineEnd:     14,
+						ColumnStart: 5,
+						ColumnEnd:   6,
+					},
+				},
+			},
+		},
+	}
+
+	b, err := sbom.ToCycloneDX(packages)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	var bom cyclonedx.BOM
+	if err := cyclonedx.NewBOMDecoder(bytes.NewReader(b), cyclonedx.BOMFileFormatJSON).Decode(&bom); err != nil {
+		t.Fatalf("Could not decode the SBOM: %v", err)
+	}
+
+	if bom.SpecVersion != cyclonedx.SpecVersion1_5 {
+		t.Errorf("Expected the SBOM to be version 1.5, but got %s", bom.SpecVersion)
+	}
+
+	if bom.Components == nil || len(*bom.Components) != 1 {
+		t.Fatalf("Expected the SBOM to have one component, but got %v", bom.Components)
+	}
+
+	component := (*bom.Components)[0]
+
+	if component.PackageURL != "pkg:npm/left-pad@1.3.0" {
+		t.Errorf("Expected the component to have the purl pkg:npm/left-pad@1.3.0, but got %s", component.PackageURL)
+	}
+
+	if component.Name != "left-pad" || component.Version != "1.3.0" {
+		t.Errorf("Expected the component to be left-pad@1.3.0, but got %s@%s", component.Name, component.Version)
+	}
+
+	if component.Evidence == nil || component.Evidence.Occurrences == nil || len(*component.Evidence.Occurrences) != 1 {
+		t.Fatalf("Expected the component to have one occurrence, but got %v", component.Evidence)
+	}
+
+	want := `{"block":{"file_name":"/dir/package-lock.json","line_start":10,"line_end":14,"column_start":5,"column_end":6}}`
+
+	if got := (*component.Evidence.Occurrences)[0].Location; got != want {
+		t.Errorf("Expected the occurrence to be at %s, but got %s", want, got)
+	}
+}
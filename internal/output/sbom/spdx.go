@@ -0,0 +1,120 @@
+//nolint:nosnakecase
+package sbom
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/osv-scanner/pkg/models"
+	spdx_json "github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/spdx/v2/common"
+	"github.com/spdx/tools-golang/spdx/v2/v2_3"
+	"golang.org/x/exp/maps"
+)
+
+const spdxNoAssertion = "NOASSERTION"
+
+// spdxIDFromPURL returns the SPDX identifier of the package with the given package-url,
+// which can only have letters, numbers, "." and "-", so it is followed by part of the
+// hash of the package-url to keep package-urls that only differ by other characters unique
+func spdxIDFromPURL(packageURL string) common.ElementID {
+	sanitized := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '.' || r == '-' {
+			return r
+		}
+
+		return '-'
+	}, strings.TrimPrefix(packageURL, "pkg:"))
+
+	hash := sha256.Sum256([]byte(packageURL))
+
+	return common.ElementID("Package-" + sanitized + "-" + hex.EncodeToString(hash[:4]))
+}
+
+// spdxSourceInfo describes where the given package was found, as a list of the
+// files that it was found in along with the lines it was found on in each
+func spdxSourceInfo(packageDetail models.PackageVulns) string {
+	found := make([]string, 0, len(packageDetail.Locations))
+
+	for _, location := range packageDetail.Locations {
+		block := location.Block
+
+		if block.LineStart == block.LineEnd {
+			found = append(found, fmt.Sprintf("%s:%d", block.Filename, block.LineStart))
+		} else {
+			found = append(found, fmt.Sprintf("%s:%d-%d", block.Filename, block.LineStart, block.LineEnd))
+		}
+	}
+
+	if len(found) == 0 {
+		return ""
+	}
+
+	return "found in " + strings.Join(found, ", ")
+}
+
+// ToSPDX encodes the given packages, which are keyed by their package-url as they are
+// when grouped with purl.Group, as the packages of an SPDX 2.3 document in JSON, with
+// each package referencing its package-url and having where it was found as its source info
+func ToSPDX(packages map[string]models.PackageVulns) ([]byte, error) {
+	packageURLs := maps.Keys(packages)
+	slices.Sort(packageURLs)
+
+	// the namespace is derived from the packages, so the same packages always have the same one
+	namespace := sha256.Sum256([]byte(strings.Join(packageURLs, "\n")))
+
+	doc := &v2_3.Document{
+		SPDXVersion:       v2_3.Version,
+		DataLicense:       v2_3.DataLicense,
+		SPDXIdentifier:    common.ElementID("DOCUMENT"),
+		DocumentName:      "osv-scanner",
+		DocumentNamespace: "https://spdx.org/spdxdocs/osv-scanner-" + hex.EncodeToString(namespace[:]),
+		CreationInfo: &v2_3.CreationInfo{
+			Creators: []common.Creator{{CreatorType: "Tool", Creator: "osv-scanner"}},
+			Created:  time.Now().UTC().Format(time.RFC3339),
+		},
+		Packages:      make([]*v2_3.Package, 0, len(packageURLs)),
+		Relationships: make([]*v2_3.Relationship, 0, len(packageURLs)),
+	}
+
+	for _, packageURL := range packageURLs {
+		packageDetail := packages[packageURL]
+		id := spdxIDFromPURL(packageURL)
+
+		doc.Packages = append(doc.Packages, &v2_3.Package{
+			PackageName:               packageDetail.Package.Name,
+			PackageSPDXIdentifier:     id,
+			PackageVersion:            packageDetail.Package.Version,
+			PackageDownloadLocation:   spdxNoAssertion,
+			FilesAnalyzed:             false,
+			IsFilesAnalyzedTagPresent: true,
+			PackageSourceInfo:         spdxSourceInfo(packageDetail),
+			PackageExternalReferences: []*v2_3.PackageExternalReference{
+				{
+					Category: common.CategoryPackageManager,
+					RefType:  common.TypePackageManagerPURL,
+					Locator:  packageURL,
+				},
+			},
+		})
+
+		doc.Relationships = append(doc.Relationships, &v2_3.Relationship{
+			RefA:         common.MakeDocElementID("", "DOCUMENT"),
+			RefB:         common.MakeDocElementID("", string(id)),
+			Relationship: common.TypeRelationshipDescribe,
+		})
+	}
+
+	var buf bytes.Buffer
+
+	if err := spdx_json.Write(doc, &buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,99 @@
+//nolint:nosnakecase
+package sbom_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/osv-scanner/internal/output/sbom"
+	"github.com/google/osv-scanner/pkg/models"
+	spdx_json "github.com/spdx/tools-golang/json"
+	"github.com/spdx/tools-golang/spdxlib"
+)
+
+func TestToSPDX(t *testing.T) {
+	t.Parallel()
+
+	packages := map[string]models.PackageVulns{
+		"pkg:npm/left-pad@1.3.0": {
+			Package: models.PackageInfo{
+				Name:      "left-pad",
+				Version:   "1.3.0",
+				Ecosystem: "npm",
+			},
+			Locations: []models.PackageLocations{
+				{Block: models.PackageLocation{Filename: "/dir/package-lock.json", LineStart: 10, LineEnd: 14}},
+				{Block: models.PackageLocation{Filename: "/dir2/yarn.lock", LineStart: 3, LineEnd: 3}},
+			},
+		},
+		"pkg:golang/github.com/BurntSushi/toml@1.0.0": {
+			Package: models.PackageInfo{
+				Name:      "github.com/BurntSushi/toml",
+				Version:   "1.0.0",
+				Ecosystem: "Go",
+			},
+		},
+	}
+
+	b, err := sbom.ToSPDX(packages)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	doc, err := spdx_json.Read(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("Could not read the SPDX document: %v", err)
+	}
+
+	if err := spdxlib.ValidateDocument(doc); err != nil {
+		t.Errorf("Expected the SPDX document to be valid, but got %v", err)
+	}
+
+	described, err := spdxlib.GetDescribedPackageIDs(doc)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if len(described) != 2 {
+		t.Errorf("Expected the document to describe 2 packages, but it described %d", len(described))
+	}
+
+	if len(doc.Packages) != 2 {
+		t.Fatalf("Expected the document to have 2 packages, but got %d", len(doc.Packages))
+	}
+
+	// the packages are sorted by their package-url
+	pkg := doc.Packages[1]
+
+	if pkg.PackageName != "left-pad" || pkg.PackageVersion != "1.3.0" {
+		t.Errorf("Expected the package to be left-pad@1.3.0, but got %s@%s", pkg.PackageName, pkg.PackageVersion)
+	}
+
+	if len(pkg.PackageExternalReferences) != 1 || pkg.PackageExternalReferences[0].Locator != "pkg:npm/left-pad@1.3.0" {
+		t.Errorf("Expected the package to reference its purl, but got %v", pkg.PackageExternalReferences)
+	}
+
+	if want := "found in /dir/package-lock.json:10-14, /dir2/yarn.lock:3"; pkg.PackageSourceInfo != want {
+		t.Errorf("Expected the source info to be %q, but got %q", want, pkg.PackageSourceInfo)
+	}
+
+	again, err := sbom.ToSPDX(packages)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	doc2, err := spdx_json.Read(bytes.NewReader(again))
+	if err != nil {
+		t.Fatalf("Could not read the SPDX document: %v", err)
+	}
+
+	for i := range doc.Packages {
+		if doc.Packages[i].PackageSPDXIdentifier != doc2.Packages[i].PackageSPDXIdentifier {
+			t.Errorf(
+				"Expected the SPDX identifiers to be the same each time, but got %s and %s",
+				doc.Packages[i].PackageSPDXIdentifier,
+				doc2.Packages[i].PackageSPDXIdentifier,
+			)
+		}
+	}
+}
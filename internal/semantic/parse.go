@@ -50,6 +50,18 @@ func Parse(str string, ecosystem models.Ecosystem) (Version, error) {
 		return parseSemverVersion(str), nil
 	case "CRAN":
 		return parseCRANVersion(str), nil
+	case "CocoaPods":
+		return parseSemverVersion(str), nil
+	case "Hackage":
+		return parseSemverVersion(str), nil
+	case "Terraform":
+		return parseSemverVersion(str), nil
+	case "Helm":
+		return parseSemverVersion(str), nil
+	case "SwiftURL":
+		return parseSemverVersion(str), nil
+	case "Nix":
+		return parseSemverVersion(str), nil
 	}
 
 	return nil, fmt.Errorf("%w %s", ErrUnsupportedEcosystem, ecosystem)
@@ -1,6 +1,8 @@
 package fileposition
 
 import (
+	"unicode/utf8"
+
 	"github.com/google/osv-scanner/internal/cachedregexp"
 )
 
@@ -9,7 +11,7 @@ var wordRe = cachedregexp.MustCompile(`[^\s\r\n]+`)
 func GetFirstNonEmptyCharacterIndexInLine(line string) int {
 	firstWord := wordRe.FindStringIndex(line)
 	if firstWord != nil {
-		return firstWord[0] + 1
+		return ColumnOfByteIndex(line, firstWord[0])
 	}
 
 	return -1
@@ -20,8 +22,14 @@ func GetLastNonEmptyCharacterIndexInLine(line string) int {
 	if words != nil {
 		lastWord := words[len(words)-1]
 
-		return lastWord[1] + 1
+		return ColumnOfByteIndex(line, lastWord[1])
 	}
 
 	return -1
 }
+
+// ColumnOfByteIndex returns the 1-based column of the given byte index in the line,
+// counting in runes so that it matches what is shown by editors for multi-byte characters
+func ColumnOfByteIndex(line string, index int) int {
+	return utf8.RuneCountInString(line[:index]) + 1
+}
@@ -29,6 +29,10 @@ func TestGetFirstNonEmptyCharacterIndexInLine(t *testing.T) {
 			line:  "			with tabs",
 			index: 4,
 		},
+		{
+			line:  " ü multi-byte characters",
+			index: 2,
+		},
 		{
 			line:  "",
 			index: -1,
@@ -63,6 +67,10 @@ func TestGetLastNonEmptyCharacterIndexInLine(t *testing.T) {
 			line:  "  abc  ",
 			index: 6,
 		},
+		{
+			line:  "äbç  ",
+			index: 4,
+		},
 		{
 			line:  "",
 			index: -1,
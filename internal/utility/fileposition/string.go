@@ -3,6 +3,7 @@ package fileposition
 import (
 	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/google/osv-scanner/internal/cachedregexp"
 
@@ -18,8 +19,8 @@ func BytesToLines(data []byte) []string {
 }
 
 func extractPositionFromLine(linePosition int, line string, str string) *models.FilePosition {
-	columnStart := strings.Index(line, str) + 1
-	columnEnd := columnStart + len(str)
+	columnStart := ColumnOfByteIndex(line, strings.Index(line, str))
+	columnEnd := columnStart + utf8.RuneCountInString(str)
 
 	return &models.FilePosition{
 		Line:   models.Position{Start: linePosition, End: linePosition},
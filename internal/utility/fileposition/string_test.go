@@ -22,6 +22,10 @@ func TestBytesToLines(t *testing.T) {
 			data:  []byte("1\n2\n3"),
 			lines: []string{"1", "2", "3"},
 		},
+		{
+			data:  []byte("1\r\n2\r\n3\r\n"),
+			lines: []string{"1", "2", "3", ""},
+		},
 	}
 
 	for _, tt := range testCases {
@@ -29,6 +33,26 @@ func TestBytesToLines(t *testing.T) {
 	}
 }
 
+func TestExtractStringPositionInBlock_NameAndVersionOnSameLine(t *testing.T) {
+	t.Parallel()
+
+	block := []string{"require (", "\tgithub.com/BurntSushi/toml v1.0.0", ")"}
+
+	name := ExtractStringPositionInBlock(block, "github.com/BurntSushi/toml", 1)
+	version := ExtractStringPositionInBlock(block, "v1.0.0", 1)
+
+	// both end columns are exclusive, so the single space between
+	// the name and the version is the column that the name ends at
+	assert.Equal(t, &models.FilePosition{
+		Line:   models.Position{Start: 2, End: 2},
+		Column: models.Position{Start: 2, End: 28},
+	}, name)
+	assert.Equal(t, &models.FilePosition{
+		Line:   models.Position{Start: 2, End: 2},
+		Column: models.Position{Start: 29, End: 35},
+	}, version)
+}
+
 func TestExtractDelimitedStringPositionInBlock(t *testing.T) {
 	t.Parallel()
 
@@ -74,6 +98,15 @@ func TestExtractDelimitedStringPositionInBlock(t *testing.T) {
 			suffix:         "l",
 			position:       nil,
 		},
+		{
+			block:          []string{"äbcdéf", "ghïjkl", "mnöpqr"},
+			str:            "jk",
+			blockStartLine: 1,
+			position: &models.FilePosition{
+				Line:   models.Position{Start: 2, End: 2},
+				Column: models.Position{Start: 4, End: 6},
+			},
+		},
 	}
 
 	for _, tt := range testCases {
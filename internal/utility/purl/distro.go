@@ -0,0 +1,17 @@
+package purl
+
+import (
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// FromDebian returns the parameters of the package-url of a Debian package,
+// whose namespace is the vendor of the distribution as required for "deb"
+func FromDebian(packageInfo models.PackageInfo) (namespace string, name string, err error) {
+	return "debian", packageInfo.Name, nil
+}
+
+// FromAlpine returns the parameters of the package-url of an Alpine package,
+// whose namespace is the vendor of the distribution as required for "apk"
+func FromAlpine(packageInfo models.PackageInfo) (namespace string, name string, err error) {
+	return "alpine", packageInfo.Name, nil
+}
@@ -0,0 +1,26 @@
+package purl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// FromNpm returns the namespace and name of the package-url of an npm package,
+// which for scoped packages like "@angular/core" are the scope and the name
+// within it, so that the "/" between them is not encoded as part of the name
+func FromNpm(packageInfo models.PackageInfo) (namespace string, name string, err error) {
+	if !strings.HasPrefix(packageInfo.Name, "@") {
+		return "", packageInfo.Name, nil
+	}
+
+	namespace, name, found := strings.Cut(packageInfo.Name, "/")
+	if !found || namespace == "@" || name == "" {
+		err = fmt.Errorf("invalid scoped npm package name (%s)", packageInfo.Name)
+
+		return
+	}
+
+	return
+}
@@ -0,0 +1,116 @@
+package purl_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/internal/utility/purl"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestNpmExtraction_shouldExtractPackages(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name              string
+		packageInfo       models.PackageInfo
+		expectedNamespace string
+		expectedName      string
+		expectedPURL      string
+	}{
+		{
+			name: "when_package_is_not_scoped",
+			packageInfo: models.PackageInfo{
+				Name:      "wrappy",
+				Version:   "1.0.2",
+				Ecosystem: string(models.EcosystemNPM),
+			},
+			expectedNamespace: "",
+			expectedName:      "wrappy",
+			expectedPURL:      "pkg:npm/wrappy@1.0.2",
+		},
+		{
+			name: "when_package_is_scoped",
+			packageInfo: models.PackageInfo{
+				Name:      "@angular/core",
+				Version:   "17.3.0",
+				Ecosystem: string(models.EcosystemNPM),
+			},
+			expectedNamespace: "@angular",
+			expectedName:      "core",
+			expectedPURL:      "pkg:npm/%40angular/core@17.3.0",
+		},
+	}
+
+	for _, test := range testCases {
+		testCase := test
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			namespace, name, err := purl.FromNpm(testCase.packageInfo)
+
+			if err != nil {
+				t.Errorf("Extraction didn't succeed, package has been wrongfully filtered")
+			}
+			if namespace != testCase.expectedNamespace {
+				t.Errorf("got %s; want %s", namespace, testCase.expectedNamespace)
+			}
+			if name != testCase.expectedName {
+				t.Errorf("got %s; want %s", name, testCase.expectedName)
+			}
+
+			packageURL, err := purl.From(testCase.packageInfo)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := packageURL.ToString(); got != testCase.expectedPURL {
+				t.Errorf("got %s; want %s", got, testCase.expectedPURL)
+			}
+
+			roundTripped, err := models.PURLToPackage(packageURL.ToString())
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if roundTripped.Name != testCase.packageInfo.Name {
+				t.Errorf("got %s; want %s", roundTripped.Name, testCase.packageInfo.Name)
+			}
+		})
+	}
+}
+
+func TestNpmExtraction_shouldFilterPackages(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		packageInfo models.PackageInfo
+	}{
+		{
+			name: "when_scoped_package_has_no_name",
+			packageInfo: models.PackageInfo{
+				Name:      "@angular",
+				Version:   "17.3.0",
+				Ecosystem: string(models.EcosystemNPM),
+			},
+		},
+		{
+			name: "when_scoped_package_has_no_scope",
+			packageInfo: models.PackageInfo{
+				Name:      "@/core",
+				Version:   "17.3.0",
+				Ecosystem: string(models.EcosystemNPM),
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		testCase := test
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, err := purl.FromNpm(testCase.packageInfo)
+
+			if err == nil {
+				t.Errorf("Package %v should have been filtered\n", testCase.packageInfo)
+			}
+		})
+	}
+}
@@ -26,7 +26,20 @@ func Group(packageSources []models.PackageSource) (map[string]models.PackageVuln
 			if packageExists {
 				// Entry already exists, we need to merge slices which are not expected to be the exact same
 				packageVulns.DepGroups = append(packageVulns.DepGroups, pkg.DepGroups...)
+				// a package is only transitive if it is not directly required by any of its sources
+				packageVulns.Transitive = packageVulns.Transitive && pkg.Transitive
 				packageVulns.Locations = append(packageVulns.Locations, pkg.Locations...)
+				// the same version should have the same hashes, but lockfiles can list
+				// only those of the artifacts relevant to them, so they are combined
+				packageVulns.Hashes = append(packageVulns.Hashes, pkg.Hashes...)
+				slices.Sort(packageVulns.Hashes)
+				packageVulns.Hashes = slices.Compact(packageVulns.Hashes)
+				if packageVulns.Package.Commit == "" {
+					packageVulns.Package.Commit = pkg.Package.Commit
+				}
+				if packageVulns.RegistryURL == "" {
+					packageVulns.RegistryURL = pkg.RegistryURL
+				}
 				if packageVulns.Metadata == nil {
 					packageVulns.Metadata = pkg.Metadata
 				} else {
@@ -40,6 +53,9 @@ func Group(packageSources []models.PackageSource) (map[string]models.PackageVuln
 					Package:           pkg.Package,
 					Locations:         slices.Clone(pkg.Locations),
 					DepGroups:         slices.Clone(pkg.DepGroups),
+					Transitive:        pkg.Transitive,
+					Hashes:            slices.Clone(pkg.Hashes),
+					RegistryURL:       pkg.RegistryURL,
 					Vulnerabilities:   slices.Clone(pkg.Vulnerabilities),
 					Groups:            slices.Clone(pkg.Groups),
 					Licenses:          slices.Clone(pkg.Licenses),
@@ -232,3 +232,523 @@ func TestGroupPackageByPURL_ShouldReportDependencyAsDirect(t *testing.T) {
 		}
 	}
 }
+
+func TestGroupPackageByPURL_ShouldCarryCommitForward(t *testing.T) {
+	t.Parallel()
+	input := []models.PackageSource{
+		{
+			Source: models.SourceInfo{
+				Path: "/dir/go.mod",
+				Type: "",
+			},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{
+						Name:      "golang.org/x/sys",
+						Version:   "0.0.0-20210630005230-0f9fa26af87c",
+						Ecosystem: string(lockfile.GoEcosystem),
+					},
+				},
+			},
+		},
+		{
+			Source: models.SourceInfo{
+				Path: "/dir2/go.mod",
+				Type: "",
+			},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{
+						Name:      "golang.org/x/sys",
+						Version:   "0.0.0-20210630005230-0f9fa26af87c",
+						Ecosystem: string(lockfile.GoEcosystem),
+						Commit:    "0f9fa26af87c",
+					},
+				},
+			},
+		},
+	}
+
+	result, errors := purl.Group(input)
+
+	expected := map[string]models.PackageVulns{
+		"pkg:golang/golang.org/x/sys@0.0.0-20210630005230-0f9fa26af87c": {
+			Package: models.PackageInfo{
+				Name:      "golang.org/x/sys",
+				Version:   "0.0.0-20210630005230-0f9fa26af87c",
+				Ecosystem: string(lockfile.GoEcosystem),
+				Commit:    "0f9fa26af87c",
+			},
+		},
+	}
+	if len(errors) > 0 {
+		t.Errorf("Unexpected errors: %v", errors)
+	}
+	if len(result) != len(expected) {
+		t.Errorf("Expected %d packages, got %d", len(expected), len(result))
+	}
+	for expectedPURL, expectedInfo := range expected {
+		info, exists := result[expectedPURL]
+
+		if !exists {
+			t.Errorf("Expected package %s to be in the results", expectedPURL)
+		}
+		if !reflect.DeepEqual(info, expectedInfo) {
+			t.Errorf("Expected package %s to be %v, got %v", expectedPURL, expectedInfo, info)
+		}
+	}
+}
+
+func TestGroupPackageByPURL_ShouldGroupGoStdlibByVersion(t *testing.T) {
+	t.Parallel()
+	input := []models.PackageSource{
+		{
+			Source: models.SourceInfo{
+				Path: "/dir/go.mod",
+				Type: "lockfile",
+			},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{
+						Name:      "stdlib",
+						Version:   "1.20",
+						Ecosystem: string(lockfile.GoEcosystem),
+					},
+					Locations: []models.PackageLocations{
+						{Block: models.PackageLocation{Filename: "/dir/go.mod", LineStart: 3, LineEnd: 3}},
+					},
+				},
+			},
+		},
+		{
+			Source: models.SourceInfo{
+				Path: "/dir2/go.mod",
+				Type: "lockfile",
+			},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{
+						Name:      "stdlib",
+						Version:   "1.21.5",
+						Ecosystem: string(lockfile.GoEcosystem),
+					},
+					Locations: []models.PackageLocations{
+						{Block: models.PackageLocation{Filename: "/dir2/go.mod", LineStart: 3, LineEnd: 3}},
+					},
+				},
+			},
+		},
+		{
+			Source: models.SourceInfo{
+				Path: "/dir3/go.mod",
+				Type: "lockfile",
+			},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{
+						Name:      "stdlib",
+						Version:   "1.20",
+						Ecosystem: string(lockfile.GoEcosystem),
+					},
+					Locations: []models.PackageLocations{
+						{Block: models.PackageLocation{Filename: "/dir3/go.mod", LineStart: 5, LineEnd: 5}},
+					},
+				},
+			},
+		},
+	}
+
+	result, errors := purl.Group(input)
+
+	expected := map[string]models.PackageVulns{
+		"pkg:golang/stdlib@1.20": {
+			Package: models.PackageInfo{
+				Name:      "stdlib",
+				Version:   "1.20",
+				Ecosystem: string(lockfile.GoEcosystem),
+			},
+			Locations: []models.PackageLocations{
+				{Block: models.PackageLocation{Filename: "/dir/go.mod", LineStart: 3, LineEnd: 3}},
+				{Block: models.PackageLocation{Filename: "/dir3/go.mod", LineStart: 5, LineEnd: 5}},
+			},
+		},
+		"pkg:golang/stdlib@1.21.5": {
+			Package: models.PackageInfo{
+				Name:      "stdlib",
+				Version:   "1.21.5",
+				Ecosystem: string(lockfile.GoEcosystem),
+			},
+			Locations: []models.PackageLocations{
+				{Block: models.PackageLocation{Filename: "/dir2/go.mod", LineStart: 3, LineEnd: 3}},
+			},
+		},
+	}
+	if len(errors) > 0 {
+		t.Errorf("Unexpected errors: %v", errors)
+	}
+	if len(result) != len(expected) {
+		t.Errorf("Expected %d packages, got %d", len(expected), len(result))
+	}
+	for expectedPURL, expectedInfo := range expected {
+		info, exists := result[expectedPURL]
+
+		if !exists {
+			t.Errorf("Expected package %s to be in the results", expectedPURL)
+		}
+		if !reflect.DeepEqual(info, expectedInfo) {
+			t.Errorf("Expected package %s to be %v, got %v", expectedPURL, expectedInfo, info)
+		}
+	}
+}
+
+func TestGroupPackageByPURL_ShouldCarryLicenseForward(t *testing.T) {
+	t.Parallel()
+	input := []models.PackageSource{
+		{
+			Source: models.SourceInfo{
+				Path: "/dir/package-lock.json",
+				Type: "lockfile",
+			},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{
+						Name:      "lodash",
+						Version:   "4.17.21",
+						Ecosystem: string(lockfile.NpmEcosystem),
+					},
+					Metadata: map[models.PackageMetadataType]string{
+						models.PackageManagerMetadata: "NPM",
+					},
+				},
+			},
+		},
+		{
+			Source: models.SourceInfo{
+				Path: "/dir2/package-lock.json",
+				Type: "lockfile",
+			},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{
+						Name:      "lodash",
+						Version:   "4.17.21",
+						Ecosystem: string(lockfile.NpmEcosystem),
+					},
+					Metadata: map[models.PackageMetadataType]string{
+						models.PackageManagerMetadata: "NPM",
+						models.LicenseMetadata:        "MIT",
+					},
+				},
+			},
+		},
+	}
+
+	result, errors := purl.Group(input)
+
+	expected := map[string]models.PackageVulns{
+		"pkg:npm/lodash@4.17.21": {
+			Package: models.PackageInfo{
+				Name:      "lodash",
+				Version:   "4.17.21",
+				Ecosystem: string(lockfile.NpmEcosystem),
+			},
+			Metadata: map[models.PackageMetadataType]string{
+				models.PackageManagerMetadata: "NPM",
+				models.LicenseMetadata:        "MIT",
+			},
+		},
+	}
+	if len(errors) > 0 {
+		t.Errorf("Unexpected errors: %v", errors)
+	}
+	if len(result) != len(expected) {
+		t.Errorf("Expected %d packages, got %d", len(expected), len(result))
+	}
+	for expectedPURL, expectedInfo := range expected {
+		info, exists := result[expectedPURL]
+
+		if !exists {
+			t.Errorf("Expected package %s to be in the results", expectedPURL)
+		}
+		if !reflect.DeepEqual(info, expectedInfo) {
+			t.Errorf("Expected package %s to be %v, got %v", expectedPURL, expectedInfo, info)
+		}
+	}
+}
+
+func TestGroupPackageByPURL_ShouldCombineHashes(t *testing.T) {
+	t.Parallel()
+	input := []models.PackageSource{
+		{
+			Source: models.SourceInfo{
+				Path: "/dir/Pipfile.lock",
+				Type: "lockfile",
+			},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{
+						Name:      "markupsafe",
+						Version:   "2.1.1",
+						Ecosystem: string(lockfile.PipEcosystem),
+					},
+					Hashes: []string{
+						"sha256:0212a68688482dc52b2d45013df70d169f542b7394fc744c02a57374a4207003",
+						"sha256:089cf3dbf0cd6c100f02945abeb18484bd1ee57a079aefd52cffd17fba910b88",
+					},
+				},
+			},
+		},
+		{
+			Source: models.SourceInfo{
+				Path: "/dir2/Pipfile.lock",
+				Type: "lockfile",
+			},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{
+						Name:      "markupsafe",
+						Version:   "2.1.1",
+						Ecosystem: string(lockfile.PipEcosystem),
+					},
+					Hashes: []string{
+						"sha256:089cf3dbf0cd6c100f02945abeb18484bd1ee57a079aefd52cffd17fba910b88",
+						"sha256:10c1bfff05d95783da83491be968e8fe789263689c02724e0c691933c52994f5",
+					},
+				},
+			},
+		},
+	}
+
+	result, errors := purl.Group(input)
+
+	expected := map[string]models.PackageVulns{
+		"pkg:pypi/markupsafe@2.1.1": {
+			Package: models.PackageInfo{
+				Name:      "markupsafe",
+				Version:   "2.1.1",
+				Ecosystem: string(lockfile.PipEcosystem),
+			},
+			Hashes: []string{
+				"sha256:0212a68688482dc52b2d45013df70d169f542b7394fc744c02a57374a4207003",
+				"sha256:089cf3dbf0cd6c100f02945abeb18484bd1ee57a079aefd52cffd17fba910b88",
+				"sha256:10c1bfff05d95783da83491be968e8fe789263689c02724e0c691933c52994f5",
+			},
+		},
+	}
+	if len(errors) > 0 {
+		t.Errorf("Unexpected errors: %v", errors)
+	}
+	if len(result) != len(expected) {
+		t.Errorf("Expected %d packages, got %d", len(expected), len(result))
+	}
+	for expectedPURL, expectedInfo := range expected {
+		info, exists := result[expectedPURL]
+
+		if !exists {
+			t.Errorf("Expected package %s to be in the results", expectedPURL)
+		}
+		if !reflect.DeepEqual(info, expectedInfo) {
+			t.Errorf("Expected package %s to be %v, got %v", expectedPURL, expectedInfo, info)
+		}
+	}
+}
+
+func TestGroupPackageByPURL_ShouldNotUnifyDifferentArchitectures(t *testing.T) {
+	t.Parallel()
+	input := []models.PackageSource{
+		{
+			Source: models.SourceInfo{
+				Path: "/lib/apk/db/installed",
+				Type: "os",
+			},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{
+						Name:         "musl",
+						Version:      "1.2.4-r2",
+						Ecosystem:    "Alpine:v3.18",
+						Architecture: "x86_64",
+					},
+				},
+				{
+					Package: models.PackageInfo{
+						Name:         "musl",
+						Version:      "1.2.4-r2",
+						Ecosystem:    "Alpine:v3.18",
+						Architecture: "aarch64",
+					},
+				},
+			},
+		},
+	}
+
+	result, errors := purl.Group(input)
+
+	expected := map[string]models.PackageVulns{
+		"pkg:apk/alpine/musl@1.2.4-r2?arch=aarch64&distro=alpine-3.18": {
+			Package: models.PackageInfo{
+				Name:         "musl",
+				Version:      "1.2.4-r2",
+				Ecosystem:    "Alpine:v3.18",
+				Architecture: "aarch64",
+			},
+		},
+		"pkg:apk/alpine/musl@1.2.4-r2?arch=x86_64&distro=alpine-3.18": {
+			Package: models.PackageInfo{
+				Name:         "musl",
+				Version:      "1.2.4-r2",
+				Ecosystem:    "Alpine:v3.18",
+				Architecture: "x86_64",
+			},
+		},
+	}
+	if len(errors) > 0 {
+		t.Errorf("Unexpected errors: %v", errors)
+	}
+	if len(result) != len(expected) {
+		t.Errorf("Expected %d packages, got %d", len(expected), len(result))
+	}
+	for expectedPURL, expectedInfo := range expected {
+		info, exists := result[expectedPURL]
+
+		if !exists {
+			t.Errorf("Expected package %s to be in the results", expectedPURL)
+		}
+		if !reflect.DeepEqual(info, expectedInfo) {
+			t.Errorf("Expected package %s to be %v, got %v", expectedPURL, expectedInfo, info)
+		}
+	}
+}
+
+func TestGroupPackageByPURL_ShouldOnlyReportTransitiveIfAllSourcesAgree(t *testing.T) {
+	t.Parallel()
+	text := models.PackageInfo{
+		Name:      "golang.org/x/text",
+		Version:   "0.3.7",
+		Ecosystem: string(lockfile.GoEcosystem),
+	}
+	sys := models.PackageInfo{
+		Name:      "golang.org/x/sys",
+		Version:   "0.5.0",
+		Ecosystem: string(lockfile.GoEcosystem),
+	}
+
+	input := []models.PackageSource{
+		{
+			Source: models.SourceInfo{
+				Path: "/dir/go.mod",
+				Type: "",
+			},
+			Packages: []models.PackageVulns{
+				{Package: text},
+				{Package: sys, Transitive: true},
+			},
+		},
+		{
+			Source: models.SourceInfo{
+				Path: "/dir2/go.mod",
+				Type: "",
+			},
+			Packages: []models.PackageVulns{
+				{Package: text, Transitive: true},
+				{Package: sys, Transitive: true},
+			},
+		},
+	}
+
+	result, errors := purl.Group(input)
+
+	expected := map[string]models.PackageVulns{
+		// it is directly required by the first go.mod
+		"pkg:golang/golang.org/x/text@0.3.7": {
+			Package:    text,
+			Transitive: false,
+		},
+		"pkg:golang/golang.org/x/sys@0.5.0": {
+			Package:    sys,
+			Transitive: true,
+		},
+	}
+	if len(errors) > 0 {
+		t.Errorf("Unexpected errors: %v", errors)
+	}
+	if len(result) != len(expected) {
+		t.Errorf("Expected %d packages, got %d", len(expected), len(result))
+	}
+	for expectedPURL, expectedInfo := range expected {
+		info, exists := result[expectedPURL]
+
+		if !exists {
+			t.Errorf("Expected package %s to be in the results", expectedPURL)
+		}
+		if !reflect.DeepEqual(info, expectedInfo) {
+			t.Errorf("Expected package %s to be %v, got %v", expectedPURL, expectedInfo, info)
+		}
+	}
+}
+
+func TestGroupPackageByPURL_ShouldCarryRegistryURLForward(t *testing.T) {
+	t.Parallel()
+	input := []models.PackageSource{
+		{
+			Source: models.SourceInfo{
+				Path: "/dir/package-lock.json",
+				Type: "",
+			},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{
+						Name:      "wrappy",
+						Version:   "1.0.2",
+						Ecosystem: string(lockfile.NpmEcosystem),
+					},
+				},
+			},
+		},
+		{
+			Source: models.SourceInfo{
+				Path: "/dir2/package-lock.json",
+				Type: "",
+			},
+			Packages: []models.PackageVulns{
+				{
+					Package: models.PackageInfo{
+						Name:      "wrappy",
+						Version:   "1.0.2",
+						Ecosystem: string(lockfile.NpmEcosystem),
+					},
+					RegistryURL: "https://npm.example.com",
+				},
+			},
+		},
+	}
+
+	result, errors := purl.Group(input)
+
+	expected := map[string]models.PackageVulns{
+		"pkg:npm/wrappy@1.0.2": {
+			Package: models.PackageInfo{
+				Name:      "wrappy",
+				Version:   "1.0.2",
+				Ecosystem: string(lockfile.NpmEcosystem),
+			},
+			RegistryURL: "https://npm.example.com",
+		},
+	}
+	if len(errors) > 0 {
+		t.Errorf("Unexpected errors: %v", errors)
+	}
+	if len(result) != len(expected) {
+		t.Errorf("Expected %d packages, got %d", len(expected), len(result))
+	}
+	for expectedPURL, expectedInfo := range expected {
+		info, exists := result[expectedPURL]
+
+		if !exists {
+			t.Errorf("Expected package %s to be in the results", expectedPURL)
+		}
+		if !reflect.DeepEqual(info, expectedInfo) {
+			t.Errorf("Expected package %s to be %v, got %v", expectedPURL, expectedInfo, info)
+		}
+	}
+}
@@ -2,43 +2,66 @@ package purl
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/google/osv-scanner/pkg/lockfile"
 	"github.com/google/osv-scanner/pkg/models"
 	"github.com/package-url/packageurl-go"
 )
 
 type ParameterExtractor func(packageInfo models.PackageInfo) (namespace string, name string, err error)
 
-var EcosystemToPURLMapper = map[models.Ecosystem]string{
-	models.EcosystemMaven:       packageurl.TypeMaven,
-	models.EcosystemGo:          packageurl.TypeGolang,
-	models.EcosystemPackagist:   packageurl.TypeComposer,
-	models.EcosystemPyPI:        packageurl.TypePyPi,
-	models.EcosystemRubyGems:    packageurl.TypeGem,
-	models.EcosystemNuGet:       packageurl.TypeNuget,
-	models.EcosystemNPM:         packageurl.TypeNPM,
-	models.EcosystemConanCenter: packageurl.TypeConan,
-	models.EcosystemCratesIO:    packageurl.TypeCargo,
-	models.EcosystemPub:         "pub",
-	models.EcosystemHex:         packageurl.TypeHex,
-	models.EcosystemCRAN:        packageurl.TypeCran,
-}
-
 var ecosystemPURLExtractor = map[models.Ecosystem]ParameterExtractor{
 	models.EcosystemMaven:     FromMaven,
 	models.EcosystemGo:        FromGo,
 	models.EcosystemPackagist: FromComposer,
+	models.EcosystemNPM:       FromNpm,
+	models.EcosystemDebian:    FromDebian,
+	models.EcosystemAlpine:    FromAlpine,
+	// provider addresses are paths like Go modules, with the type of the provider being their last part
+	models.EcosystemTerraform: FromGo,
+	// so are the repository URLs that Swift packages are named by
+	models.EcosystemSwiftURL: FromGo,
+	// and the repositories that the inputs of Nix flakes are locked to
+	models.EcosystemNix: FromGo,
 }
 
+// qualifiersFor returns the qualifiers of the package-url of the given package,
+// which are the architecture and distribution of the packages of operating systems
+// so that different builds of the same version of a package have different ones
+func qualifiersFor(packageInfo models.PackageInfo) packageurl.Qualifiers {
+	qualifiers := map[string]string{}
+
+	if packageInfo.Architecture != "" {
+		qualifiers["arch"] = packageInfo.Architecture
+	}
+
+	// the release of the ecosystem is the release of the distribution, like "Alpine:v3.18"
+	if base, release, ok := strings.Cut(packageInfo.Ecosystem, ":"); ok && release != "" {
+		qualifiers["distro"] = strings.ToLower(base) + "-" + strings.TrimPrefix(release, "v")
+	}
+
+	if len(qualifiers) == 0 {
+		return nil
+	}
+
+	// the qualifiers are sorted by their keys, so the package-url is always the same
+	return packageurl.QualifiersFromMap(qualifiers)
+}
+
+// From builds the package-url of the given package based on its ecosystem,
+// with the release of the ecosystem if it has one (e.g. "Alpine:v3.18") and
+// the architecture of the package being included as qualifiers
 func From(packageInfo models.PackageInfo) (*packageurl.PackageURL, error) {
 	var namespace string
 	var name string
 	version := packageInfo.Version
-	ecosystem := models.Ecosystem(packageInfo.Ecosystem)
-	purlType, typeExists := EcosystemToPURLMapper[ecosystem]
+	base, _, _ := strings.Cut(packageInfo.Ecosystem, ":")
+	ecosystem := models.Ecosystem(base)
+	purlType := lockfile.Ecosystem(ecosystem).PURLType()
 	parameterExtractor, extractorExists := ecosystemPURLExtractor[ecosystem]
 
-	if !typeExists {
+	if purlType == "" {
 		return nil, fmt.Errorf("unable to determine purl type of %s@%s (%s)", packageInfo.Name, packageInfo.Version, packageInfo.Ecosystem)
 	}
 
@@ -52,5 +75,22 @@ func From(packageInfo models.PackageInfo) (*packageurl.PackageURL, error) {
 		name = packageInfo.Name
 	}
 
-	return packageurl.NewPackageURL(purlType, namespace, name, version, nil, ""), nil
+	return packageurl.NewPackageURL(purlType, namespace, name, version, qualifiersFor(packageInfo), ""), nil
+}
+
+// FromPackageDetails builds the package-url of the given package using its Ecosystem,
+// which is the registry that the package comes from, rather than its CompareAs,
+// which is only how the versions of the package are compared.
+//
+// Packages extracted from lockfiles should have their package-url built with this
+// rather than being converted to a models.PackageInfo, as the lockfile package
+// cannot build them itself without depending on this one.
+func FromPackageDetails(pkg lockfile.PackageDetails) (*packageurl.PackageURL, error) {
+	return From(models.PackageInfo{
+		Name:         pkg.Name,
+		Version:      pkg.Version,
+		Ecosystem:    string(pkg.Ecosystem),
+		Commit:       pkg.Commit,
+		Architecture: pkg.Architecture,
+	})
 }
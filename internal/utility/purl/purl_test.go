@@ -0,0 +1,210 @@
+package purl_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/internal/utility/purl"
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestFrom_EcosystemWithRelease(t *testing.T) {
+	t.Parallel()
+
+	packageURL, err := purl.From(models.PackageInfo{
+		Name:      "busybox",
+		Version:   "1.36.1-r5",
+		Ecosystem: "Alpine:v3.18",
+	})
+
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if got, want := packageURL.String(), "pkg:apk/alpine/busybox@1.36.1-r5?distro=alpine-3.18"; got != want {
+		t.Errorf("got %s; want %s", got, want)
+	}
+}
+
+func TestFrom_Qualifiers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		packageInfo models.PackageInfo
+		want        string
+	}{
+		{
+			name: "architecture and distribution",
+			packageInfo: models.PackageInfo{
+				Name:         "busybox",
+				Version:      "1.36.1-r5",
+				Ecosystem:    "Alpine:v3.18",
+				Architecture: "x86_64",
+			},
+			want: "pkg:apk/alpine/busybox@1.36.1-r5?arch=x86_64&distro=alpine-3.18",
+		},
+		{
+			name: "architecture without a release",
+			packageInfo: models.PackageInfo{
+				Name:         "curl",
+				Version:      "7.88.1-10+deb12u5",
+				Ecosystem:    "Debian",
+				Architecture: "amd64",
+			},
+			want: "pkg:deb/debian/curl@7.88.1-10+deb12u5?arch=amd64",
+		},
+		{
+			name: "release without an architecture",
+			packageInfo: models.PackageInfo{
+				Name:      "curl",
+				Version:   "7.88.1-10+deb12u5",
+				Ecosystem: "Debian:12",
+			},
+			want: "pkg:deb/debian/curl@7.88.1-10+deb12u5?distro=debian-12",
+		},
+		{
+			name: "neither",
+			packageInfo: models.PackageInfo{
+				Name:      "left-pad",
+				Version:   "1.3.0",
+				Ecosystem: "npm",
+			},
+			want: "pkg:npm/left-pad@1.3.0",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			packageURL, err := purl.From(tt.packageInfo)
+
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+
+			// the string is compared to make sure that the qualifiers are always in the same order
+			if got := packageURL.String(); got != tt.want {
+				t.Errorf("got %s; want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFrom_UnknownEcosystem(t *testing.T) {
+	t.Parallel()
+
+	_, err := purl.From(models.PackageInfo{
+		Name:      "my-package",
+		Version:   "1.0.0",
+		Ecosystem: "unknown",
+	})
+
+	if err == nil {
+		t.Errorf("Expected to get an error, but did not")
+	}
+}
+
+func TestFromPackageDetails_UsesEcosystemRatherThanCompareAs(t *testing.T) {
+	t.Parallel()
+
+	packageURL, err := purl.FromPackageDetails(lockfile.PackageDetails{
+		Name:      "busybox",
+		Version:   "1:1.36.1",
+		Ecosystem: lockfile.AlpineEcosystem,
+		CompareAs: lockfile.DebianEcosystem,
+	})
+
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if got, want := packageURL.String(), "pkg:apk/alpine/busybox@1:1.36.1"; got != want {
+		t.Errorf("got %s; want %s", got, want)
+	}
+}
+
+func TestFromPackageDetails_KnownEcosystems(t *testing.T) {
+	t.Parallel()
+
+	tests := map[lockfile.Ecosystem]struct {
+		name string
+		want string
+	}{
+		lockfile.NpmEcosystem:       {name: "@angular/core", want: "pkg:npm/%40angular/core@1.2.3"},
+		lockfile.NuGetEcosystem:     {name: "Newtonsoft.Json", want: "pkg:nuget/Newtonsoft.Json@1.2.3"},
+		lockfile.CargoEcosystem:     {name: "serde", want: "pkg:cargo/serde@1.2.3"},
+		lockfile.BundlerEcosystem:   {name: "rails", want: "pkg:gem/rails@1.2.3"},
+		lockfile.ComposerEcosystem:  {name: "symfony/yaml", want: "pkg:composer/symfony/yaml@1.2.3"},
+		lockfile.GoEcosystem:        {name: "github.com/google/uuid", want: "pkg:golang/github.com/google/uuid@1.2.3"},
+		lockfile.MixEcosystem:       {name: "plug", want: "pkg:hex/plug@1.2.3"},
+		lockfile.MavenEcosystem:     {name: "org.apache.commons:commons-lang3", want: "pkg:maven/org.apache.commons/commons-lang3@1.2.3"},
+		lockfile.PipEcosystem:       {name: "django", want: "pkg:pypi/django@1.2.3"},
+		lockfile.PubEcosystem:       {name: "http", want: "pkg:pub/http@1.2.3"},
+		lockfile.ConanEcosystem:     {name: "zlib", want: "pkg:conan/zlib@1.2.3"},
+		lockfile.CRANEcosystem:      {name: "ggplot2", want: "pkg:cran/ggplot2@1.2.3"},
+		lockfile.CocoaPodsEcosystem: {name: "Alamofire", want: "pkg:cocoapods/Alamofire@1.2.3"},
+		lockfile.HackageEcosystem:   {name: "aeson", want: "pkg:hackage/aeson@1.2.3"},
+		lockfile.TerraformEcosystem: {name: "registry.terraform.io/hashicorp/aws", want: "pkg:terraform/registry.terraform.io/hashicorp/aws@1.2.3"},
+		lockfile.HelmEcosystem:      {name: "postgresql", want: "pkg:helm/postgresql@1.2.3"},
+		lockfile.SwiftURLEcosystem:  {name: "github.com/apple/swift-log", want: "pkg:swift/github.com/apple/swift-log@1.2.3"},
+		lockfile.NixEcosystem:       {name: "NixOS/nixpkgs", want: "pkg:nix/NixOS/nixpkgs@1.2.3"},
+	}
+
+	for _, ecosystem := range lockfile.KnownEcosystems() {
+		tt, ok := tests[ecosystem]
+
+		if !ok {
+			t.Errorf("Ecosystem %s does not have a test case", ecosystem)
+
+			continue
+		}
+
+		packageURL, err := purl.FromPackageDetails(lockfile.PackageDetails{
+			Name:      tt.name,
+			Version:   "1.2.3",
+			Ecosystem: ecosystem,
+			CompareAs: ecosystem,
+		})
+
+		if err != nil {
+			t.Errorf("%s: got unexpected error: %v", ecosystem, err)
+
+			continue
+		}
+
+		if got := packageURL.String(); got != tt.want {
+			t.Errorf("%s: got %s; want %s", ecosystem, got, tt.want)
+		}
+
+		if got, want := packageURL.Type, ecosystem.PURLType(); got != want {
+			t.Errorf("%s: got type %s; want %s", ecosystem, got, want)
+		}
+	}
+}
+
+func TestFrom_DistroRoundTrips(t *testing.T) {
+	t.Parallel()
+
+	for _, packageInfo := range []models.PackageInfo{
+		{Name: "busybox", Version: "1.36.1-r5", Ecosystem: string(models.EcosystemAlpine)},
+		{Name: "curl", Version: "7.88.1-10+deb12u5", Ecosystem: string(models.EcosystemDebian)},
+	} {
+		packageURL, err := purl.From(packageInfo)
+
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+
+		got, err := models.PURLToPackage(packageURL.String())
+
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+
+		if got != packageInfo {
+			t.Errorf("got %v; want %v", got, packageInfo)
+		}
+	}
+}
@@ -50,7 +50,7 @@ func rangeContainsVersion(ar models.Range, pkg lockfile.PackageDetails) bool {
 		return false
 	}
 
-	vp := semantic.MustParse(pkg.Version, convertLockfileEcosystem(pkg.CompareAs))
+	vp := semantic.MustParse(pkg.VersionToCompare(), convertLockfileEcosystem(pkg.CompareAs))
 
 	sort.Slice(ar.Events, func(i, j int) bool {
 		a := ar.Events[i]
@@ -73,7 +73,7 @@ func rangeContainsVersion(ar models.Range, pkg lockfile.PackageDetails) bool {
 			if e.Fixed != "" {
 				affected = vp.CompareStr(e.Fixed) < 0
 			} else if e.LastAffected != "" {
-				affected = e.LastAffected == pkg.Version || vp.CompareStr(e.LastAffected) <= 0
+				affected = e.LastAffected == pkg.VersionToCompare() || vp.CompareStr(e.LastAffected) <= 0
 			}
 		} else if e.Introduced != "" {
 			affected = e.Introduced == "0" || vp.CompareStr(e.Introduced) >= 0
@@ -155,7 +155,7 @@ func IsAffected(v models.Vulnerability, pkg lockfile.PackageDetails) bool {
 				continue
 			}
 
-			if slices.Contains(affected.Versions, pkg.Version) {
+			if slices.Contains(affected.Versions, pkg.VersionToCompare()) {
 				return true
 			}
 
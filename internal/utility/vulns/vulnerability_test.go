@@ -730,3 +730,38 @@ func TestOSV_EcosystemsWithSuffix(t *testing.T) {
 		t.Errorf("Expected OSV to affect package version %s but it did not", "0.0.0")
 	}
 }
+
+func TestOSV_IsAffected_ComparesUsingCompareAs(t *testing.T) {
+	t.Parallel()
+
+	vuln := buildOSVWithAffected(
+		models.Affected{
+			Package: models.Package{Ecosystem: models.EcosystemAlpine, Name: "my-package"},
+			Ranges: []models.Range{
+				buildEcosystemAffectsRange(
+					models.Event{Introduced: "0"},
+					models.Event{Fixed: "2.0"},
+				),
+			},
+		},
+	)
+
+	// the epoch makes this version greater than the fixed version when
+	// compared as a Debian version, but not when compared as an Alpine one
+	pkg := lockfile.PackageDetails{
+		Name:      "my-package",
+		Version:   "1:0.5",
+		Ecosystem: lockfile.AlpineEcosystem,
+		CompareAs: lockfile.DebianEcosystem,
+	}
+
+	if vulns.IsAffected(vuln, pkg) {
+		t.Errorf("Expected OSV not to affect package version %s when compared as Debian but it did", pkg.Version)
+	}
+
+	pkg.CompareAs = lockfile.AlpineEcosystem
+
+	if !vulns.IsAffected(vuln, pkg) {
+		t.Errorf("Expected OSV to affect package version %s when compared as Alpine but it did not", pkg.Version)
+	}
+}
@@ -0,0 +1,85 @@
+package grouper
+
+import (
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// PackageVersionChange is a package whose version is different between two results,
+// with the PURLs and details of the package in each so that their locations are known
+type PackageVersionChange struct {
+	OldPURL string
+	Old     models.PackageDetails
+	NewPURL string
+	New     models.PackageDetails
+}
+
+// PackageDiff is the difference between the packages of two results, keyed by their PURL
+type PackageDiff struct {
+	Added   map[string]models.PackageDetails
+	Removed map[string]models.PackageDetails
+	// Changed is keyed by the PURL of the package in the new results
+	Changed map[string]PackageVersionChange
+}
+
+// packageDiffKey returns the key that packages are paired by when their version has changed,
+// which is what identifies the package regardless of its version
+func packageDiffKey(pkg models.PackageDetails) string {
+	return pkg.Ecosystem + "/" + pkg.Name
+}
+
+// Diff compares the packages of two results, which are keyed by their PURL like those
+// grouped by purl.Group, returning the packages that were added, removed, or had their
+// version changed, with each package keeping its locations in the results it is from.
+//
+// A package that was removed is paired with one that was added as a version change only
+// when there is just one version of it on each side, as otherwise it cannot be known
+// which of the versions was changed to which, so they are reported as added and removed.
+func Diff(oldPackages, newPackages map[string]models.PackageDetails) PackageDiff {
+	diff := PackageDiff{
+		Added:   map[string]models.PackageDetails{},
+		Removed: map[string]models.PackageDetails{},
+		Changed: map[string]PackageVersionChange{},
+	}
+
+	for purl, pkg := range oldPackages {
+		if _, ok := newPackages[purl]; !ok {
+			diff.Removed[purl] = pkg
+		}
+	}
+
+	for purl, pkg := range newPackages {
+		if _, ok := oldPackages[purl]; !ok {
+			diff.Added[purl] = pkg
+		}
+	}
+
+	removedByKey := make(map[string][]string)
+	for purl, pkg := range diff.Removed {
+		removedByKey[packageDiffKey(pkg)] = append(removedByKey[packageDiffKey(pkg)], purl)
+	}
+
+	addedByKey := make(map[string][]string)
+	for purl, pkg := range diff.Added {
+		addedByKey[packageDiffKey(pkg)] = append(addedByKey[packageDiffKey(pkg)], purl)
+	}
+
+	for key, added := range addedByKey {
+		removed := removedByKey[key]
+
+		if len(added) != 1 || len(removed) != 1 {
+			continue
+		}
+
+		diff.Changed[added[0]] = PackageVersionChange{
+			OldPURL: removed[0],
+			Old:     diff.Removed[removed[0]],
+			NewPURL: added[0],
+			New:     diff.Added[added[0]],
+		}
+
+		delete(diff.Removed, removed[0])
+		delete(diff.Added, added[0])
+	}
+
+	return diff
+}
@@ -0,0 +1,75 @@
+package grouper_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/pkg/grouper"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	location := func(line int) []models.PackageLocations {
+		return []models.PackageLocations{
+			{Block: models.PackageLocation{Filename: "/path/to/package-lock.json", LineStart: line, LineEnd: line + 4}},
+		}
+	}
+
+	oldPackages := map[string]models.PackageDetails{
+		"pkg:npm/wrappy@1.0.2": {Name: "wrappy", Version: "1.0.2", Ecosystem: "npm", Locations: location(10)},
+		"pkg:npm/once@1.4.0":   {Name: "once", Version: "1.4.0", Ecosystem: "npm", Locations: location(20)},
+		"pkg:npm/debug@2.6.9":  {Name: "debug", Version: "2.6.9", Ecosystem: "npm", Locations: location(30)},
+		"pkg:npm/ms@2.0.0":     {Name: "ms", Version: "2.0.0", Ecosystem: "npm", Locations: location(40)},
+	}
+
+	newPackages := map[string]models.PackageDetails{
+		"pkg:npm/wrappy@1.0.2":  {Name: "wrappy", Version: "1.0.2", Ecosystem: "npm", Locations: location(10)},
+		"pkg:npm/eslint@8.57.0": {Name: "eslint", Version: "8.57.0", Ecosystem: "npm", Locations: location(50)},
+		"pkg:npm/debug@4.3.4":   {Name: "debug", Version: "4.3.4", Ecosystem: "npm", Locations: location(30)},
+		// there are two versions of ms, so which of them the old version was changed to is not known
+		"pkg:npm/ms@2.1.2": {Name: "ms", Version: "2.1.2", Ecosystem: "npm", Locations: location(40)},
+		"pkg:npm/ms@2.1.3": {Name: "ms", Version: "2.1.3", Ecosystem: "npm", Locations: location(60)},
+	}
+
+	want := grouper.PackageDiff{
+		Added: map[string]models.PackageDetails{
+			"pkg:npm/eslint@8.57.0": newPackages["pkg:npm/eslint@8.57.0"],
+			"pkg:npm/ms@2.1.2":      newPackages["pkg:npm/ms@2.1.2"],
+			"pkg:npm/ms@2.1.3":      newPackages["pkg:npm/ms@2.1.3"],
+		},
+		Removed: map[string]models.PackageDetails{
+			"pkg:npm/once@1.4.0": oldPackages["pkg:npm/once@1.4.0"],
+			"pkg:npm/ms@2.0.0":   oldPackages["pkg:npm/ms@2.0.0"],
+		},
+		Changed: map[string]grouper.PackageVersionChange{
+			"pkg:npm/debug@4.3.4": {
+				OldPURL: "pkg:npm/debug@2.6.9",
+				Old:     oldPackages["pkg:npm/debug@2.6.9"],
+				NewPURL: "pkg:npm/debug@4.3.4",
+				New:     newPackages["pkg:npm/debug@4.3.4"],
+			},
+		},
+	}
+
+	got := grouper.Diff(oldPackages, newPackages)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Diff() returned an unexpected result (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiff_Empty(t *testing.T) {
+	t.Parallel()
+
+	packages := map[string]models.PackageDetails{
+		"pkg:npm/wrappy@1.0.2": {Name: "wrappy", Version: "1.0.2", Ecosystem: "npm"},
+	}
+
+	got := grouper.Diff(packages, packages)
+
+	if len(got.Added) != 0 || len(got.Removed) != 0 || len(got.Changed) != 0 {
+		t.Errorf("Expected no differences, but got %+v", got)
+	}
+}
@@ -0,0 +1,124 @@
+package grouper
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// sameLocation checks if the two package locations are for the same place,
+// comparing the locations that they point to rather than the pointers
+func sameLocation(a, b models.PackageLocations) bool {
+	samePointer := func(x, y *models.PackageLocation) bool {
+		if x == nil || y == nil {
+			return x == y
+		}
+
+		return *x == *y
+	}
+
+	return a.Block == b.Block &&
+		samePointer(a.Namespace, b.Namespace) &&
+		samePointer(a.Name, b.Name) &&
+		samePointer(a.Version, b.Version)
+}
+
+// unionSorted returns the values that are in either of the slices, sorted and without duplicates
+func unionSorted[S ~[]E, E ~string](a, b S) S {
+	if len(a) == 0 && len(b) == 0 {
+		return nil
+	}
+
+	union := append(slices.Clone(a), b...)
+	slices.Sort(union)
+
+	return slices.Compact(union)
+}
+
+// unionFunc returns the values of a followed by those of b that are not
+// in a according to the given function, keeping the order they are in
+func unionFunc[S ~[]E, E any](a, b S, same func(E, E) bool) S {
+	union := slices.Clone(a)
+
+	for _, value := range b {
+		if !slices.ContainsFunc(union, func(existing E) bool { return same(existing, value) }) {
+			union = append(union, value)
+		}
+	}
+
+	return union
+}
+
+// mergePackageVulns merges the details of the same package from two
+// scans of the same source, keeping everything that either has
+func mergePackageVulns(a, b models.PackageVulns) models.PackageVulns {
+	merged := a
+
+	merged.DepGroups = unionSorted(a.DepGroups, b.DepGroups)
+	merged.Transitive = a.Transitive && b.Transitive
+	merged.Locations = unionFunc(a.Locations, b.Locations, sameLocation)
+	merged.Hashes = unionSorted(a.Hashes, b.Hashes)
+
+	if merged.RegistryURL == "" {
+		merged.RegistryURL = b.RegistryURL
+	}
+	merged.Vulnerabilities = unionFunc(a.Vulnerabilities, b.Vulnerabilities, func(x, y models.Vulnerability) bool {
+		return x.ID == y.ID
+	})
+	merged.Groups = unionFunc(a.Groups, b.Groups, func(x, y models.GroupInfo) bool {
+		return slices.Equal(x.IDs, y.IDs)
+	})
+	merged.Licenses = unionSorted(a.Licenses, b.Licenses)
+	merged.LicenseViolations = unionSorted(a.LicenseViolations, b.LicenseViolations)
+
+	if a.Metadata == nil {
+		merged.Metadata = b.Metadata
+	} else {
+		merged.Metadata = maps.Clone(a.Metadata).Merge(b.Metadata)
+	}
+
+	return merged
+}
+
+// MergePackageSources combines the package sources from two separate scans, such as
+// from before and after a build, into one list with a single source for each path.
+//
+// Unlike purl.Group, the packages are kept with the source that they are from, with the
+// packages of a source that is in both lists being the union of the packages in each,
+// and the details of a package that is in both, like its DepGroups and Locations,
+// being the union of its details in each.
+func MergePackageSources(a, b []models.PackageSource) []models.PackageSource {
+	merged := make([]models.PackageSource, 0, len(a)+len(b))
+
+	// where each source is in the merged list, along with where each of its packages are
+	sourceIndexes := make(map[string]int)
+	packageIndexes := make([]map[models.PackageInfo]int, 0, len(a)+len(b))
+
+	for _, source := range append(slices.Clone(a), b...) {
+		i, ok := sourceIndexes[source.Source.Path]
+
+		if !ok {
+			i = len(merged)
+			sourceIndexes[source.Source.Path] = i
+			merged = append(merged, models.PackageSource{
+				Source:   source.Source,
+				Packages: make([]models.PackageVulns, 0, len(source.Packages)),
+			})
+			packageIndexes = append(packageIndexes, make(map[models.PackageInfo]int))
+		}
+
+		for _, pkg := range source.Packages {
+			if j, ok := packageIndexes[i][pkg.Package]; ok {
+				merged[i].Packages[j] = mergePackageVulns(merged[i].Packages[j], pkg)
+
+				continue
+			}
+
+			packageIndexes[i][pkg.Package] = len(merged[i].Packages)
+			merged[i].Packages = append(merged[i].Packages, pkg)
+		}
+	}
+
+	return merged
+}
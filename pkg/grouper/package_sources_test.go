@@ -0,0 +1,141 @@
+package grouper_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/osv-scanner/pkg/grouper"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestMergePackageSources(t *testing.T) {
+	t.Parallel()
+
+	lockfile := models.SourceInfo{Path: "/path/to/package-lock.json", Type: "lockfile"}
+	sbom := models.SourceInfo{Path: "/path/to/bom.json", Type: "sbom"}
+	image := models.SourceInfo{Path: "/path/to/image.tar", Type: "docker"}
+
+	wrappy := models.PackageInfo{Name: "wrappy", Version: "1.0.2", Ecosystem: "npm"}
+	once := models.PackageInfo{Name: "once", Version: "1.4.0", Ecosystem: "npm"}
+	eslint := models.PackageInfo{Name: "eslint", Version: "8.57.0", Ecosystem: "npm"}
+
+	wrappyLocation := models.PackageLocations{
+		Block: models.PackageLocation{Filename: lockfile.Path, LineStart: 10, LineEnd: 14},
+		Name:  &models.PackageLocation{Filename: lockfile.Path, LineStart: 10, LineEnd: 10},
+	}
+	nestedWrappyLocation := models.PackageLocations{
+		Block: models.PackageLocation{Filename: lockfile.Path, LineStart: 20, LineEnd: 24},
+	}
+
+	before := []models.PackageSource{
+		{
+			Source: lockfile,
+			Packages: []models.PackageVulns{
+				{
+					Package:   wrappy,
+					Locations: []models.PackageLocations{wrappyLocation},
+				},
+				{Package: once},
+			},
+		},
+		{
+			Source:   sbom,
+			Packages: []models.PackageVulns{{Package: once}},
+		},
+	}
+
+	after := []models.PackageSource{
+		{
+			Source: lockfile,
+			Packages: []models.PackageVulns{
+				{
+					Package:   wrappy,
+					DepGroups: []string{"dev"},
+					Locations: []models.PackageLocations{
+						{
+							Block: wrappyLocation.Block,
+							Name:  &models.PackageLocation{Filename: lockfile.Path, LineStart: 10, LineEnd: 10},
+						},
+						nestedWrappyLocation,
+					},
+				},
+				{Package: eslint, DepGroups: []string{"dev"}},
+				{Package: once, RegistryURL: "https://npm.example.com"},
+			},
+		},
+		{
+			Source:   image,
+			Packages: []models.PackageVulns{{Package: wrappy}},
+		},
+	}
+
+	got := grouper.MergePackageSources(before, after)
+
+	want := []models.PackageSource{
+		{
+			Source: lockfile,
+			Packages: []models.PackageVulns{
+				{
+					Package:   wrappy,
+					DepGroups: []string{"dev"},
+					Locations: []models.PackageLocations{wrappyLocation, nestedWrappyLocation},
+				},
+				{Package: once, RegistryURL: "https://npm.example.com"},
+				{Package: eslint, DepGroups: []string{"dev"}},
+			},
+		},
+		{
+			Source:   sbom,
+			Packages: []models.PackageVulns{{Package: once}},
+		},
+		{
+			Source:   image,
+			Packages: []models.PackageVulns{{Package: wrappy}},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MergePackageSources() returned an unexpected result (-want, +got):\n%s", diff)
+	}
+}
+
+func TestMergePackageSources_Vulnerabilities(t *testing.T) {
+	t.Parallel()
+
+	source := models.SourceInfo{Path: "/path/to/go.mod", Type: "lockfile"}
+	pkg := models.PackageInfo{Name: "golang.org/x/net", Version: "0.1.0", Ecosystem: "Go"}
+
+	got := grouper.MergePackageSources(
+		[]models.PackageSource{{
+			Source: source,
+			Packages: []models.PackageVulns{{
+				Package:         pkg,
+				Vulnerabilities: []models.Vulnerability{{ID: "GO-1"}},
+				Groups:          []models.GroupInfo{{IDs: []string{"GO-1"}}},
+				Metadata:        models.PackageMetadata{models.IsDirectDependencyMetadata: "true"},
+			}},
+		}},
+		[]models.PackageSource{{
+			Source: source,
+			Packages: []models.PackageVulns{{
+				Package:         pkg,
+				Vulnerabilities: []models.Vulnerability{{ID: "GO-1"}, {ID: "GO-2"}},
+				Groups:          []models.GroupInfo{{IDs: []string{"GO-1"}}, {IDs: []string{"GO-2"}}},
+			}},
+		}},
+	)
+
+	want := []models.PackageSource{{
+		Source: source,
+		Packages: []models.PackageVulns{{
+			Package:         pkg,
+			Vulnerabilities: []models.Vulnerability{{ID: "GO-1"}, {ID: "GO-2"}},
+			Groups:          []models.GroupInfo{{IDs: []string{"GO-1"}}, {IDs: []string{"GO-2"}}},
+			Metadata:        models.PackageMetadata{models.IsDirectDependencyMetadata: "true"},
+		}},
+	}}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MergePackageSources() returned an unexpected result (-want, +got):\n%s", diff)
+	}
+}
@@ -7,44 +7,72 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/google/osv-scanner/internal/utility/fileposition"
 	"github.com/google/osv-scanner/pkg/models"
 )
 
 const AlpineEcosystem Ecosystem = "Alpine"
 
-func groupApkPackageLines(scanner *bufio.Scanner) [][]string {
-	var groups [][]string
-	var group []string
+// apkPackageGroup is the lines of a single record of an installed file,
+// along with the line number that the record starts on
+type apkPackageGroup struct {
+	lines     []string
+	lineStart int
+}
+
+func groupApkPackageLines(scanner *bufio.Scanner) []apkPackageGroup {
+	var groups []apkPackageGroup
+	var group apkPackageGroup
+
+	lineNumber := 0
 
 	for scanner.Scan() {
 		line := scanner.Text()
+		lineNumber++
 
 		if line != "" {
-			group = append(group, line)
+			if len(group.lines) == 0 {
+				group.lineStart = lineNumber
+			}
+			group.lines = append(group.lines, line)
+
 			continue
 		}
-		if len(group) > 0 {
+		if len(group.lines) > 0 {
 			groups = append(groups, group)
 		}
-		group = make([]string, 0)
+		group = apkPackageGroup{}
 	}
 
-	if len(group) > 0 {
+	if len(group.lines) > 0 {
 		groups = append(groups, group)
 	}
 
 	return groups
 }
 
-func parseApkPackageGroup(group []string) PackageDetails {
+func parseApkPackageGroup(group apkPackageGroup, path string) PackageDetails {
+	lastLine := group.lines[len(group.lines)-1]
+
 	var pkg = PackageDetails{
 		Ecosystem:      AlpineEcosystem,
 		CompareAs:      AlpineEcosystem,
 		PackageManager: models.Unknown,
+		BlockLocation: models.FilePosition{
+			Line: models.Position{
+				Start: group.lineStart,
+				End:   group.lineStart + len(group.lines) - 1,
+			},
+			Column: models.Position{
+				Start: 1,
+				End:   fileposition.ColumnOfByteIndex(lastLine, len(lastLine)),
+			},
+			Filename: path,
+		},
 	}
 
 	// File SPECS: https://wiki.alpinelinux.org/wiki/Apk_spec
-	for _, line := range group {
+	for _, line := range group.lines {
 		switch {
 		case strings.HasPrefix(line, "P:"):
 			pkg.Name = strings.TrimPrefix(line, "P:")
@@ -52,6 +80,8 @@ func parseApkPackageGroup(group []string) PackageDetails {
 			pkg.Version = strings.TrimPrefix(line, "V:")
 		case strings.HasPrefix(line, "c:"):
 			pkg.Commit = strings.TrimPrefix(line, "c:")
+		case strings.HasPrefix(line, "A:"):
+			pkg.Architecture = strings.TrimPrefix(line, "A:")
 		}
 	}
 
@@ -76,7 +106,7 @@ func (e ApkInstalledExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 	packages := make([]PackageDetails, 0, len(packageGroups))
 
 	for _, group := range packageGroups {
-		pkg := parseApkPackageGroup(group)
+		pkg := parseApkPackageGroup(group, f.Path())
 
 		if pkg.Name == "" {
 			continue
@@ -2,6 +2,8 @@ package lockfile_test
 
 import (
 	"io/fs"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/osv-scanner/pkg/models"
@@ -45,7 +47,13 @@ func TestParseApkInstalled_NotAnInstalled(t *testing.T) {
 func TestParseApkInstalled_Malformed(t *testing.T) {
 	t.Parallel()
 
-	packages, err := lockfile.ParseApkInstalled("fixtures/apk/malformed_installed")
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/apk/malformed_installed"))
+	packages, err := lockfile.ParseApkInstalled(path)
 
 	if err != nil {
 		t.Errorf("Got unexpected error: %v", err)
@@ -58,7 +66,13 @@ func TestParseApkInstalled_Malformed(t *testing.T) {
 			Commit:         "1dbf7a793afae640ea643a055b6dd4f430ac116b",
 			Ecosystem:      lockfile.AlpineEcosystem,
 			CompareAs:      lockfile.AlpineEcosystem,
+			Architecture:   "x86_64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 91, End: 131},
+				Column:   models.Position{Start: 1, End: 10},
+				Filename: path,
+			},
 		},
 	})
 }
@@ -66,7 +80,13 @@ func TestParseApkInstalled_Malformed(t *testing.T) {
 func TestParseApkInstalled_Single(t *testing.T) {
 	t.Parallel()
 
-	packages, err := lockfile.ParseApkInstalled("fixtures/apk/single_installed")
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/apk/single_installed"))
+	packages, err := lockfile.ParseApkInstalled(path)
 
 	if err != nil {
 		t.Errorf("Got unexpected error: %v", err)
@@ -79,7 +99,13 @@ func TestParseApkInstalled_Single(t *testing.T) {
 			Commit:         "0188f510baadbae393472103427b9c1875117136",
 			Ecosystem:      lockfile.AlpineEcosystem,
 			CompareAs:      lockfile.AlpineEcosystem,
+			Architecture:   "x86_64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 1, End: 32},
+				Column:   models.Position{Start: 1, End: 14},
+				Filename: path,
+			},
 		},
 	})
 }
@@ -87,7 +113,13 @@ func TestParseApkInstalled_Single(t *testing.T) {
 func TestParseApkInstalled_Shuffled(t *testing.T) {
 	t.Parallel()
 
-	packages, err := lockfile.ParseApkInstalled("fixtures/apk/shuffled_installed")
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/apk/shuffled_installed"))
+	packages, err := lockfile.ParseApkInstalled(path)
 
 	if err != nil {
 		t.Errorf("Got unexpected error: %v", err)
@@ -100,7 +132,13 @@ func TestParseApkInstalled_Shuffled(t *testing.T) {
 			Commit:         "0188f510baadbae393472103427b9c1875117136",
 			Ecosystem:      lockfile.AlpineEcosystem,
 			CompareAs:      lockfile.AlpineEcosystem,
+			Architecture:   "x86_64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 1, End: 32},
+				Column:   models.Position{Start: 1, End: 50},
+				Filename: path,
+			},
 		},
 	})
 }
@@ -108,7 +146,13 @@ func TestParseApkInstalled_Shuffled(t *testing.T) {
 func TestParseApkInstalled_Multiple(t *testing.T) {
 	t.Parallel()
 
-	packages, err := lockfile.ParseApkInstalled("fixtures/apk/multiple_installed")
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/apk/multiple_installed"))
+	packages, err := lockfile.ParseApkInstalled(path)
 
 	if err != nil {
 		t.Errorf("Got unexpected error: %v", err)
@@ -121,7 +165,13 @@ func TestParseApkInstalled_Multiple(t *testing.T) {
 			Commit:         "bd965a7ebf7fd8f07d7a0cc0d7375bf3e4eb9b24",
 			Ecosystem:      lockfile.AlpineEcosystem,
 			CompareAs:      lockfile.AlpineEcosystem,
+			Architecture:   "x86_64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 1, End: 47},
+				Column:   models.Position{Start: 1, End: 33},
+				Filename: path,
+			},
 		},
 		{
 			Name:           "musl",
@@ -129,7 +179,13 @@ func TestParseApkInstalled_Multiple(t *testing.T) {
 			Commit:         "f93af038c3de7146121c2ea8124ba5ce29b4b058",
 			Ecosystem:      lockfile.AlpineEcosystem,
 			CompareAs:      lockfile.AlpineEcosystem,
+			Architecture:   "x86_64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 49, End: 69},
+				Column:   models.Position{Start: 1, End: 33},
+				Filename: path,
+			},
 		},
 		{
 			Name:           "busybox",
@@ -137,7 +193,13 @@ func TestParseApkInstalled_Multiple(t *testing.T) {
 			Commit:         "1dbf7a793afae640ea643a055b6dd4f430ac116b",
 			Ecosystem:      lockfile.AlpineEcosystem,
 			CompareAs:      lockfile.AlpineEcosystem,
+			Architecture:   "x86_64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 71, End: 123},
+				Column:   models.Position{Start: 1, End: 17},
+				Filename: path,
+			},
 		},
 	})
 }
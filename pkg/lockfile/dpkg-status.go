@@ -6,6 +6,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/google/osv-scanner/internal/utility/fileposition"
 	"github.com/google/osv-scanner/pkg/models"
 
 	"github.com/google/osv-scanner/internal/cachedregexp"
@@ -13,24 +14,38 @@ import (
 
 const DebianEcosystem Ecosystem = "Debian"
 
-func groupDpkgPackageLines(scanner *bufio.Scanner) [][]string {
-	var groups [][]string
-	var group []string
+// dpkgPackageGroup is the lines of a single paragraph of a status file,
+// along with the line number that the paragraph starts on
+type dpkgPackageGroup struct {
+	lines     []string
+	lineStart int
+}
+
+func groupDpkgPackageLines(scanner *bufio.Scanner) []dpkgPackageGroup {
+	var groups []dpkgPackageGroup
+	var group dpkgPackageGroup
+
+	lineNumber := 0
 
 	for scanner.Scan() {
 		line := scanner.Text()
+		lineNumber++
 
 		if line != "" {
-			group = append(group, line)
+			if len(group.lines) == 0 {
+				group.lineStart = lineNumber
+			}
+			group.lines = append(group.lines, line)
+
 			continue
 		}
-		if len(group) > 0 {
+		if len(group.lines) > 0 {
 			groups = append(groups, group)
 		}
-		group = make([]string, 0)
+		group = dpkgPackageGroup{}
 	}
 
-	if len(group) > 0 {
+	if len(group.lines) > 0 {
 		groups = append(groups, group)
 	}
 
@@ -49,16 +64,29 @@ func parseSourceField(source string) (string, string) {
 	return strings.TrimSpace(source), ""
 }
 
-func parseDpkgPackageGroup(group []string) PackageDetails {
+func parseDpkgPackageGroup(group dpkgPackageGroup, path string) PackageDetails {
+	lastLine := group.lines[len(group.lines)-1]
+
 	var pkg = PackageDetails{
 		Ecosystem:      DebianEcosystem,
 		CompareAs:      DebianEcosystem,
 		PackageManager: models.Unknown,
+		BlockLocation: models.FilePosition{
+			Line: models.Position{
+				Start: group.lineStart,
+				End:   group.lineStart + len(group.lines) - 1,
+			},
+			Column: models.Position{
+				Start: 1,
+				End:   fileposition.ColumnOfByteIndex(lastLine, len(lastLine)),
+			},
+			Filename: path,
+		},
 	}
 
 	sourcePresent := false
 	sourceHasVersion := false
-	for _, line := range group {
+	for _, line := range group.lines {
 		switch {
 		// Status field SPECS: http://www.fifi.org/doc/libapt-pkg-doc/dpkg-tech.html/ch1.html#s1.2
 		case strings.HasPrefix(line, "Status:"):
@@ -74,6 +102,10 @@ func parseDpkgPackageGroup(group []string) PackageDetails {
 			if tokens[2] == "not-installed" || tokens[2] == "config-files" {
 				return PackageDetails{}
 			}
+			// Package has been selected to be removed, so it is no longer considered to be installed
+			if tokens[0] == "deinstall" || tokens[0] == "purge" {
+				return PackageDetails{}
+			}
 
 		case strings.HasPrefix(line, "Source:"):
 			sourcePresent = true
@@ -92,6 +124,9 @@ func parseDpkgPackageGroup(group []string) PackageDetails {
 				pkg.Version = strings.TrimSpace(pkg.Version)
 			}
 
+		case strings.HasPrefix(line, "Architecture:"):
+			pkg.Architecture = strings.TrimSpace(strings.TrimPrefix(line, "Architecture:"))
+
 		// Some packages have no Source field (e.g. sudo) so we use Package value
 		case strings.HasPrefix(line, "Package:"):
 			if !sourcePresent {
@@ -121,7 +156,7 @@ func (e DpkgStatusExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 	packages := make([]PackageDetails, 0, len(packageGroups))
 
 	for _, group := range packageGroups {
-		pkg := parseDpkgPackageGroup(group)
+		pkg := parseDpkgPackageGroup(group, f.Path())
 
 		// PackageDetails does not contain any field that represent a "not installed" state
 		// To manage this state and avoid false positives, empty ecosystem means "not installed" so skip it
@@ -2,6 +2,8 @@ package lockfile_test
 
 import (
 	"io/fs"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/osv-scanner/pkg/models"
@@ -45,7 +47,13 @@ func TestParseDpkgStatus_NotAStatus(t *testing.T) {
 func TestParseDpkgStatus_Malformed(t *testing.T) {
 	t.Parallel()
 
-	packages, err := lockfile.ParseDpkgStatus("fixtures/dpkg/malformed_status")
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/dpkg/malformed_status"))
+	packages, err := lockfile.ParseDpkgStatus(path)
 
 	if err != nil {
 		t.Errorf("Got unexpected error: %v", err)
@@ -57,14 +65,26 @@ func TestParseDpkgStatus_Malformed(t *testing.T) {
 			Version:        "",
 			Ecosystem:      lockfile.DebianEcosystem,
 			CompareAs:      lockfile.DebianEcosystem,
+			Architecture:   "amd64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 5, End: 35},
+				Column:   models.Position{Start: 1, End: 60},
+				Filename: path,
+			},
 		},
 		{
 			Name:           "util-linux",
 			Version:        "2.36.1-8+deb11u1",
 			Ecosystem:      lockfile.DebianEcosystem,
 			CompareAs:      lockfile.DebianEcosystem,
+			Architecture:   "amd64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 43, End: 59},
+				Column:   models.Position{Start: 1, End: 60},
+				Filename: path,
+			},
 		},
 	})
 }
@@ -72,7 +92,13 @@ func TestParseDpkgStatus_Malformed(t *testing.T) {
 func TestParseDpkgStatus_Single(t *testing.T) {
 	t.Parallel()
 
-	packages, err := lockfile.ParseDpkgStatus("fixtures/dpkg/single_status")
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/dpkg/single_status"))
+	packages, err := lockfile.ParseDpkgStatus(path)
 
 	if err != nil {
 		t.Errorf("Got unexpected error: %v", err)
@@ -84,7 +110,13 @@ func TestParseDpkgStatus_Single(t *testing.T) {
 			Version:        "1.8.27-1+deb10u1",
 			Ecosystem:      lockfile.DebianEcosystem,
 			CompareAs:      lockfile.DebianEcosystem,
+			Architecture:   "amd64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 1, End: 33},
+				Column:   models.Position{Start: 1, End: 58},
+				Filename: path,
+			},
 		},
 	})
 }
@@ -92,7 +124,13 @@ func TestParseDpkgStatus_Single(t *testing.T) {
 func TestParseDpkgStatus_Shuffled(t *testing.T) {
 	t.Parallel()
 
-	packages, err := lockfile.ParseDpkgStatus("fixtures/dpkg/shuffled_status")
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/dpkg/shuffled_status"))
+	packages, err := lockfile.ParseDpkgStatus(path)
 
 	if err != nil {
 		t.Errorf("Got unexpected error: %v", err)
@@ -104,7 +142,13 @@ func TestParseDpkgStatus_Shuffled(t *testing.T) {
 			Version:        "2.31-13+deb11u5",
 			Ecosystem:      lockfile.DebianEcosystem,
 			CompareAs:      lockfile.DebianEcosystem,
+			Architecture:   "amd64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 1, End: 22},
+				Column:   models.Position{Start: 1, End: 15},
+				Filename: path,
+			},
 		},
 	})
 }
@@ -112,7 +156,13 @@ func TestParseDpkgStatus_Shuffled(t *testing.T) {
 func TestParseDpkgStatus_Multiple(t *testing.T) {
 	t.Parallel()
 
-	packages, err := lockfile.ParseDpkgStatus("fixtures/dpkg/multiple_status")
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/dpkg/multiple_status"))
+	packages, err := lockfile.ParseDpkgStatus(path)
 
 	if err != nil {
 		t.Errorf("Got unexpected error: %v", err)
@@ -124,28 +174,52 @@ func TestParseDpkgStatus_Multiple(t *testing.T) {
 			Version:        "5.1-2+deb11u1",
 			Ecosystem:      lockfile.DebianEcosystem + ":12",
 			CompareAs:      lockfile.DebianEcosystem,
+			Architecture:   "amd64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 1, End: 32},
+				Column:   models.Position{Start: 1, End: 60},
+				Filename: path,
+			},
 		},
 		{
 			Name:           "util-linux",
 			Version:        "2.36.1-8+deb11u1",
 			Ecosystem:      lockfile.DebianEcosystem + ":12",
 			CompareAs:      lockfile.DebianEcosystem,
+			Architecture:   "amd64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 34, End: 51},
+				Column:   models.Position{Start: 1, End: 60},
+				Filename: path,
+			},
 		},
 		{
 			Name:           "glibc",
 			Version:        "2.31-13+deb11u5",
 			Ecosystem:      lockfile.DebianEcosystem + ":12",
 			CompareAs:      lockfile.DebianEcosystem,
+			Architecture:   "amd64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 53, End: 74},
+				Column:   models.Position{Start: 1, End: 54},
+				Filename: path,
+			},
 		},
 		{
 			Name:           "base-files",
 			Version:        "12.4+deb12u5",
 			Ecosystem:      lockfile.DebianEcosystem + ":12",
 			CompareAs:      lockfile.DebianEcosystem,
+			Architecture:   "amd64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 101, End: 126},
+				Column:   models.Position{Start: 1, End: 67},
+				Filename: path,
+			},
 		},
 	})
 }
@@ -153,7 +227,13 @@ func TestParseDpkgStatus_Multiple(t *testing.T) {
 func TestParseDpkgStatus_Source_Ver_Override(t *testing.T) {
 	t.Parallel()
 
-	packages, err := lockfile.ParseDpkgStatus("fixtures/dpkg/source_ver_override_status")
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/dpkg/source_ver_override_status"))
+	packages, err := lockfile.ParseDpkgStatus(path)
 
 	if err != nil {
 		t.Errorf("Got unexpected error: %v", err)
@@ -165,7 +245,45 @@ func TestParseDpkgStatus_Source_Ver_Override(t *testing.T) {
 			Version:        "2.02.176-4.1ubuntu3",
 			Ecosystem:      lockfile.DebianEcosystem,
 			CompareAs:      lockfile.DebianEcosystem,
+			Architecture:   "amd64",
+			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 1, End: 20},
+				Column:   models.Position{Start: 1, End: 74},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseDpkgStatus_Deinstall(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/dpkg/deinstall_status"))
+	packages, err := lockfile.ParseDpkgStatus(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "curl",
+			Version:        "7.88.1-10+deb12u5",
+			Ecosystem:      lockfile.DebianEcosystem,
+			CompareAs:      lockfile.DebianEcosystem,
+			Architecture:   "amd64",
 			PackageManager: models.Unknown,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 1, End: 10},
+				Column:   models.Position{Start: 1, End: 69},
+				Filename: path,
+			},
 		},
 	})
 }
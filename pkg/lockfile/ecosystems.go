@@ -1,5 +1,12 @@
 package lockfile
 
+import (
+	"slices"
+	"strings"
+
+	"github.com/package-url/packageurl-go"
+)
+
 // KnownEcosystems returns a list of ecosystems that `lockfile` supports
 // automatically inferring an extractor for based on a file path.
 func KnownEcosystems() []Ecosystem {
@@ -16,8 +23,131 @@ func KnownEcosystems() []Ecosystem {
 		PubEcosystem,
 		ConanEcosystem,
 		CRANEcosystem,
+		CocoaPodsEcosystem,
+		HackageEcosystem,
+		TerraformEcosystem,
+		HelmEcosystem,
+		SwiftURLEcosystem,
+		NixEcosystem,
 		// Disabled temporarily,
 		// see https://github.com/google/osv-scanner/pull/128 discussion for additional context
 		// AlpineEcosystem,
 	}
 }
+
+// PURLType returns the package-url type that packages of the ecosystem have,
+// or an empty string if the ecosystem does not have a known type.
+func (sys Ecosystem) PURLType() string {
+	switch sys {
+	case AlpineEcosystem:
+		return "apk"
+	case BundlerEcosystem:
+		return packageurl.TypeGem
+	case CocoaPodsEcosystem:
+		return packageurl.TypeCocoapods
+	case CargoEcosystem:
+		return packageurl.TypeCargo
+	case ComposerEcosystem:
+		return packageurl.TypeComposer
+	case ConanEcosystem:
+		return packageurl.TypeConan
+	case CRANEcosystem:
+		return packageurl.TypeCran
+	case DebianEcosystem:
+		return packageurl.TypeDebian
+	case GoEcosystem:
+		return packageurl.TypeGolang
+	case HackageEcosystem:
+		return packageurl.TypeHackage
+	case HelmEcosystem:
+		return "helm"
+	case MavenEcosystem:
+		return packageurl.TypeMaven
+	case MixEcosystem:
+		return packageurl.TypeHex
+	case NixEcosystem:
+		return "nix"
+	case NpmEcosystem:
+		return packageurl.TypeNPM
+	case NuGetEcosystem:
+		return packageurl.TypeNuget
+	case PipEcosystem:
+		return packageurl.TypePyPi
+	case PubEcosystem:
+		return "pub"
+	case SwiftURLEcosystem:
+		return packageurl.TypeSwift
+	case TerraformEcosystem:
+		return "terraform"
+	}
+
+	return ""
+}
+
+// RegistryURL returns the url of the registry that packages of the ecosystem come
+// from by default, or an empty string if the ecosystem does not have one, like
+// those of operating systems or that identify packages by their source url.
+func (sys Ecosystem) RegistryURL() string {
+	switch sys {
+	case BundlerEcosystem:
+		return "https://rubygems.org"
+	case CocoaPodsEcosystem:
+		return "https://cdn.cocoapods.org"
+	case CargoEcosystem:
+		return "https://crates.io"
+	case ComposerEcosystem:
+		return "https://repo.packagist.org"
+	case ConanEcosystem:
+		return "https://center.conan.io"
+	case CRANEcosystem:
+		return "https://cran.r-project.org"
+	case GoEcosystem:
+		return "https://proxy.golang.org"
+	case HackageEcosystem:
+		return "https://hackage.haskell.org"
+	case MavenEcosystem:
+		return "https://repo.maven.apache.org/maven2"
+	case MixEcosystem:
+		return "https://repo.hex.pm"
+	case NpmEcosystem:
+		return "https://registry.npmjs.org"
+	case NuGetEcosystem:
+		return "https://api.nuget.org/v3/index.json"
+	case PipEcosystem:
+		return "https://pypi.org/simple"
+	case PubEcosystem:
+		return "https://pub.dev"
+	case TerraformEcosystem:
+		return "https://registry.terraform.io"
+	}
+
+	return ""
+}
+
+// defaultRegistryAliases are the other urls that the default registry of
+// an ecosystem can be referred to by in lockfiles, such as its index
+var defaultRegistryAliases = map[Ecosystem][]string{
+	CargoEcosystem: {
+		"https://github.com/rust-lang/crates.io-index",
+		"https://index.crates.io",
+	},
+	NpmEcosystem: {
+		"https://registry.yarnpkg.com",
+	},
+	PipEcosystem: {
+		"https://pypi.python.org/simple",
+	},
+}
+
+// nonDefaultRegistryURL returns the given url of the registry that a package
+// was resolved from if it is not the default registry of the ecosystem, so that
+// only packages from other registries have their RegistryURL set
+func (sys Ecosystem) nonDefaultRegistryURL(url string) string {
+	url = strings.TrimSuffix(url, "/")
+
+	if url == "" || url == sys.RegistryURL() || slices.Contains(defaultRegistryAliases[sys], url) {
+		return ""
+	}
+
+	return url
+}
@@ -34,11 +34,16 @@ func TestKnownEcosystems(t *testing.T) {
 
 	expectedCount := numberOfLockfileParsers(t)
 
-	// - npm, yarn, and pnpm,
-	// - pip, poetry, pdm and pipenv,
-	// - maven, gradle, and gradle/verification-metadata
+	// - npm, yarn, pnpm, deno, and bun,
+	// - pip, poetry, pdm, pipenv, uv, and pyproject.toml,
+	// - maven, gradle, build.gradle, gradle/verification-metadata, ivy.xml, and maven_install.json
+	// - Cargo.lock and Cargo.toml
+	// - composer.lock and composer.json
+	// - packages.lock.json, packages.config, and project files
+	// - go.mod and go.work
+	// - cabal.project.freeze and stack.yaml.lock
 	// all use the same ecosystem so "ignore" those parsers in the count
-	expectedCount -= 7
+	expectedCount -= 20
 
 	ecosystems := lockfile.KnownEcosystems()
 
@@ -56,3 +61,45 @@ func TestKnownEcosystems(t *testing.T) {
 		}
 	}
 }
+
+func TestEcosystem_PURLType(t *testing.T) {
+	t.Parallel()
+
+	for _, ecosystem := range lockfile.KnownEcosystems() {
+		if ecosystem.PURLType() == "" {
+			t.Errorf(`Ecosystem "%s" does not have a PURL type`, ecosystem)
+		}
+	}
+
+	if purlType := lockfile.GoEcosystem.PURLType(); purlType != "golang" {
+		t.Errorf(`Expected Go to have the "golang" PURL type, but got "%s"`, purlType)
+	}
+
+	if purlType := lockfile.PipenvEcosystem.PURLType(); purlType != "pypi" {
+		t.Errorf(`Expected Pipenv to have the "pypi" PURL type, but got "%s"`, purlType)
+	}
+
+	if purlType := lockfile.Ecosystem("unknown").PURLType(); purlType != "" {
+		t.Errorf(`Expected an unknown ecosystem to not have a PURL type, but got "%s"`, purlType)
+	}
+}
+
+func TestEcosystem_RegistryURL(t *testing.T) {
+	t.Parallel()
+
+	if url := lockfile.NpmEcosystem.RegistryURL(); url != "https://registry.npmjs.org" {
+		t.Errorf(`Expected npm to have the "https://registry.npmjs.org" registry, but got "%s"`, url)
+	}
+
+	if url := lockfile.PipenvEcosystem.RegistryURL(); url != "https://pypi.org/simple" {
+		t.Errorf(`Expected Pipenv to have the "https://pypi.org/simple" registry, but got "%s"`, url)
+	}
+
+	if url := lockfile.DebianEcosystem.RegistryURL(); url != "" {
+		t.Errorf(`Expected Debian to not have a registry, but got "%s"`, url)
+	}
+
+	if url := lockfile.Ecosystem("unknown").RegistryURL(); url != "" {
+		t.Errorf(`Expected an unknown ecosystem to not have a registry, but got "%s"`, url)
+	}
+}
@@ -1,5 +1,116 @@
 package lockfile
 
-import "errors"
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+)
 
 var ErrIncompatibleFileFormat = errors.New("file format is incompatible, but this is expected")
+
+// ParseError is returned when a lockfile is malformed, pointing to the line and
+// column of the problem when the underlying parser reports where it is
+type ParseError struct {
+	Path string
+	// Line and Column are 1-based, and are 0 if the position is not known
+	Line   int
+	Column int
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("could not extract from %s: %v", e.Path, e.Err)
+	}
+
+	return fmt.Sprintf("could not extract from %s (line %d, column %d): %v", e.Path, e.Line, e.Column, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newJSONParseError returns a ParseError for an error from decoding the given
+// JSON content, using the offset of syntax and type errors to find their position
+func newJSONParseError(path string, content []byte, err error) *ParseError {
+	parseErr := &ParseError{Path: path, Err: err}
+
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return parseErr
+	}
+
+	// the offset is of the byte after the one that caused the error
+	index := int(offset) - 1
+	if index < 0 || index >= len(content) {
+		return parseErr
+	}
+
+	lineStart := bytes.LastIndexByte(content[:index], '\n') + 1
+	lineEnd := bytes.IndexByte(content[lineStart:], '\n')
+	if lineEnd == -1 {
+		lineEnd = len(content) - lineStart
+	}
+
+	parseErr.Line = bytes.Count(content[:lineStart], []byte("\n")) + 1
+	parseErr.Column = fileposition.ColumnOfByteIndex(string(content[lineStart:lineStart+lineEnd]), index-lineStart)
+
+	return parseErr
+}
+
+// EntryError is returned alongside the packages that could be extracted when a single
+// entry of a lockfile is malformed, as the other entries can still be extracted
+type EntryError struct {
+	Path string
+	// Entry is the name of the package of the entry, if it is known
+	Entry string
+	// Line is 1-based, and is 0 if the line is not known
+	Line int
+	Err  error
+}
+
+func (e *EntryError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("skipped %s in %s: %v", e.Entry, e.Path, e.Err)
+	}
+
+	return fmt.Sprintf("skipped %s in %s (line %d): %v", e.Entry, e.Path, e.Line, e.Err)
+}
+
+func (e *EntryError) Unwrap() error {
+	return e.Err
+}
+
+// OnlyEntryErrors checks if the given error is made up of only EntryErrors, in which
+// case the packages of the other entries were still extracted and can be used
+func OnlyEntryErrors(err error) bool {
+	switch e := err.(type) { //nolint:errorlint
+	case *EntryError:
+		return true
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+
+		for _, err := range errs {
+			if !OnlyEntryErrors(err) {
+				return false
+			}
+		}
+
+		return len(errs) > 0
+	case interface{ Unwrap() error }:
+		return OnlyEntryErrors(e.Unwrap())
+	}
+
+	return false
+}
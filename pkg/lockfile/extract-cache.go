@@ -0,0 +1,60 @@
+package lockfile
+
+import (
+	"slices"
+	"sync"
+)
+
+// ExtractCache stores the packages that have been extracted from lockfiles, keyed
+// by the path of the lockfile and the hash of its contents, so that lockfiles which
+// have not changed since they were last extracted from do not need to be parsed again.
+//
+// It is used by multiple goroutines at the same time when extracting from a directory,
+// so implementations must be safe for concurrent use.
+type ExtractCache interface {
+	// Get returns the packages that were extracted from the lockfile at the given
+	// path when it had the given hash, returning false if there are none
+	Get(path string, hash string) ([]PackageDetails, bool)
+
+	// Put stores the packages that were extracted from the lockfile at the given
+	// path when it had the given hash
+	Put(path string, hash string, packages []PackageDetails)
+}
+
+// MemoryExtractCache is an ExtractCache which keeps the packages in memory,
+// only keeping those for the latest hash of each lockfile.
+type MemoryExtractCache struct {
+	mu      sync.RWMutex
+	entries map[string]memoryExtractCacheEntry
+}
+
+type memoryExtractCacheEntry struct {
+	hash     string
+	packages []PackageDetails
+}
+
+func NewMemoryExtractCache() *MemoryExtractCache {
+	return &MemoryExtractCache{entries: map[string]memoryExtractCacheEntry{}}
+}
+
+func (c *MemoryExtractCache) Get(path string, hash string) ([]PackageDetails, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[path]
+
+	if !ok || entry.hash != hash {
+		return nil, false
+	}
+
+	return slices.Clone(entry.packages), true
+}
+
+func (c *MemoryExtractCache) Put(path string, hash string, packages []PackageDetails) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[path] = memoryExtractCacheEntry{hash: hash, packages: slices.Clone(packages)}
+}
+
+var _ ExtractCache = &MemoryExtractCache{}
@@ -0,0 +1,462 @@
+package lockfile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-git/go-git/v5/plumbing/format/gitignore"
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/internal/utility/location"
+	"github.com/google/osv-scanner/pkg/models"
+	"golang.org/x/sync/errgroup"
+)
+
+// skippedDirNames are the names of directories which are not walked into when
+// extracting from a directory, as they hold installed copies of dependencies
+// rather than lockfiles belonging to the project being scanned
+var skippedDirNames = map[string]struct{}{
+	".git":         {},
+	"node_modules": {},
+	"vendor":       {},
+}
+
+// ExtractAllFromDir walks the given directory, extracting packages from every
+// file that a registered extractor supports, and returns one PackageSource per lockfile.
+//
+// Directories such as node_modules and vendor are skipped. Errors encountered while
+// extracting a specific lockfile do not stop the walk; instead they are collected and
+// returned alongside the successfully extracted sources.
+func ExtractAllFromDir(root string) ([]models.PackageSource, error) {
+	return ExtractAllFromDirCtx(context.Background(), root)
+}
+
+// ExtractAllFromDirCtx is like ExtractAllFromDir, but stops walking the directory
+// once the given context is done, in which case the context's error is returned.
+func ExtractAllFromDirCtx(ctx context.Context, root string) ([]models.PackageSource, error) {
+	return ExtractAllFromDirWithOptions(ctx, root, ExtractDirOptions{})
+}
+
+type ExtractDirOptions struct {
+	// Workers is the maximum number of lockfiles that are extracted at the same time,
+	// defaulting to GOMAXPROCS if it is not positive
+	Workers int
+
+	// RelativeTo is the directory that the paths of sources and package locations are
+	// made relative to, so that they are the same regardless of where it is checked out.
+	// It is recorded as the ScanPath of each source, keeping the absolute path available
+	RelativeTo string
+
+	// Ignore is a list of gitignore-style patterns for files and directories that
+	// should not be extracted from, which are matched relative to the directory
+	// being walked so that ignored directories are not walked into at all
+	Ignore []string
+
+	// Include is a list of gitignore-style patterns such as "**/go.mod" that files must
+	// match to be extracted from when it is not empty, with files that are included
+	// still being skipped if they are ignored. Directories are walked regardless
+	Include []string
+
+	// FollowSymlinks is whether symlinks to directories are walked into, which is
+	// only done once for each real directory so that cycles cannot make the walk endless.
+	// Lockfiles that are symlinked are extracted from regardless, but only once for each
+	// real file, using the first path it is found at
+	FollowSymlinks bool
+
+	// Cache is where the packages extracted from each lockfile are stored, keyed by
+	// the hash of its contents, so that lockfiles which have not changed since the
+	// cache was last used are not parsed again
+	Cache ExtractCache
+
+	// OnFile is called once each lockfile has been extracted from, regardless of if
+	// it could be, with the path that it was found at, how many lockfiles have been
+	// extracted from including it, and the total number of lockfiles to extract from.
+	// The directory is walked before anything is extracted so that the total is known,
+	// and calls are never made at the same time even when several workers are used
+	OnFile func(path string, index, total int)
+}
+
+// dirLockfile is a lockfile that was found while walking a directory,
+// along with the extractor that it is to be extracted with
+type dirLockfile struct {
+	path      string
+	extractor Extractor
+}
+
+// newIgnoreMatcher returns a matcher for the given gitignore-style patterns,
+// or nil if there are no patterns to match against
+func newIgnoreMatcher(patterns []string) gitignore.Matcher {
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	ps := make([]gitignore.Pattern, 0, len(patterns))
+
+	for _, pattern := range patterns {
+		ps = append(ps, gitignore.ParsePattern(pattern, nil))
+	}
+
+	return gitignore.NewMatcher(ps)
+}
+
+// isIgnored checks if the given path within the root is matched by the matcher
+func isIgnored(matcher gitignore.Matcher, root string, path string, isDir bool) bool {
+	if matcher == nil {
+		return false
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." {
+		return false
+	}
+
+	return matcher.Match(strings.Split(filepath.ToSlash(rel), "/"), isDir)
+}
+
+// isIncluded checks if the given file within the root is matched by the matcher,
+// with every file being included if there is no matcher
+func isIncluded(matcher gitignore.Matcher, root string, path string) bool {
+	if matcher == nil {
+		return true
+	}
+
+	return isIgnored(matcher, root, path, false)
+}
+
+// isSkippedDir checks if the given directory within the root should not be walked into
+func isSkippedDir(matcher gitignore.Matcher, root string, path string, name string) bool {
+	if _, ok := skippedDirNames[name]; ok && path != root {
+		return true
+	}
+
+	return isIgnored(matcher, root, path, true)
+}
+
+// resolveSymlink returns the absolute real path of the given path, along with
+// if it is a directory, returning false if it cannot be resolved
+func resolveSymlink(path string) (string, bool, bool) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", false, false
+	}
+
+	target, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", false, false
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		return "", false, false
+	}
+
+	return target, info.IsDir(), true
+}
+
+// symlinkedWalkPath returns the path within the given directory that corresponds
+// to the given path within the real directory that the directory points to
+func symlinkedWalkPath(dir string, realDir string, realPath string) string {
+	rel, err := filepath.Rel(realDir, realPath)
+	if err != nil || rel == "." {
+		return dir
+	}
+
+	return filepath.Join(dir, rel)
+}
+
+// ExtractAllFromDirWithOptions is like ExtractAllFromDirCtx, but allows
+// configuring how the directory is extracted.
+func ExtractAllFromDirWithOptions(ctx context.Context, root string, opts ExtractDirOptions) ([]models.PackageSource, error) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	relativeTo := opts.RelativeTo
+	if relativeTo != "" {
+		var err error
+		if relativeTo, err = filepath.Abs(relativeTo); err != nil {
+			return nil, err
+		}
+	}
+
+	ignore := newIgnoreMatcher(opts.Ignore)
+	include := newIgnoreMatcher(opts.Include)
+
+	var lockfiles []dirLockfile
+
+	// the real paths of the directories that have been walked and the lockfiles that have been
+	// extracted, so that symlinks do not cause either to be done more than once
+	walkedDirs := map[string]struct{}{}
+	extractedFiles := map[string]struct{}{}
+
+	var walk func(dir string, realDir string) error
+
+	walk = func(dir string, realDir string) error {
+		return filepath.WalkDir(realDir, func(realPath string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			// the path that is reported is the one the file was found at, which is
+			// within a symlinked directory when walking the directory it points to
+			path := symlinkedWalkPath(dir, realDir, realPath)
+
+			if d.IsDir() {
+				if isSkippedDir(ignore, root, path, d.Name()) {
+					return filepath.SkipDir
+				}
+
+				walkedDirs[realPath] = struct{}{}
+
+				return nil
+			}
+
+			if isIgnored(ignore, root, path, false) {
+				return nil
+			}
+
+			if d.Type()&fs.ModeSymlink != 0 {
+				target, isDir, ok := resolveSymlink(realPath)
+
+				if ok && isDir {
+					if _, walked := walkedDirs[target]; walked || !opts.FollowSymlinks {
+						return nil
+					}
+
+					if isSkippedDir(ignore, root, path, d.Name()) {
+						return nil
+					}
+
+					return walk(path, target)
+				}
+
+				// dangling symlinks are left to fail when they are extracted from
+				if ok {
+					realPath = target
+				}
+			}
+
+			if !isIncluded(include, root, path) {
+				return nil
+			}
+
+			extractor, ok := FindExtractorForPath(path)
+			if !ok {
+				return nil
+			}
+
+			if _, extracted := extractedFiles[realPath]; extracted {
+				return nil
+			}
+
+			extractedFiles[realPath] = struct{}{}
+			lockfiles = append(lockfiles, dirLockfile{path: path, extractor: extractor})
+
+			return nil
+		})
+	}
+
+	realRoot := root
+	if target, isDir, ok := resolveSymlink(root); ok && isDir {
+		realRoot = target
+	}
+
+	err := walk(root, realRoot)
+
+	var mu sync.Mutex
+	var sources []models.PackageSource
+	var errs []error
+
+	extracted := 0
+
+	g := &errgroup.Group{}
+	g.SetLimit(workers)
+
+	for _, file := range lockfiles {
+		file := file
+
+		g.Go(func() error {
+			source, err := extractPackageSource(ctx, root, file.path, file.extractor, relativeTo, opts.Cache)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", file.path, err))
+			}
+
+			// the packages of the entries that could be extracted are still kept
+			if err == nil || OnlyEntryErrors(err) {
+				sources = append(sources, source)
+			}
+
+			extracted++
+
+			if opts.OnFile != nil {
+				opts.OnFile(file.path, extracted, len(lockfiles))
+			}
+
+			// errors are collected per lockfile, so that one failing does not stop the others
+			return nil
+		})
+	}
+
+	// nothing is returned by the workers, so there's no error to check here
+	_ = g.Wait()
+
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Source.Path < sources[j].Source.Path
+	})
+
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Error() < errs[j].Error()
+	})
+
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	return sources, errors.Join(errs...)
+}
+
+// extractPackagesWithCache extracts the packages from the given file using the extractor,
+// using the packages in the cache instead if the file has not changed since they were stored
+func extractPackagesWithCache(ctx context.Context, f DepFile, extractor Extractor, cache ExtractCache) ([]PackageDetails, error) {
+	hashed, ok := f.(DepFileWithContentHash)
+	if cache == nil || !ok {
+		return extractFromDepFile(ctx, f, extractor)
+	}
+
+	hash, err := hashed.ContentHash()
+	if err != nil {
+		return extractFromDepFile(ctx, f, extractor)
+	}
+
+	if packages, ok := cache.Get(f.Path(), hash); ok {
+		return packages, nil
+	}
+
+	packages, err := extractFromDepFile(ctx, f, extractor)
+	if err != nil {
+		return packages, err
+	}
+
+	cache.Put(f.Path(), hash, packages)
+
+	return packages, nil
+}
+
+func extractPackageSource(ctx context.Context, root string, path string, extractor Extractor, relativeTo string, cache ExtractCache) (models.PackageSource, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return models.PackageSource{}, err
+	}
+
+	f, err := OpenLocalDepFileInRoot(root, path)
+	if err != nil {
+		return models.PackageSource{}, err
+	}
+
+	defer f.Close()
+
+	packages, err := extractPackagesWithCache(ctx, f, extractor, cache)
+	if err != nil && !OnlyEntryErrors(err) {
+		return models.PackageSource{}, err
+	}
+
+	sort.Slice(packages, func(i, j int) bool {
+		if packages[i].Name == packages[j].Name {
+			return packages[i].Version < packages[j].Version
+		}
+
+		return packages[i].Name < packages[j].Name
+	})
+
+	return toPackageSource(path, packages, relativeTo), err
+}
+
+// extractPackageLocations returns the locations of the package, with their
+// filenames made relative to the given directory if there is one
+func extractPackageLocations(pkg PackageDetails, relativeTo string) []models.PackageLocations {
+	if !fileposition.IsFilePositionExtractedSuccessfully(pkg.BlockLocation) {
+		return []models.PackageLocations{}
+	}
+
+	locations := location.NewPackageLocations(pkg.BlockLocation, pkg.NameLocation, pkg.VersionLocation)
+
+	if relativeTo != "" {
+		locations.Block.Filename = fileposition.ToRelativePath(relativeTo, locations.Block.Filename)
+
+		if locations.Name != nil {
+			locations.Name.Filename = fileposition.ToRelativePath(relativeTo, locations.Name.Filename)
+		}
+
+		if locations.Version != nil {
+			locations.Version.Filename = fileposition.ToRelativePath(relativeTo, locations.Version.Filename)
+		}
+	}
+
+	return []models.PackageLocations{locations}
+}
+
+func toPackageSource(path string, details []PackageDetails, relativeTo string) models.PackageSource {
+	packages := make([]models.PackageVulns, 0, len(details))
+
+	for _, pkg := range details {
+		metadata := models.PackageMetadata{}
+
+		if len(pkg.PackageManager) > 0 && pkg.PackageManager != models.Unknown {
+			metadata[models.PackageManagerMetadata] = string(pkg.PackageManager)
+		}
+		if pkg.IsDirect {
+			metadata[models.IsDirectDependencyMetadata] = strconv.FormatBool(pkg.IsDirect)
+		}
+		if pkg.License != "" {
+			metadata[models.LicenseMetadata] = pkg.License
+		}
+
+		packages = append(packages, models.PackageVulns{
+			Package: models.PackageInfo{
+				Name:         pkg.Name,
+				Version:      pkg.Version,
+				Ecosystem:    string(pkg.Ecosystem),
+				Commit:       pkg.Commit,
+				Architecture: pkg.Architecture,
+			},
+			DepGroups:   pkg.DepGroups,
+			Transitive:  pkg.Transitive,
+			Locations:   extractPackageLocations(pkg, relativeTo),
+			Hashes:      pkg.Hashes,
+			RegistryURL: pkg.RegistryURL,
+			Metadata:    metadata,
+		})
+	}
+
+	source := models.SourceInfo{
+		Path: path,
+		Type: "lockfile",
+	}
+
+	if relativeTo != "" {
+		source.ScanPath = relativeTo
+		source.Path = fileposition.ToRelativePath(relativeTo, path)
+	}
+
+	return models.PackageSource{
+		Source:   source,
+		Packages: packages,
+	}
+}
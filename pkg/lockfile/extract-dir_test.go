@@ -0,0 +1,628 @@
+package lockfile_test
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestExtractAllFromDir(t *testing.T) {
+	t.Parallel()
+
+	root, err := filepath.Abs("fixtures/extract-dir")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	sources, err := lockfile.ExtractAllFromDir(root)
+
+	expectErrContaining(t, err, filepath.Join(root, "broken", "composer.lock"))
+
+	expectedPaths := []string{
+		filepath.Join(root, "go.mod"),
+		filepath.Join(root, "nested", "package-lock.json"),
+	}
+
+	if len(sources) != len(expectedPaths) {
+		t.Fatalf("Expected %d sources but got %d: %v", len(expectedPaths), len(sources), sources)
+	}
+
+	for i, source := range sources {
+		if source.Source.Path != expectedPaths[i] {
+			t.Errorf("Expected source %d to be %s but got %s", i, expectedPaths[i], source.Source.Path)
+		}
+
+		if source.Source.Type != "lockfile" {
+			t.Errorf("Expected source %d to have type lockfile but got %s", i, source.Source.Type)
+		}
+	}
+
+	expectedPackage := models.PackageInfo{
+		Name:      "github.com/BurntSushi/toml",
+		Version:   "1.0.0",
+		Ecosystem: string(lockfile.GoEcosystem),
+	}
+
+	if len(sources[0].Packages) != 1 || sources[0].Packages[0].Package != expectedPackage {
+		t.Errorf("Expected go.mod to contain %v but got %v", expectedPackage, sources[0].Packages)
+	}
+}
+
+func TestExtractAllFromDir_SkippedDirAsRoot(t *testing.T) {
+	t.Parallel()
+
+	sources, err := lockfile.ExtractAllFromDir("fixtures/extract-dir/node_modules")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	if len(sources) != 1 {
+		t.Errorf("Expected the root directory to be walked even though it is usually skipped, but got %v", sources)
+	}
+}
+
+func TestExtractAllFromDir_DirDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	sources, err := lockfile.ExtractAllFromDir("fixtures/extract-dir/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+
+	if len(sources) != 0 {
+		t.Errorf("Expected no sources but got %v", sources)
+	}
+}
+
+func TestExtractAllFromDirCtx_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	sources, err := lockfile.ExtractAllFromDirCtx(ctx, "fixtures/extract-dir")
+
+	expectErrIs(t, err, context.Canceled)
+
+	if len(sources) != 0 {
+		t.Errorf("Expected no sources but got %v", sources)
+	}
+}
+
+func TestExtractAllFromDirWithOptions_SingleWorker(t *testing.T) {
+	t.Parallel()
+
+	expected, expectedErr := lockfile.ExtractAllFromDir("fixtures/extract-dir")
+
+	sources, err := lockfile.ExtractAllFromDirWithOptions(
+		context.Background(),
+		"fixtures/extract-dir",
+		lockfile.ExtractDirOptions{Workers: 1},
+	)
+
+	if fmt.Sprint(err) != fmt.Sprint(expectedErr) {
+		t.Errorf("Expected error to be %v but got %v", expectedErr, err)
+	}
+
+	if !reflect.DeepEqual(sources, expected) {
+		t.Errorf("Expected extracting with one worker to give the same sources as the default")
+	}
+}
+
+func TestExtractAllFromDirWithOptions_OnFile(t *testing.T) {
+	t.Parallel()
+
+	root, err := filepath.Abs("fixtures/extract-dir")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	var paths []string
+	var indexes []int
+
+	// the callback is not safe to call at the same time, as the walker should never do so
+	_, _ = lockfile.ExtractAllFromDirWithOptions(context.Background(), root, lockfile.ExtractDirOptions{
+		Workers: 4,
+		OnFile: func(path string, index, total int) {
+			if total != 3 {
+				t.Errorf("Expected the total to be 3 but got %d", total)
+			}
+
+			paths = append(paths, path)
+			indexes = append(indexes, index)
+		},
+	})
+
+	if !reflect.DeepEqual(indexes, []int{1, 2, 3}) {
+		t.Errorf("Expected the indexes to be [1 2 3] but got %v", indexes)
+	}
+
+	slices.Sort(paths)
+
+	expectedPaths := []string{
+		filepath.Join(root, "broken", "composer.lock"),
+		filepath.Join(root, "go.mod"),
+		filepath.Join(root, "nested", "package-lock.json"),
+	}
+
+	if !reflect.DeepEqual(paths, expectedPaths) {
+		t.Errorf("Expected OnFile to be called for %v but got %v", expectedPaths, paths)
+	}
+}
+
+func TestExtractAllFromDirWithOptions_RelativeTo(t *testing.T) {
+	t.Parallel()
+
+	root, err := filepath.Abs("fixtures/extract-dir")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	sources, _ := lockfile.ExtractAllFromDirWithOptions(
+		context.Background(),
+		"fixtures/extract-dir",
+		lockfile.ExtractDirOptions{RelativeTo: "fixtures/extract-dir"},
+	)
+
+	expectedPaths := []string{"go.mod", "nested/package-lock.json"}
+
+	if len(sources) != len(expectedPaths) {
+		t.Fatalf("Expected %d sources but got %d: %v", len(expectedPaths), len(sources), sources)
+	}
+
+	for i, source := range sources {
+		if source.Source.Path != expectedPaths[i] {
+			t.Errorf("Expected source %d to be %s but got %s", i, expectedPaths[i], source.Source.Path)
+		}
+
+		if source.Source.ScanPath != root {
+			t.Errorf("Expected source %d to have been scanned from %s but got %s", i, root, source.Source.ScanPath)
+		}
+
+		expectedAbsolutePath := filepath.Join(root, filepath.FromSlash(expectedPaths[i]))
+		if absolutePath := source.Source.AbsolutePath(); absolutePath != expectedAbsolutePath {
+			t.Errorf("Expected source %d to have the absolute path %s but got %s", i, expectedAbsolutePath, absolutePath)
+		}
+
+		// packages can be located in a manifest next to the lockfile, rather than in the lockfile itself
+		for _, pkg := range source.Packages {
+			for _, location := range pkg.Locations {
+				if path.Dir(location.Block.Filename) != path.Dir(expectedPaths[i]) {
+					t.Errorf("Expected %s to be located next to %s but got %s", pkg.Package.Name, expectedPaths[i], location.Block.Filename)
+				}
+			}
+		}
+	}
+}
+
+func TestExtractAllFromDirWithOptions_Ignore(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		ignore []string
+		want   []string
+	}{
+		{
+			name:   "nothing ignored",
+			ignore: nil,
+			want: []string{
+				"examples/basic/requirements.txt",
+				"go.mod",
+				"requirements.bak.txt",
+				"requirements.txt",
+				"third_party/lib/go.mod",
+			},
+		},
+		{
+			name:   "directories",
+			ignore: []string{"third_party/", "examples/"},
+			want: []string{
+				"go.mod",
+				"requirements.bak.txt",
+				"requirements.txt",
+			},
+		},
+		{
+			name:   "extensions",
+			ignore: []string{"*.bak.txt"},
+			want: []string{
+				"examples/basic/requirements.txt",
+				"go.mod",
+				"requirements.txt",
+				"third_party/lib/go.mod",
+			},
+		},
+		{
+			name:   "anchored to the root",
+			ignore: []string{"/requirements.txt"},
+			want: []string{
+				"examples/basic/requirements.txt",
+				"go.mod",
+				"requirements.bak.txt",
+				"third_party/lib/go.mod",
+			},
+		},
+		{
+			name:   "negated",
+			ignore: []string{"requirements*.txt", "!examples/**/requirements.txt"},
+			want: []string{
+				"examples/basic/requirements.txt",
+				"go.mod",
+				"third_party/lib/go.mod",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sources, err := lockfile.ExtractAllFromDirWithOptions(
+				context.Background(),
+				"fixtures/extract-dir-ignore",
+				lockfile.ExtractDirOptions{
+					RelativeTo: "fixtures/extract-dir-ignore",
+					Ignore:     tt.ignore,
+				},
+			)
+
+			if err != nil {
+				t.Errorf("Got unexpected error: %v", err)
+			}
+
+			got := make([]string, 0, len(sources))
+			for _, source := range sources {
+				got = append(got, source.Source.Path)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected sources %v but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestExtractAllFromDirWithOptions_Include(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		include []string
+		ignore  []string
+		want    []string
+	}{
+		{
+			name:    "recursive",
+			include: []string{"**/go.mod"},
+			want: []string{
+				"go.mod",
+				"third_party/lib/go.mod",
+			},
+		},
+		{
+			name:    "directories",
+			include: []string{"examples/**", "third_party/**"},
+			want: []string{
+				"examples/basic/requirements.txt",
+				"third_party/lib/go.mod",
+			},
+		},
+		{
+			name:    "anchored to the root",
+			include: []string{"/go.mod", "/requirements.txt"},
+			want: []string{
+				"go.mod",
+				"requirements.txt",
+			},
+		},
+		{
+			name:    "with ignore",
+			include: []string{"**/go.mod", "**/requirements.txt"},
+			ignore:  []string{"third_party/", "/requirements.txt"},
+			want: []string{
+				"examples/basic/requirements.txt",
+				"go.mod",
+			},
+		},
+		{
+			name:    "nothing matched",
+			include: []string{"**/Cargo.lock"},
+			want:    []string{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sources, err := lockfile.ExtractAllFromDirWithOptions(
+				context.Background(),
+				"fixtures/extract-dir-ignore",
+				lockfile.ExtractDirOptions{
+					RelativeTo: "fixtures/extract-dir-ignore",
+					Include:    tt.include,
+					Ignore:     tt.ignore,
+				},
+			)
+
+			if err != nil {
+				t.Errorf("Got unexpected error: %v", err)
+			}
+
+			got := make([]string, 0, len(sources))
+			for _, source := range sources {
+				got = append(got, source.Source.Path)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected sources %v but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// createSymlinkedLockfilesDir creates a directory tree where a lockfile is symlinked
+// into another directory, a directory outside of the tree is symlinked into it, and
+// there is a symlink to the root of the tree so that it has a cycle
+func createSymlinkedLockfilesDir(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	outside := t.TempDir()
+
+	content, err := os.ReadFile("fixtures/go/two-packages.mod")
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	for _, dir := range []string{"app", "shared"} {
+		if err := os.Mkdir(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatalf("could not create directory: %v", err)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "shared", "go.mod"), content, 0o600); err != nil {
+		t.Fatalf("could not write lockfile: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(outside, "go.mod"), content, 0o600); err != nil {
+		t.Fatalf("could not write lockfile: %v", err)
+	}
+
+	symlinks := map[string]string{
+		filepath.Join(root, "app", "go.mod"): filepath.Join("..", "shared", "go.mod"),
+		filepath.Join(root, "app", "loop"):   "..",
+		filepath.Join(root, "external"):      outside,
+		filepath.Join(root, "linked"):        "shared",
+	}
+
+	for link, target := range symlinks {
+		if err := os.Symlink(target, link); err != nil {
+			t.Skipf("symlinks are not supported: %v", err)
+		}
+	}
+
+	return root
+}
+
+func TestExtractAllFromDirWithOptions_Symlinks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		followSymlinks bool
+		want           []string
+	}{
+		{
+			name:           "not following symlinks",
+			followSymlinks: false,
+			want: []string{
+				"app/go.mod",
+			},
+		},
+		{
+			name:           "following symlinks",
+			followSymlinks: true,
+			want: []string{
+				"app/go.mod",
+				"external/go.mod",
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			root := createSymlinkedLockfilesDir(t)
+
+			sources, err := lockfile.ExtractAllFromDirWithOptions(
+				context.Background(),
+				root,
+				lockfile.ExtractDirOptions{
+					RelativeTo:     root,
+					FollowSymlinks: tt.followSymlinks,
+				},
+			)
+
+			if err != nil {
+				t.Errorf("Got unexpected error: %v", err)
+			}
+
+			got := make([]string, 0, len(sources))
+			for _, source := range sources {
+				got = append(got, filepath.ToSlash(source.Source.Path))
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Expected sources %v but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+// countingExtractCache is an ExtractCache that counts how many times
+// lockfiles were found in it and how many had to be extracted from
+type countingExtractCache struct {
+	*lockfile.MemoryExtractCache
+
+	hits atomic.Int32
+	puts atomic.Int32
+}
+
+func (c *countingExtractCache) Get(path string, hash string) ([]lockfile.PackageDetails, bool) {
+	packages, ok := c.MemoryExtractCache.Get(path, hash)
+
+	if ok {
+		c.hits.Add(1)
+	}
+
+	return packages, ok
+}
+
+func (c *countingExtractCache) Put(path string, hash string, packages []lockfile.PackageDetails) {
+	c.puts.Add(1)
+	c.MemoryExtractCache.Put(path, hash, packages)
+}
+
+func TestExtractAllFromDirWithOptions_Cache(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+
+	for name, fixture := range map[string]string{
+		"go.mod":            "fixtures/go/one-package.mod",
+		"package-lock.json": "fixtures/npm/one-package.v2.json",
+	} {
+		content, err := os.ReadFile(fixture)
+		if err != nil {
+			t.Fatalf("could not read fixture: %v", err)
+		}
+
+		if err := os.WriteFile(filepath.Join(root, name), content, 0o600); err != nil {
+			t.Fatalf("could not write lockfile: %v", err)
+		}
+	}
+
+	cache := &countingExtractCache{MemoryExtractCache: lockfile.NewMemoryExtractCache()}
+	opts := lockfile.ExtractDirOptions{Cache: cache}
+
+	expected, err := lockfile.ExtractAllFromDirWithOptions(context.Background(), root, opts)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if hits, puts := cache.hits.Load(), cache.puts.Load(); hits != 0 || puts != 2 {
+		t.Errorf("Expected the first scan to extract from every lockfile, but had %d hits and %d puts", hits, puts)
+	}
+
+	sources, err := lockfile.ExtractAllFromDirWithOptions(context.Background(), root, opts)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if hits, puts := cache.hits.Load(), cache.puts.Load(); hits != 2 || puts != 2 {
+		t.Errorf("Expected the second scan to not extract from any lockfile, but had %d hits and %d puts", hits, puts)
+	}
+
+	if !reflect.DeepEqual(sources, expected) {
+		t.Errorf("Expected the second scan to give the same sources as the first")
+	}
+
+	content, err := os.ReadFile("fixtures/go/two-packages.mod")
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "go.mod"), content, 0o600); err != nil {
+		t.Fatalf("could not write lockfile: %v", err)
+	}
+
+	sources, err = lockfile.ExtractAllFromDirWithOptions(context.Background(), root, opts)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if hits, puts := cache.hits.Load(), cache.puts.Load(); hits != 3 || puts != 3 {
+		t.Errorf("Expected only the changed lockfile to be extracted from, but had %d hits and %d puts", hits, puts)
+	}
+
+	if reflect.DeepEqual(sources[0], expected[0]) {
+		t.Errorf("Expected the changed go.mod to be extracted from again, but got the cached packages")
+	}
+}
+
+// createManyLockfilesDir creates a directory tree containing n copies of some lockfiles
+func createManyLockfilesDir(b *testing.B, n int) string {
+	b.Helper()
+
+	root := b.TempDir()
+	// these lockfiles are picked because they do not get matched against another file
+	lockfiles := map[string]string{
+		"fixtures/cargo/two-packages.lock":           "Cargo.lock",
+		"fixtures/conan/nested-dependencies.v2.json": "conan.lock",
+		"fixtures/go/two-packages.mod":               "go.mod",
+		"fixtures/mix/many.lock":                     "mix.lock",
+		"fixtures/pdm/two-packages.toml":             "pdm.lock",
+		"fixtures/pip/multiple-packages-mixed.txt":   "requirements.txt",
+		"fixtures/pub/two-packages.lock":             "pubspec.lock",
+		"fixtures/renv/two-packages.lock":            "renv.lock",
+	}
+
+	for i := 0; i < n; i++ {
+		dir := filepath.Join(root, fmt.Sprintf("project-%d", i))
+
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			b.Fatalf("could not create directory: %v", err)
+		}
+
+		for fixture, name := range lockfiles {
+			content, err := os.ReadFile(fixture)
+			if err != nil {
+				b.Fatalf("could not read fixture: %v", err)
+			}
+
+			if err := os.WriteFile(filepath.Join(dir, name), content, 0o600); err != nil {
+				b.Fatalf("could not write lockfile: %v", err)
+			}
+		}
+	}
+
+	return root
+}
+
+func BenchmarkExtractAllFromDir(b *testing.B) {
+	root := createManyLockfilesDir(b, 50)
+
+	for _, workers := range []int{1, 0} {
+		name := "default workers"
+		if workers == 1 {
+			name = "one worker"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, err := lockfile.ExtractAllFromDirWithOptions(
+					context.Background(),
+					root,
+					lockfile.ExtractDirOptions{Workers: workers},
+				)
+
+				if err != nil {
+					b.Fatalf("Got unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
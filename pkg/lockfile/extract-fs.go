@@ -0,0 +1,69 @@
+package lockfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"strings"
+)
+
+// An FSFile represents a file within an fs.FS, such as an entry of an archive.
+type FSFile struct {
+	io.Reader
+	io.Closer
+
+	fsys fs.FS
+	path string
+}
+
+// Open opens the file with the given name within the same fs.FS as this file,
+// with absolute names being relative to the root of the fs.FS.
+func (f FSFile) Open(name string) (NestedDepFile, error) {
+	if path.IsAbs(name) {
+		return OpenFSDepFile(f.fsys, strings.TrimPrefix(name, "/"))
+	}
+
+	return OpenFSDepFile(f.fsys, path.Join(path.Dir(f.path), name))
+}
+
+func (f FSFile) Path() string { return f.path }
+
+// OpenFSDepFile opens the file with the given name within the fs.FS for extracting,
+// which must be a valid path as described by fs.ValidPath.
+func OpenFSDepFile(fsys fs.FS, name string) (NestedDepFile, error) {
+	r, err := fsys.Open(name)
+
+	if err != nil {
+		return FSFile{}, err
+	}
+
+	return FSFile{newBOMDecodingReader(r), r, fsys, name}, nil
+}
+
+var _ DepFile = FSFile{}
+var _ NestedDepFile = FSFile{}
+
+// ExtractFromFS extracts the packages from the file with the given name within the fs.FS,
+// using the extractor registered for its path.
+//
+// This allows extracting from files that are not on the local filesystem, such as
+// those in a jar that has been opened with zip.OpenReader.
+func ExtractFromFS(fsys fs.FS, name string) ([]PackageDetails, error) {
+	extractor, ok := FindExtractorForPath(name)
+
+	if !ok {
+		return []PackageDetails{}, fmt.Errorf("%w for %s", ErrExtractorNotFound, name)
+	}
+
+	f, err := OpenFSDepFile(fsys, name)
+
+	if err != nil {
+		return []PackageDetails{}, err
+	}
+
+	defer f.Close()
+
+	return extractFromDepFile(context.Background(), f, extractor)
+}
@@ -0,0 +1,155 @@
+package lockfile_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/fs"
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestExtractFromFS(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile("fixtures/go/one-package.mod")
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	fsys := fstest.MapFS{"my-library/go.mod": {Data: content}}
+
+	packages, err := lockfile.ExtractFromFS(fsys, "my-library/go.mod")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 2, End: 35},
+				Filename: "my-library/go.mod",
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 2, End: 28},
+				Filename: "my-library/go.mod",
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 30, End: 35},
+				Filename: "my-library/go.mod",
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestExtractFromFS_Archive(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile("fixtures/npm/one-package.v2.json")
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	var buf bytes.Buffer
+
+	w := zip.NewWriter(&buf)
+	entry, err := w.Create("my-library/package-lock.json")
+	if err != nil {
+		t.Fatalf("could not create archive entry: %v", err)
+	}
+	if _, err = entry.Write(content); err != nil {
+		t.Fatalf("could not write archive entry: %v", err)
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("could not close archive: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("could not read archive: %v", err)
+	}
+
+	packages, err := lockfile.ExtractFromFS(archive, "my-library/package-lock.json")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "wrappy",
+			Version:        "1.0.2",
+			PackageManager: models.NPM,
+			TargetVersions: []string{"^1.0.0"},
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			IsDirect:       true,
+		},
+	})
+}
+
+func TestExtractFromFS_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ExtractFromFS(fstest.MapFS{}, "my-library/go.mod")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestExtractFromFS_ExtractorNotFound(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{"my-library/README.md": {Data: []byte("# my-library\n")}}
+
+	packages, err := lockfile.ExtractFromFS(fsys, "my-library/README.md")
+
+	expectErrIs(t, err, lockfile.ErrExtractorNotFound)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestFSFile_Open(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"my-library/package-lock.json": {Data: []byte("{}")},
+		"my-library/package.json":      {Data: []byte("{}")},
+		"package.json":                 {Data: []byte("{}")},
+	}
+
+	f, err := lockfile.OpenFSDepFile(fsys, "my-library/package-lock.json")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	for name, expected := range map[string]string{
+		"package.json":  "my-library/package.json",
+		"/package.json": "package.json",
+	} {
+		nested, err := f.Open(name)
+		if err != nil {
+			t.Errorf("Got unexpected error opening %s: %v", name, err)
+
+			continue
+		}
+
+		if nested.Path() != expected {
+			t.Errorf("Expected %s to open %s, but got %s", name, expected, nested.Path())
+		}
+
+		nested.Close()
+	}
+}
@@ -0,0 +1,42 @@
+package lockfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// StdinPath is the path that is used for reading a lockfile from stdin, both by
+// ParseStdin and when it is passed as the path to a parser like ParseGoLock.
+const StdinPath = "-"
+
+// A ReaderFile represents a file whose contents have already been read or fetched,
+// such as from a network request or a git object, and so is not on any filesystem.
+type ReaderFile struct {
+	io.Reader
+
+	path string
+}
+
+// Open always fails as a ReaderFile has no other files around it.
+func (f ReaderFile) Open(name string) (NestedDepFile, error) {
+	return nil, fmt.Errorf("%w: %s is not on a filesystem", ErrOpenNotSupported, f.path)
+}
+
+func (f ReaderFile) Path() string { return f.path }
+
+// NewReaderDepFile returns a DepFile with the given name for the contents of the reader.
+func NewReaderDepFile(name string, r io.Reader) DepFile {
+	return ReaderFile{newBOMDecodingReader(r), name}
+}
+
+var _ DepFile = ReaderFile{}
+
+// ExtractFromReader extracts the packages from the contents of the reader using the
+// extractor, with the given name being used as the path of the file.
+//
+// This allows extracting from files without having to write them to disk first,
+// though any files that are normally opened alongside the file will not be found.
+func ExtractFromReader(name string, r io.Reader, extractor Extractor) ([]PackageDetails, error) {
+	return extractFromDepFile(context.Background(), NewReaderDepFile(name, r), extractor)
+}
@@ -0,0 +1,164 @@
+package lockfile_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestExtractFromReader(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile("fixtures/go/one-package.mod")
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	packages, err := lockfile.ExtractFromReader("my-library/go.mod", bytes.NewReader(content), lockfile.GoLockExtractor{})
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 2, End: 35},
+				Filename: "my-library/go.mod",
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 2, End: 28},
+				Filename: "my-library/go.mod",
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 30, End: 35},
+				Filename: "my-library/go.mod",
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestExtractFromReader_Invalid(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ExtractFromReader(
+		"my-library/go.mod",
+		strings.NewReader("this is not a go.mod file!"),
+		lockfile.GoLockExtractor{},
+	)
+
+	expectErrContaining(t, err, "my-library/go.mod")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestReaderFile_Open(t *testing.T) {
+	t.Parallel()
+
+	f := lockfile.NewReaderDepFile("my-library/package-lock.json", strings.NewReader("{}"))
+
+	_, err := f.Open("package.json")
+
+	expectErrIs(t, err, lockfile.ErrOpenNotSupported)
+}
+
+func TestParseReaderAs(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile("fixtures/go/one-package.mod")
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	packages, err := lockfile.ParseReaderAs(bytes.NewReader(content), lockfile.GoEcosystem)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 2, End: 35},
+				Filename: lockfile.StdinPath,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 2, End: 28},
+				Filename: lockfile.StdinPath,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 30, End: 35},
+				Filename: lockfile.StdinPath,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestParseReaderAs_UnknownEcosystem(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseReaderAs(strings.NewReader("{}"), lockfile.Ecosystem("unknown"))
+
+	expectErrIs(t, err, lockfile.ErrParserNotFound)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+//nolint:paralleltest
+func TestParseGoLock_Stdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("could not create pipe: %v", err)
+	}
+
+	stdin := os.Stdin
+	os.Stdin = r
+	t.Cleanup(func() { os.Stdin = stdin })
+
+	content, err := os.ReadFile("fixtures/go/one-package.mod")
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	if _, err := w.Write(content); err != nil {
+		t.Fatalf("could not write to pipe: %v", err)
+	}
+	w.Close()
+
+	packages, err := lockfile.ParseGoLock(lockfile.StdinPath)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+	})
+}
@@ -1,24 +1,152 @@
 package lockfile
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"os"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 )
 
-var lockfileExtractors = map[string]Extractor{}
+var (
+	lockfileExtractorsMu sync.RWMutex
+	lockfileExtractors   = map[string]Extractor{}
+	// lockfileExtractorNames tracks the order extractors were registered in,
+	// which is used to decide which one wins when several can extract a path
+	// and they have the same priority
+	lockfileExtractorNames []string
+	// lockfileExtractorPriorities are the priorities that extractors were registered
+	// with, which decide which one wins when several can extract a path
+	lockfileExtractorPriorities = map[string]int{}
+	// lockfileExtractorEcosystems are the ecosystems of the packages extracted by
+	// the built-in extractors, which are not known for those registered by others
+	lockfileExtractorEcosystems = map[string]Ecosystem{}
+)
+
+var ErrExtractorAlreadyRegistered = errors.New("an extractor is already registered")
+
+func registerExtractor(name string, ecosystem Ecosystem, extractor Extractor) {
+	if err := RegisterExtractor(name, extractor); err != nil {
+		panic(err)
+	}
+
+	lockfileExtractorsMu.Lock()
+	defer lockfileExtractorsMu.Unlock()
+
+	lockfileExtractorEcosystems[name] = ecosystem
+}
+
+// RegisterExtractor makes the given Extractor available under the given name,
+// so that it is picked up by FindExtractor and ExtractDeps.
+//
+// The extractor has the same priority as the built-in extractors, which is 0.
+//
+// An error is returned if an extractor is already registered under that name;
+// use OverrideExtractor to replace an existing extractor instead.
+func RegisterExtractor(name string, extractor Extractor) error {
+	return RegisterExtractorWithPriority(name, extractor, 0)
+}
+
+// RegisterExtractorWithPriority is like RegisterExtractor, but registers the
+// Extractor with the given priority, so that it wins over the extractors with a
+// lower priority when several of them can extract a path, such as to have an
+// extractor take over a file that a built-in extractor would otherwise extract.
+func RegisterExtractorWithPriority(name string, extractor Extractor, priority int) error {
+	lockfileExtractorsMu.Lock()
+	defer lockfileExtractorsMu.Unlock()
 
-func registerExtractor(name string, extractor Extractor) {
 	if _, ok := lockfileExtractors[name]; ok {
-		panic("an extractor is already registered as " + name)
+		return fmt.Errorf("%w as %s", ErrExtractorAlreadyRegistered, name)
+	}
+
+	lockfileExtractors[name] = extractor
+	lockfileExtractorNames = append(lockfileExtractorNames, name)
+	lockfileExtractorPriorities[name] = priority
+
+	return nil
+}
+
+// OverrideExtractor registers the given Extractor under the given name,
+// replacing any extractor (including a built-in one) that was already registered as it.
+//
+// An overridden extractor keeps the precedence and priority of the one it replaces.
+func OverrideExtractor(name string, extractor Extractor) {
+	lockfileExtractorsMu.Lock()
+	defer lockfileExtractorsMu.Unlock()
+
+	if _, ok := lockfileExtractors[name]; !ok {
+		lockfileExtractorNames = append(lockfileExtractorNames, name)
 	}
 
 	lockfileExtractors[name] = extractor
 }
 
+// findExtractorForPath returns the name of the extractor that should be used
+// for the given path, considering only the extractors allowed by isEnabled.
+//
+// When multiple extractors can extract the path, the one with the highest
+// priority wins, with the last registered one winning if they have the same.
+func findExtractorForPath(path string, isEnabled func(name string) bool) (Extractor, string) {
+	var found Extractor
+	foundName := ""
+
+	for i := len(lockfileExtractorNames) - 1; i >= 0; i-- {
+		name := lockfileExtractorNames[i]
+		extractor := lockfileExtractors[name]
+
+		if found != nil && lockfileExtractorPriorities[name] <= lockfileExtractorPriorities[foundName] {
+			continue
+		}
+
+		if isEnabled(name) && extractor.ShouldExtract(path) {
+			found, foundName = extractor, name
+		}
+	}
+
+	return found, foundName
+}
+
+// FindExtractorForPath returns the registered Extractor that should be used
+// to extract the given path, regardless of which parsers are enabled.
+//
+// When multiple extractors can extract the path, the one with the highest
+// priority wins, with the last registered one winning if they have the same.
+func FindExtractorForPath(path string) (Extractor, bool) {
+	lockfileExtractorsMu.RLock()
+	defer lockfileExtractorsMu.RUnlock()
+
+	extractor, _ := findExtractorForPath(path, func(string) bool { return true })
+
+	return extractor, extractor != nil
+}
+
+// DepGroupsFor returns the groups that packages extracted from the given path
+// can have in their DepGroups, which is nil if the format does not have groups
+// or there is no extractor for the path.
+func DepGroupsFor(path string) []string {
+	extractor, ok := FindExtractorForPath(path)
+	if !ok {
+		return nil
+	}
+
+	if e, ok := extractor.(ExtractorWithDepGroups); ok {
+		return e.SupportedDepGroups()
+	}
+
+	return nil
+}
+
+// FindExtractor returns the enabled Extractor that should be used for the given path,
+// unless extractAs explicitly names the extractor to use.
+//
+// When multiple enabled extractors can extract the path, the one with the highest
+// priority wins, with the last registered one winning if they have the same.
 func FindExtractor(path, extractAs string, enabledParsers map[string]bool) (Extractor, string) {
+	lockfileExtractorsMu.RLock()
+	defer lockfileExtractorsMu.RUnlock()
+
 	if extractAs != "" {
 		if enabledParsers[extractAs] {
 			return lockfileExtractors[extractAs], extractAs
@@ -27,17 +155,13 @@ func FindExtractor(path, extractAs string, enabledParsers map[string]bool) (Extr
 		return nil, ""
 	}
 
-	for name, extractor := range lockfileExtractors {
-		isEnabled := enabledParsers[name]
-		if isEnabled && extractor.ShouldExtract(path) {
-			return extractor, name
-		}
-	}
-
-	return nil, ""
+	return findExtractorForPath(path, func(name string) bool { return enabledParsers[name] })
 }
 
 func ListExtractors() []string {
+	lockfileExtractorsMu.RLock()
+	defer lockfileExtractorsMu.RUnlock()
+
 	es := make([]string, 0, len(lockfileExtractors))
 
 	for s := range lockfileExtractors {
@@ -51,9 +175,39 @@ func ListExtractors() []string {
 	return es
 }
 
+// SupportedFilenames returns the names of the files that can be extracted by
+// the registered extractors, including those registered with RegisterExtractor.
+func SupportedFilenames() []string {
+	return ListExtractors()
+}
+
+// SupportedEcosystems returns the ecosystems of the packages that can be
+// extracted by the built-in extractors, sorted by name.
+func SupportedEcosystems() []Ecosystem {
+	lockfileExtractorsMu.RLock()
+	defer lockfileExtractorsMu.RUnlock()
+
+	ecosystems := make([]Ecosystem, 0, len(lockfileExtractorEcosystems))
+
+	for _, ecosystem := range lockfileExtractorEcosystems {
+		if !slices.Contains(ecosystems, ecosystem) {
+			ecosystems = append(ecosystems, ecosystem)
+		}
+	}
+
+	slices.Sort(ecosystems)
+
+	return ecosystems
+}
+
 var ErrExtractorNotFound = errors.New("could not determine extractor")
 
 func ExtractDeps(f DepFile, extractAs string, enabledParsers map[string]bool) (Lockfile, error) {
+	return ExtractDepsCtx(context.Background(), f, extractAs, enabledParsers)
+}
+
+// ExtractDepsCtx is like ExtractDeps, but stops extracting early if the given context is done.
+func ExtractDepsCtx(ctx context.Context, f DepFile, extractAs string, enabledParsers map[string]bool) (Lockfile, error) {
 	extractor, extractedAs := FindExtractor(f.Path(), extractAs, enabledParsers)
 
 	if extractor == nil {
@@ -64,7 +218,12 @@ func ExtractDeps(f DepFile, extractAs string, enabledParsers map[string]bool) (L
 		return Lockfile{}, fmt.Errorf("%w for %s", ErrExtractorNotFound, f.Path())
 	}
 
-	packages, err := extractor.Extract(f)
+	packages, err := extractWithContext(ctx, extractor, f)
+
+	// there's no point enriching the packages if we've been told to stop
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return Lockfile{}, ctxErr
+	}
 
 	if err != nil && extractedAs != "" {
 		//nolint:all
@@ -76,7 +235,7 @@ func ExtractDeps(f DepFile, extractAs string, enabledParsers map[string]bool) (L
 		if matcher := e.GetMatcher(); matcher != nil {
 			matchError := matchWithFile(f, packages, matcher)
 			if matchError != nil {
-				_, _ = fmt.Fprintf(os.Stderr, "there was an error matching the source file: %s\n", matchError.Error())
+				logWarningf("there was an error matching the source file: %s\n", matchError.Error())
 			}
 		}
 	}
@@ -95,14 +254,20 @@ func ExtractDeps(f DepFile, extractAs string, enabledParsers map[string]bool) (L
 		Packages: packages,
 	}
 
-	depFile, err := OpenLocalDepFile(f.Path())
-	if err != nil {
-		return parsedLockfile, err
-	}
+	// only local files can be opened again to get their artifact, with the error from
+	// extracting being kept so that it is still returned alongside the artifact
 	if e, ok := extractor.(ArtifactExtractor); ok {
-		artifact, err := e.GetArtifact(depFile)
-		if err == nil {
-			parsedLockfile.Artifact = artifact
+		if _, isLocal := f.(LocalFile); isLocal {
+			depFile, openErr := OpenLocalDepFile(f.Path())
+			if openErr != nil {
+				return parsedLockfile, errors.Join(err, openErr)
+			}
+			defer depFile.Close()
+
+			artifact, err := e.GetArtifact(depFile)
+			if err == nil {
+				parsedLockfile.Artifact = artifact
+			}
 		}
 	}
 
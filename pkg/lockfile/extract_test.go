@@ -1,12 +1,20 @@
 package lockfile_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"io"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
 )
 
 type TestDepFile struct {
@@ -33,24 +41,41 @@ func TestFindExtractor(t *testing.T) {
 	t.Parallel()
 
 	lockfiles := map[string]string{
-		"buildscript-gradle.lockfile":      "gradle.lockfile",
+		".terraform.lock.hcl":              ".terraform.lock.hcl",
+		"buildscript-gradle.lockfile":      "buildscript-gradle.lockfile",
+		"bun.lock":                         "bun.lock",
+		"bun.lockb":                        "bun.lockb",
+		"cabal.project.freeze":             "cabal.project.freeze",
 		"Cargo.lock":                       "Cargo.lock",
+		"Chart.lock":                       "Chart.lock",
 		"composer.lock":                    "composer.lock",
+		"deno.lock":                        "deno.lock",
+		"flake.lock":                       "flake.lock",
 		"Gemfile.lock":                     "Gemfile.lock",
 		"go.mod":                           "go.mod",
 		"gradle/verification-metadata.xml": "gradle/verification-metadata.xml",
 		"gradle.lockfile":                  "gradle.lockfile",
+		"ivy.xml":                          "ivy.xml",
+		"maven_install.json":               "maven_install.json",
 		"mix.lock":                         "mix.lock",
+		"MyApp.csproj":                     "csproj",
+		"MyApp.fsproj":                     "fsproj",
+		"MyApp.vbproj":                     "vbproj",
 		"pdm.lock":                         "pdm.lock",
 		"Pipfile.lock":                     "Pipfile.lock",
 		"package-lock.json":                "package-lock.json",
+		"Package.resolved":                 "Package.resolved",
+		"packages.config":                  "packages.config",
 		"packages.lock.json":               "packages.lock.json",
 		"pnpm-lock.yaml":                   "pnpm-lock.yaml",
+		"Podfile.lock":                     "Podfile.lock",
 		"poetry.lock":                      "poetry.lock",
 		"pom.xml":                          "pom.xml",
 		"pubspec.lock":                     "pubspec.lock",
 		"renv.lock":                        "renv.lock",
 		"requirements.txt":                 "requirements.txt",
+		"stack.yaml.lock":                  "stack.yaml.lock",
+		"uv.lock":                          "uv.lock",
 		"yarn.lock":                        "yarn.lock",
 	}
 	enabledParsers := make(map[string]bool)
@@ -68,6 +93,31 @@ func TestFindExtractor(t *testing.T) {
 	}
 }
 
+func TestDepGroupsFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "/path/to/my/composer.lock", want: []string{"dev"}},
+		{path: "/path/to/my/composer.json", want: []string{"dev"}},
+		{path: "/path/to/my/package-lock.json", want: []string{"dev", "optional", "peer"}},
+		{path: "/path/to/my/Pipfile.lock", want: []string{"dev", "editable"}},
+		{path: "/path/to/my/buildscript-gradle.lockfile", want: []string{"buildscript"}},
+		{path: "/path/to/my/gradle.lockfile", want: nil},
+		{path: "/path/to/my/go.mod", want: []string{"tool"}},
+		{path: "/path/to/my/Cargo.lock", want: nil},
+		{path: "/path/to/my/unknown.lock", want: nil},
+	}
+
+	for _, tt := range tests {
+		if got := lockfile.DepGroupsFor(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("DepGroupsFor(%s) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
+
 func TestFindExtractor_ExplicitExtractAs(t *testing.T) {
 	t.Parallel()
 
@@ -89,32 +139,57 @@ func TestExtractDeps_FindsExpectedExtractor(t *testing.T) {
 	t.Parallel()
 
 	lockfiles := []string{
+		".terraform.lock.hcl",
 		"buildscript-gradle.lockfile",
+		"bun.lock",
+		"build.gradle",
+		"cabal.project.freeze",
 		"Cargo.lock",
+		"Cargo.toml",
+		"Chart.lock",
+		"composer.json",
 		"composer.lock",
 		"conan.lock",
+		"deno.lock",
+		"flake.lock",
 		"Gemfile.lock",
 		"go.mod",
+		"go.work",
 		"gradle.lockfile",
 		"gradle/verification-metadata.xml",
+		"ivy.xml",
+		"maven_install.json",
 		"mix.lock",
+		"MyApp.csproj",
+		"MyApp.fsproj",
+		"MyApp.vbproj",
 		"pdm.lock",
 		"Pipfile.lock",
 		"package-lock.json",
+		"Package.resolved",
+		"packages.config",
 		"packages.lock.json",
 		"pnpm-lock.yaml",
+		"Podfile.lock",
 		"poetry.lock",
 		"pom.xml",
 		"pubspec.lock",
+		"pyproject.toml",
 		"renv.lock",
 		"requirements.txt",
+		"stack.yaml.lock",
+		"uv.lock",
 		"yarn.lock",
 	}
 	enabledParsers := make(map[string]bool)
 	for _, name := range lockfiles {
 		enabledParsers[name] = true
 	}
-	delete(enabledParsers, "buildscript-gradle.lockfile") // This extractor does not exists, it uses the gradle one
+
+	// project files are named after their project, so their extractors are named after their extension
+	for _, name := range []string{"csproj", "fsproj", "vbproj"} {
+		enabledParsers[name] = true
+	}
 	count := 0
 
 	for _, file := range lockfiles {
@@ -127,9 +202,12 @@ func TestExtractDeps_FindsExpectedExtractor(t *testing.T) {
 		count++
 	}
 
-	// gradle.lockfile and buildscript-gradle.lockfile use the same parser
+	// gradle.lockfile and buildscript-gradle.lockfile are both parsed in parse-gradle-lock.go
 	count -= 1
 
+	// and all of the project files are parsed in parse-dotnet-proj.go
+	count -= 2
+
 	expectNumberOfParsersCalled(t, count)
 }
 
@@ -166,7 +244,7 @@ func TestListExtractors(t *testing.T) {
 
 	extractors := lockfile.ListExtractors()
 
-	firstExpected := "Cargo.lock"
+	firstExpected := ".terraform.lock.hcl"
 	//nolint:ifshort
 	lastExpected := "yarn.lock"
 
@@ -179,6 +257,90 @@ func TestListExtractors(t *testing.T) {
 	}
 }
 
+func TestSupportedFilenames(t *testing.T) {
+	t.Parallel()
+
+	filenames := lockfile.SupportedFilenames()
+
+	// other tests register their own extractors, so only the built-in ones are checked
+	for _, expected := range []string{
+		".terraform.lock.hcl",
+		"buildscript-gradle.lockfile",
+		"bun.lock",
+		"bun.lockb",
+		"cabal.project.freeze",
+		"Cargo.lock",
+		"Cargo.toml",
+		"Chart.lock",
+		"composer.json",
+		"composer.lock",
+		"conan.lock",
+		"csproj",
+		"deno.lock",
+		"flake.lock",
+		"fsproj",
+		"Gemfile.lock",
+		"go.mod",
+		"go.work",
+		"gradle.lockfile",
+		"gradle/verification-metadata.xml",
+		"ivy.xml",
+		"maven_install.json",
+		"mix.lock",
+		"package-lock.json",
+		"Package.resolved",
+		"packages.config",
+		"packages.lock.json",
+		"pdm.lock",
+		"Pipfile.lock",
+		"pnpm-lock.yaml",
+		"Podfile.lock",
+		"poetry.lock",
+		"pom.xml",
+		"pubspec.lock",
+		"pyproject.toml",
+		"renv.lock",
+		"requirements.txt",
+		"stack.yaml.lock",
+		"uv.lock",
+		"vbproj",
+		"yarn.lock",
+	} {
+		if !slices.Contains(filenames, expected) {
+			t.Errorf("Expected %s to be a supported filename, but it was not", expected)
+		}
+	}
+}
+
+func TestSupportedEcosystems(t *testing.T) {
+	t.Parallel()
+
+	expected := []lockfile.Ecosystem{
+		lockfile.CRANEcosystem,
+		lockfile.CocoaPodsEcosystem,
+		lockfile.ConanEcosystem,
+		lockfile.GoEcosystem,
+		lockfile.HackageEcosystem,
+		lockfile.HelmEcosystem,
+		lockfile.MixEcosystem,
+		lockfile.MavenEcosystem,
+		lockfile.NixEcosystem,
+		lockfile.NuGetEcosystem,
+		lockfile.ComposerEcosystem,
+		lockfile.PubEcosystem,
+		lockfile.PipEcosystem,
+		lockfile.BundlerEcosystem,
+		lockfile.SwiftURLEcosystem,
+		lockfile.TerraformEcosystem,
+		lockfile.CargoEcosystem,
+		lockfile.NpmEcosystem,
+	}
+
+	if ecosystems := lockfile.SupportedEcosystems(); !reflect.DeepEqual(ecosystems, expected) {
+		t.Errorf("Expected supported ecosystems to be %v, but got %v", expected, ecosystems)
+	}
+}
+
 func TestDisabledExtractor(t *testing.T) {
 	t.Parallel()
 
@@ -188,3 +350,373 @@ func TestDisabledExtractor(t *testing.T) {
 		t.Errorf("Expected no extractor to be found but one has been found (%s)", extractedAs)
 	}
 }
+
+type customExtractor struct {
+	filename string
+	packages []lockfile.PackageDetails
+}
+
+func (e customExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == e.filename
+}
+
+func (e customExtractor) Extract(_ lockfile.DepFile) ([]lockfile.PackageDetails, error) {
+	return e.packages, nil
+}
+
+var _ lockfile.Extractor = customExtractor{}
+
+func TestRegisterExtractor(t *testing.T) {
+	t.Parallel()
+
+	extractor := customExtractor{
+		filename: "registered.lock",
+		packages: []lockfile.PackageDetails{{Name: "registered", Version: "1.0.0"}},
+	}
+
+	if err := lockfile.RegisterExtractor("registered.lock", extractor); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	found, extractedAs := lockfile.FindExtractor("/path/to/my/registered.lock", "", map[string]bool{"registered.lock": true})
+
+	if found == nil {
+		t.Fatalf("Expected the registered extractor to be found but it was not")
+	}
+
+	if extractedAs != "registered.lock" {
+		t.Errorf("Expected extractedAs to be registered.lock but got %s instead", extractedAs)
+	}
+
+	if !slices.Contains(lockfile.ListExtractors(), "registered.lock") {
+		t.Errorf("Expected registered.lock to be listed as an extractor")
+	}
+}
+
+func TestRegisterExtractor_AlreadyRegistered(t *testing.T) {
+	t.Parallel()
+
+	err := lockfile.RegisterExtractor("go.mod", customExtractor{filename: "go.mod"})
+
+	expectErrIs(t, err, lockfile.ErrExtractorAlreadyRegistered)
+}
+
+func TestOverrideExtractor(t *testing.T) {
+	t.Parallel()
+
+	original := customExtractor{
+		filename: "overridden.lock",
+		packages: []lockfile.PackageDetails{{Name: "original", Version: "1.0.0"}},
+	}
+	replacement := customExtractor{
+		filename: "overridden.lock",
+		packages: []lockfile.PackageDetails{{Name: "replacement", Version: "2.0.0"}},
+	}
+
+	if err := lockfile.RegisterExtractor("overridden.lock", original); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	lockfile.OverrideExtractor("overridden.lock", replacement)
+
+	parsed, err := lockfile.ExtractDeps(
+		openTestDepFile("/path/to/my/overridden.lock"),
+		"",
+		map[string]bool{"overridden.lock": true},
+	)
+
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, parsed.Packages, replacement.packages)
+}
+
+func TestRegisterExtractor_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			name := fmt.Sprintf("concurrent-%d.lock", i)
+			if err := lockfile.RegisterExtractor(name, customExtractor{filename: name}); err != nil {
+				t.Errorf("Got unexpected error: %v", err)
+			}
+
+			lockfile.FindExtractor("/path/to/my/"+name, "", map[string]bool{name: true})
+			lockfile.ListExtractors()
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func TestFindExtractorForPath(t *testing.T) {
+	t.Parallel()
+
+	extractor, ok := lockfile.FindExtractorForPath("/path/to/my/go.mod")
+
+	if !ok {
+		t.Fatalf("Expected an extractor to be found for go.mod but did not")
+	}
+
+	if _, isGo := extractor.(lockfile.GoLockExtractor); !isGo {
+		t.Errorf("Expected the go.mod extractor to be found, but got %T instead", extractor)
+	}
+}
+
+func TestFindExtractorForPath_NotFound(t *testing.T) {
+	t.Parallel()
+
+	extractor, ok := lockfile.FindExtractorForPath("/path/to/my/unknown.file")
+
+	if ok || extractor != nil {
+		t.Errorf("Expected no extractor to be found but got %T", extractor)
+	}
+}
+
+func TestFindExtractorForPath_LastRegisteredWins(t *testing.T) {
+	t.Parallel()
+
+	first := customExtractor{
+		filename: "precedence.lock",
+		packages: []lockfile.PackageDetails{{Name: "first", Version: "1.0.0"}},
+	}
+	second := customExtractor{
+		filename: "precedence.lock",
+		packages: []lockfile.PackageDetails{{Name: "second", Version: "1.0.0"}},
+	}
+
+	if err := lockfile.RegisterExtractor("precedence-first.lock", first); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if err := lockfile.RegisterExtractor("precedence-second.lock", second); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	extractor, ok := lockfile.FindExtractorForPath("/path/to/my/precedence.lock")
+
+	if !ok {
+		t.Fatalf("Expected an extractor to be found for precedence.lock but did not")
+	}
+
+	if got := extractor.(customExtractor).packages[0].Name; got != "second" {
+		t.Errorf("Expected the last registered extractor to win, but got %s", got)
+	}
+
+	_, extractedAs := lockfile.FindExtractor("/path/to/my/precedence.lock", "", map[string]bool{
+		"precedence-first.lock":  true,
+		"precedence-second.lock": true,
+	})
+
+	if extractedAs != "precedence-second.lock" {
+		t.Errorf("Expected extractedAs to be precedence-second.lock but got %s instead", extractedAs)
+	}
+}
+
+func TestFindExtractorForPath_HighestPriorityWins(t *testing.T) {
+	t.Parallel()
+
+	high := customExtractor{
+		filename: "priority.lock",
+		packages: []lockfile.PackageDetails{{Name: "high", Version: "1.0.0"}},
+	}
+	low := customExtractor{
+		filename: "priority.lock",
+		packages: []lockfile.PackageDetails{{Name: "low", Version: "1.0.0"}},
+	}
+
+	if err := lockfile.RegisterExtractorWithPriority("priority-high.lock", high, 10); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	if err := lockfile.RegisterExtractorWithPriority("priority-low.lock", low, 5); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	extractor, ok := lockfile.FindExtractorForPath("/path/to/my/priority.lock")
+
+	if !ok {
+		t.Fatalf("Expected an extractor to be found for priority.lock but did not")
+	}
+
+	if got := extractor.(customExtractor).packages[0].Name; got != "high" {
+		t.Errorf("Expected the extractor with the highest priority to win, but got %s", got)
+	}
+
+	_, extractedAs := lockfile.FindExtractor("/path/to/my/priority.lock", "", map[string]bool{
+		"priority-high.lock": true,
+		"priority-low.lock":  true,
+	})
+
+	if extractedAs != "priority-high.lock" {
+		t.Errorf("Expected extractedAs to be priority-high.lock but got %s instead", extractedAs)
+	}
+
+	// disabled extractors are not considered, regardless of their priority
+	_, extractedAs = lockfile.FindExtractor("/path/to/my/priority.lock", "", map[string]bool{
+		"priority-low.lock": true,
+	})
+
+	if extractedAs != "priority-low.lock" {
+		t.Errorf("Expected extractedAs to be priority-low.lock but got %s instead", extractedAs)
+	}
+}
+
+type customContextExtractor struct {
+	customExtractor
+}
+
+func (e customContextExtractor) ExtractCtx(ctx context.Context, _ lockfile.DepFile) ([]lockfile.PackageDetails, error) {
+	if err := ctx.Err(); err != nil {
+		return []lockfile.PackageDetails{}, fmt.Errorf("custom: %w", err)
+	}
+
+	return e.packages, nil
+}
+
+var _ lockfile.ExtractorWithContext = customContextExtractor{}
+
+func TestExtractWithOptions(t *testing.T) {
+	t.Parallel()
+
+	extractor := customExtractor{
+		filename: "groups.lock",
+		packages: []lockfile.PackageDetails{
+			{Name: "prod", Version: "1.0.0"},
+			{Name: "dev", Version: "1.0.0", DepGroups: []string{"dev"}},
+			{Name: "optional", Version: "1.0.0", DepGroups: []string{"optional"}},
+			{Name: "dev-optional", Version: "1.0.0", DepGroups: []string{"dev", "optional"}},
+		},
+	}
+
+	packages, err := lockfile.ExtractWithOptions(
+		extractor,
+		openTestDepFile("/path/to/my/groups.lock"),
+		lockfile.ExtractOptions{ExcludeDepGroups: []string{"dev"}},
+	)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{Name: "prod", Version: "1.0.0"},
+		{Name: "optional", Version: "1.0.0", DepGroups: []string{"optional"}},
+	})
+}
+
+func TestExtractWithReport_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	extractor := customExtractor{
+		filename: "groups.lock",
+		packages: []lockfile.PackageDetails{
+			{Name: "prod", Version: "1.0.0"},
+			{Name: "dev", Version: "1.0.0", DepGroups: []string{"dev"}},
+		},
+	}
+
+	packages, skipped, err := lockfile.ExtractWithReport(
+		extractor,
+		openTestDepFile("/path/to/my/groups.lock"),
+		lockfile.ExtractOptions{ExcludeDepGroups: []string{"dev"}},
+	)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{Name: "prod", Version: "1.0.0"},
+	})
+
+	if skipped != nil {
+		t.Errorf("Expected no skipped packages to be reported, but got %v", skipped)
+	}
+}
+
+func TestExtractDepsCtx(t *testing.T) {
+	t.Parallel()
+
+	extractor := customContextExtractor{customExtractor{
+		filename: "context.lock",
+		packages: []lockfile.PackageDetails{{Name: "context", Version: "1.0.0"}},
+	}}
+
+	if err := lockfile.RegisterExtractor("context.lock", extractor); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	parsed, err := lockfile.ExtractDepsCtx(
+		context.Background(),
+		openTestDepFile("/path/to/my/context.lock"),
+		"",
+		map[string]bool{"context.lock": true},
+	)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, parsed.Packages, extractor.packages)
+}
+
+func TestExtractDepsCtx_ReaderDepFile(t *testing.T) {
+	t.Parallel()
+
+	content := `<project>
+  <dependencies>
+    <dependency>
+      <groupId>org.apache.maven</groupId>
+      <artifactId>maven-artifact</artifactId>
+      <version>1.0.0</version>
+    </dependency>
+  </dependencies>
+</project>`
+
+	parsed, err := lockfile.ExtractDepsCtx(
+		context.Background(),
+		lockfile.NewReaderDepFile("/path/to/my/pom.xml", strings.NewReader(content)),
+		"",
+		map[string]bool{"pom.xml": true},
+	)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	if parsed.Artifact != nil {
+		t.Errorf("Expected no artifact for a file that is not local, but got %v", parsed.Artifact)
+	}
+
+	expectPackagesWithoutLocations(t, parsed.Packages, []lockfile.PackageDetails{
+		{
+			Name:           "org.apache.maven:maven-artifact",
+			Version:        "1.0.0",
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			PackageManager: models.Maven,
+			IsDirect:       true,
+		},
+	})
+}
+
+func TestExtractDepsCtx_Cancelled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := lockfile.ExtractDepsCtx(
+		ctx,
+		openTestDepFile("/path/to/my/go.mod"),
+		"",
+		map[string]bool{"go.mod": true},
+	)
+
+	expectErrIs(t, err, context.Canceled)
+}
@@ -1,11 +1,15 @@
 package lockfile
 
 import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/google/osv-scanner/pkg/models"
 
@@ -37,12 +41,169 @@ type NestedDepFile interface {
 	DepFile
 }
 
+// hasSiblingLockfile checks if there is any of the given lockfiles next to the file,
+// in which case extractors of manifests extract nothing from it, as the lockfile has
+// the exact versions that are installed and is extracted instead
+func hasSiblingLockfile(f DepFile, names ...string) bool {
+	for _, name := range names {
+		if lockfile, err := f.Open(name); err == nil {
+			lockfile.Close()
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// DepFileWithContentHash is a DepFile that also knows where it is relative to the root
+// of the scan that it is part of and the hash of its contents, so that what is extracted
+// from it can be tied to the exact version of the file that it was extracted from.
+//
+// This is optional, so extractors must check if the DepFile they are given implements it.
+type DepFileWithContentHash interface {
+	DepFile
+
+	// RelPath returns the path of the file relative to the root of the scan,
+	// which always uses forward slashes
+	RelPath() string
+
+	// ContentHash returns the hash of the contents of the file as git would
+	// calculate it for a blob, so that it matches the hash of the file in a repository
+	ContentHash() (string, error)
+}
+
 type Extractor interface {
 	// ShouldExtract checks if the Extractor should be used for the given path.
 	ShouldExtract(path string) bool
 	Extract(f DepFile) ([]PackageDetails, error)
 }
 
+// ExtractorWithContext is an Extractor that can stop extracting early
+// when the given context is cancelled or its deadline is exceeded.
+type ExtractorWithContext interface {
+	Extractor
+	ExtractCtx(ctx context.Context, f DepFile) ([]PackageDetails, error)
+}
+
+// ExtractorWithDepGroups is an Extractor for a format that can distinguish
+// between groups of dependencies, such as those only needed for development.
+type ExtractorWithDepGroups interface {
+	Extractor
+	// SupportedDepGroups returns the groups that can be set in the DepGroups
+	// of the packages that are extracted.
+	SupportedDepGroups() []string
+}
+
+// ExtractOptions configures which of the packages in a file are extracted.
+type ExtractOptions struct {
+	// ExcludeDepGroups are the groups of packages to omit, with a package
+	// being omitted if it is in any of them.
+	ExcludeDepGroups []string
+
+	// NoDedup is whether packages that are the same as another package in the file
+	// should be kept, such as when several modules are replaced with the same one
+	// in a go.mod, which can be useful for debugging how the file is being resolved.
+	// This is only supported by extractors which deduplicate packages themselves.
+	NoDedup bool
+
+	// Verbose is whether packages that would normally be skipped, such as those
+	// without a version, should be extracted too, so that it can be seen which
+	// packages are being skipped and where they are in the file.
+	// This is only supported by some extractors.
+	Verbose bool
+
+	// ExcludeStdlib is whether the "stdlib" package that represents the Go standard
+	// library, which is based on the go directive of go.mod and go.work files, should
+	// be omitted, such as when only third-party packages are being audited.
+	ExcludeStdlib bool
+
+	// OnUnresolvedVersion is what to do with packages whose version cannot be
+	// resolved, such as Go modules that are required at a branch like "master".
+	// This is only supported by the go.mod and go.work extractors.
+	OnUnresolvedVersion UnresolvedVersionBehavior
+
+	// ValidateLocations is whether the name and version locations of the extracted
+	// packages should be checked against the text that they point to in their files,
+	// with a warning being logged for each that does not point to the name or version,
+	// which is useful for catching mistakes in how the locations are worked out.
+	// This requires reading the files again, so it should only be used for debugging.
+	ValidateLocations bool
+
+	// CollapseIncompatibleVersions is whether the "+incompatible" suffix that Go modules
+	// without semantic import versioning have on major versions after v1 should be
+	// dropped from the version that they are compared against advisories with, while
+	// keeping it in the version that is shown, as it is not part of the version that
+	// advisories use. This is only supported by the go.mod and go.work extractors.
+	CollapseIncompatibleVersions bool
+
+	// IncludeWorkspacePackages is whether the packages of a workspace that other
+	// packages of it depend on with the "workspace:" protocol should be extracted
+	// in the "workspace" group, rather than being omitted as they are local packages
+	// that are not published to a registry. This is only supported by the
+	// pnpm-lock.yaml extractor.
+	IncludeWorkspacePackages bool
+}
+
+// UnresolvedVersionBehavior is what extractors do with packages whose version
+// is not one that can be resolved, such as a branch name in a go.mod
+type UnresolvedVersionBehavior int
+
+const (
+	// UnresolvedVersionDefault keeps the package without a version, with a warning
+	// being logged that it is defaulting to an unresolved version
+	UnresolvedVersionDefault UnresolvedVersionBehavior = iota
+	// UnresolvedVersionSkip omits the package, with a warning being logged
+	// so that it can be seen which packages are being skipped
+	UnresolvedVersionSkip
+	// UnresolvedVersionKeepRaw keeps the package with its version as it is
+	// written in the file, even though it will not match any advisories
+	UnresolvedVersionKeepRaw
+)
+
+func (opts ExtractOptions) excludesGroup(group string) bool {
+	return slices.Contains(opts.ExcludeDepGroups, group)
+}
+
+func (opts ExtractOptions) excludes(pkg PackageDetails) bool {
+	return slices.ContainsFunc(pkg.DepGroups, opts.excludesGroup)
+}
+
+// ExtractorWithOptions is an Extractor that applies ExtractOptions while extracting,
+// so that it can avoid doing the work of extracting packages that would be omitted.
+type ExtractorWithOptions interface {
+	Extractor
+	ExtractWithOptions(f DepFile, opts ExtractOptions) ([]PackageDetails, error)
+}
+
+// SkipReason is why a package that is declared in a file will not be scanned
+type SkipReason string
+
+const (
+	// SkipReasonNoVersion is for packages which do not have a version or anything
+	// else that they could be matched against advisories by, such as a commit
+	SkipReasonNoVersion SkipReason = "no version"
+	// SkipReasonLocalReplacement is for packages which have been replaced with a
+	// package on the local filesystem, such as Go modules replaced with a directory
+	SkipReasonLocalReplacement SkipReason = "replaced with a local path"
+)
+
+// SkippedPackage is a package that is declared in a file but which will not be scanned,
+// either because it was not extracted or because it was extracted without anything that
+// it can be matched against advisories by, along with why and where it was declared
+type SkippedPackage struct {
+	Name     string               `json:"name"`
+	Reason   SkipReason           `json:"reason"`
+	Location *models.FilePosition `json:"location,omitempty"`
+}
+
+// ExtractorWithReport is an ExtractorWithOptions that can also report the packages
+// that it skipped, so that it can be seen which packages in a file were not scanned.
+type ExtractorWithReport interface {
+	ExtractorWithOptions
+	ExtractWithReport(f DepFile, opts ExtractOptions) ([]PackageDetails, []SkippedPackage, error)
+}
+
 type WithMatcher struct {
 	Matcher Matcher
 }
@@ -66,19 +227,68 @@ type LocalFile struct {
 	io.Closer
 
 	path string
+	root string
 }
 
 func (f LocalFile) Open(path string) (NestedDepFile, error) {
-	if filepath.IsAbs(path) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(f.path), path)
+	}
+
+	if f.root == "" {
 		return OpenLocalDepFile(path)
 	}
 
-	return OpenLocalDepFile(filepath.Join(filepath.Dir(f.path), path))
+	return OpenLocalDepFileInRoot(f.root, path)
 }
 
 func (f LocalFile) Path() string { return f.path }
 
+// RelPath returns the path of the file relative to the root that it was opened in,
+// or just the name of the file if it was not opened in a root.
+func (f LocalFile) RelPath() string {
+	if f.root == "" {
+		return filepath.Base(f.path)
+	}
+
+	rel, err := filepath.Rel(f.root, f.path)
+	if err != nil {
+		return filepath.ToSlash(f.path)
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// ContentHash returns the git blob hash of the file, which is calculated by reading
+// the file again only when it is called, as most callers do not need it.
+func (f LocalFile) ContentHash() (string, error) {
+	content, err := os.ReadFile(f.path)
+	if err != nil {
+		return "", err
+	}
+
+	return gitBlobHash(content), nil
+}
+
+// gitBlobHash returns the hash that git gives to a blob with the given content,
+// which is the same as `git hash-object` would output for a file with it
+func gitBlobHash(content []byte) string {
+	hash := sha1.New() //nolint:gosec // this is the hash that git uses for blobs
+
+	fmt.Fprintf(hash, "blob %d\x00", len(content))
+	hash.Write(content)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 func OpenLocalDepFile(path string) (NestedDepFile, error) {
+	return OpenLocalDepFileInRoot("", path)
+}
+
+// OpenLocalDepFileInRoot opens the file at the given path for extracting, with the file
+// being within the given root such as the directory that is being scanned, which is used
+// as the root of any files that are opened relative to it too.
+func OpenLocalDepFileInRoot(root string, path string) (NestedDepFile, error) {
 	r, err := os.Open(path)
 
 	if err != nil {
@@ -88,17 +298,158 @@ func OpenLocalDepFile(path string) (NestedDepFile, error) {
 	// Very unlikely to have Abs return an error if the file opens correctly
 	path, _ = filepath.Abs(path)
 
-	// We apply a decoder on it to avoid issues with utf-16
+	if root != "" {
+		root, _ = filepath.Abs(root)
+	}
+
+	return LocalFile{newBOMDecodingReader(r), r, path, root}, nil
+}
+
+// newBOMDecodingReader returns a reader that decodes the given reader based on
+// its byte order mark, to avoid issues with files that are encoded as utf-16
+func newBOMDecodingReader(r io.Reader) io.Reader {
 	var transformer = unicode.BOMOverride(encoding.Nop.NewDecoder())
-	decodedReader := transform.NewReader(r, transformer)
 
-	return LocalFile{decodedReader, r, path}, nil
+	return transform.NewReader(r, transformer)
 }
 
 var _ DepFile = LocalFile{}
 var _ NestedDepFile = LocalFile{}
+var _ DepFileWithContentHash = LocalFile{}
+
+// contextDepFile is a DepFile which fails to be read from once its context is done,
+// so that extractors which do not support a context still stop reasonably early
+type contextDepFile struct {
+	DepFile
+
+	ctx context.Context
+}
+
+func (f contextDepFile) Read(p []byte) (int, error) {
+	if err := f.ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	return f.DepFile.Read(p)
+}
+
+func (f contextDepFile) Open(path string) (NestedDepFile, error) {
+	if err := f.ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	nested, err := f.DepFile.Open(path)
+	if err != nil {
+		return nested, err
+	}
+
+	return contextNestedDepFile{contextDepFile{nested, f.ctx}, nested}, nil
+}
+
+type contextNestedDepFile struct {
+	contextDepFile
+	io.Closer
+}
+
+// contextDepFileWithContentHash is a contextDepFile for a DepFileWithContentHash,
+// so that extractors can still tell that the file they are given has a hash
+type contextDepFileWithContentHash struct {
+	contextDepFile
+
+	hashed DepFileWithContentHash
+}
+
+func (f contextDepFileWithContentHash) RelPath() string { return f.hashed.RelPath() }
+
+func (f contextDepFileWithContentHash) ContentHash() (string, error) {
+	return f.hashed.ContentHash()
+}
+
+var _ DepFile = contextDepFile{}
+var _ NestedDepFile = contextNestedDepFile{}
+var _ DepFileWithContentHash = contextDepFileWithContentHash{}
+
+// extractWithContext extracts the given file using the given extractor,
+// making use of the context if the extractor supports one
+func extractWithContext(ctx context.Context, extractor Extractor, f DepFile) ([]PackageDetails, error) {
+	if err := ctx.Err(); err != nil {
+		return []PackageDetails{}, err
+	}
+
+	if hashed, ok := f.(DepFileWithContentHash); ok {
+		f = contextDepFileWithContentHash{contextDepFile{f, ctx}, hashed}
+	} else {
+		f = contextDepFile{f, ctx}
+	}
+
+	if e, ok := extractor.(ExtractorWithContext); ok {
+		return e.ExtractCtx(ctx, f)
+	}
+
+	return extractor.Extract(f)
+}
+
+// ExtractWithOptions extracts the packages in the given file using the extractor,
+// omitting those that are excluded by the options.
+func ExtractWithOptions(extractor Extractor, f DepFile, opts ExtractOptions) ([]PackageDetails, error) {
+	packages, err := extractWithOptions(extractor, f, opts)
+
+	if opts.ValidateLocations {
+		validateLocations(packages)
+	}
+
+	return packages, err
+}
+
+// ExtractWithReport extracts the packages in the given file in the same way as
+// ExtractWithOptions, also returning the packages that were skipped if the extractor
+// is able to report them, which otherwise are not known and so none are returned.
+func ExtractWithReport(extractor Extractor, f DepFile, opts ExtractOptions) ([]PackageDetails, []SkippedPackage, error) {
+	e, ok := extractor.(ExtractorWithReport)
+	if !ok {
+		packages, err := ExtractWithOptions(extractor, f, opts)
+
+		return packages, nil, err
+	}
+
+	packages, skipped, err := e.ExtractWithReport(f, opts)
+
+	if opts.ValidateLocations {
+		validateLocations(packages)
+	}
+
+	return packages, skipped, err
+}
+
+func extractWithOptions(extractor Extractor, f DepFile, opts ExtractOptions) ([]PackageDetails, error) {
+	if e, ok := extractor.(ExtractorWithOptions); ok {
+		return e.ExtractWithOptions(f, opts)
+	}
+
+	packages, err := extractor.Extract(f)
+	if err != nil && !OnlyEntryErrors(err) {
+		return packages, err
+	}
+
+	filtered := make([]PackageDetails, 0, len(packages))
+	for _, pkg := range packages {
+		if !opts.excludes(pkg) {
+			filtered = append(filtered, pkg)
+		}
+	}
+
+	return filtered, err
+}
 
 func extractFromFile(pathToLockfile string, extractor Extractor) ([]PackageDetails, error) {
+	return extractFromFileCtx(context.Background(), pathToLockfile, extractor)
+}
+
+func extractFromFileCtx(ctx context.Context, pathToLockfile string, extractor Extractor) ([]PackageDetails, error) {
+	if pathToLockfile == StdinPath {
+		return extractFromDepFile(ctx, NewReaderDepFile(StdinPath, os.Stdin), extractor)
+	}
+
 	f, err := OpenLocalDepFile(pathToLockfile)
 
 	if err != nil {
@@ -107,8 +458,14 @@ func extractFromFile(pathToLockfile string, extractor Extractor) ([]PackageDetai
 
 	defer f.Close()
 
-	packages, err := extractor.Extract(f)
-	if err != nil {
+	return extractFromDepFile(ctx, f, extractor)
+}
+
+// extractFromDepFile extracts the packages from the given file using the extractor,
+// matching them with the file's source file if the extractor has a matcher
+func extractFromDepFile(ctx context.Context, f DepFile, extractor Extractor) ([]PackageDetails, error) {
+	packages, err := extractWithContext(ctx, extractor, f)
+	if err != nil && !OnlyEntryErrors(err) {
 		return []PackageDetails{}, err
 	}
 
@@ -117,10 +474,10 @@ func extractFromFile(pathToLockfile string, extractor Extractor) ([]PackageDetai
 		if matcher := e.GetMatcher(); matcher != nil {
 			matchError := matchWithFile(f, packages, matcher)
 			if matchError != nil {
-				_, _ = fmt.Fprintf(os.Stderr, "there was an error matching the source file: %s\n", matchError.Error())
+				logWarningf("there was an error matching the source file: %s\n", matchError.Error())
 			}
 		}
 	}
 
-	return packages, nil
+	return packages, err
 }
@@ -0,0 +1,70 @@
+package lockfile_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestLocalFile_RelPath(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFileInRoot("fixtures", "fixtures/go/vendored/go.mod")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	hashed, ok := f.(lockfile.DepFileWithContentHash)
+	if !ok {
+		t.Fatalf("Expected file to have a content hash, but it does not")
+	}
+
+	if got := hashed.RelPath(); got != "go/vendored/go.mod" {
+		t.Errorf("Expected relative path to be \"go/vendored/go.mod\", but got \"%s\"", got)
+	}
+
+	nested, err := f.Open("vendor/modules.txt")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer nested.Close()
+
+	if got := nested.(lockfile.DepFileWithContentHash).RelPath(); got != "go/vendored/vendor/modules.txt" {
+		t.Errorf("Expected relative path to be \"go/vendored/vendor/modules.txt\", but got \"%s\"", got)
+	}
+}
+
+func TestLocalFile_RelPath_WithoutRoot(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/go/one-package.mod")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	if got := f.(lockfile.DepFileWithContentHash).RelPath(); got != "one-package.mod" {
+		t.Errorf("Expected relative path to be \"one-package.mod\", but got \"%s\"", got)
+	}
+}
+
+func TestLocalFile_ContentHash(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/go/one-package.mod")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	hash, err := f.(lockfile.DepFileWithContentHash).ContentHash()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	// this is what `git hash-object fixtures/go/one-package.mod` outputs
+	if expected := "6413971b13278fe7c8979e6479ce6e56b134c6e0"; hash != expected {
+		t.Errorf("Expected content hash to be \"%s\", but got \"%s\"", expected, hash)
+	}
+}
@@ -0,0 +1,3 @@
+module my-library
+
+require github.com/BurntSushi/toml v1.0.0
@@ -0,0 +1,3 @@
+module my-third-party-library
+
+require golang.org/x/text v0.3.7
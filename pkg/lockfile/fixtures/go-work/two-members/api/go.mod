@@ -0,0 +1,10 @@
+module example.com/api
+
+go 1.21
+
+require (
+	github.com/BurntSushi/toml v1.0.0
+	golang.org/x/text v0.3.7
+)
+
+replace github.com/BurntSushi/toml v1.0.0 => github.com/BurntSushi/toml v1.2.0
@@ -0,0 +1,11 @@
+module example.com/server
+
+go 1.21
+
+require (
+	example.com/api v0.0.0
+	golang.org/x/text v0.3.7
+	gopkg.in/yaml.v2 v2.4.0
+)
+
+replace example.com/api => ../api
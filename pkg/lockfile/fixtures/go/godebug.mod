@@ -0,0 +1,12 @@
+module my-library
+
+go 1.23
+
+godebug default=go1.21
+
+godebug (
+	panicnil=1
+	asynctimerchan=0
+)
+
+require github.com/BurntSushi/toml v1.0.0
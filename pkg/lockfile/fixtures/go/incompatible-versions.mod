@@ -0,0 +1,6 @@
+module my-library
+
+require (
+	github.com/BurntSushi/toml v1.0.0
+	github.com/docker/docker v20.10.24+incompatible
+)
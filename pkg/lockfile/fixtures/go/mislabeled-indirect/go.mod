@@ -0,0 +1,16 @@
+module example.com/app
+
+go 1.21
+
+require (
+	github.com/BurntSushi/toml v1.3.2
+	github.com/google/uuid v1.3.1 // indirect
+	github.com/spf13/cobra v1.7.0
+	golang.org/x/sync v0.3.0
+)
+
+require (
+	github.com/inconshreveable/mousetrap v1.1.0 // indirect
+	github.com/spf13/pflag v1.0.5 // indirect
+	golang.org/x/text v0.13.0
+)
@@ -0,0 +1,6 @@
+module my-library
+
+// ünïcödé çömmént
+require github.com/BurntSushi/toml v1.0.0 // ünïcödé
+
+replace github.com/BurntSushi/toml => github.com/BurntSushi/tömł v1.1.0
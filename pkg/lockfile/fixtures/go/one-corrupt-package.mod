@@ -0,0 +1,9 @@
+module my-library
+
+require (
+	github.com/BurntSushi/toml v1.0.0
+	github.com/corrupt/package
+	golang.org/x/net v0.0.0-20220722155237-a158d28d115b
+)
+
+require golang.org/x/text v0.3.7 // indirect
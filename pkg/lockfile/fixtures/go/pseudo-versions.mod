@@ -0,0 +1,13 @@
+module my-library
+
+go 1.21
+
+require (
+	github.com/BurntSushi/toml v1.0.0
+	golang.org/x/sys v0.0.0-20230101000000-abcdef123456
+	golang.org/x/net v0.17.1-0.20231005145523-a8cbbbd7098b
+	golang.org/x/text v0.14.0-pre.0.20231030141821-2b5bb2c1b83c
+	golang.org/x/sync v0.5.0-rc.1.0.20231101094126-10a6c9e2b4ca
+)
+
+replace github.com/BurntSushi/toml => github.com/BurntSushi/toml v1.3.3-0.20230920152743-5d6cde3a35c2
@@ -0,0 +1,10 @@
+require (
+    golang.org/x/net v1.2.3
+    golang.org/x/text v0.3.7
+    github.com/BurntSushi/toml v1.0.0
+)
+
+replace (
+    golang.org/x/net v1.2.3 => ./fork/net
+    golang.org/x/text v0.3.7 => example.com/fork/text master
+)
@@ -0,0 +1,11 @@
+require (
+    golang.org/x/net v1.2.3
+    golang.org/x/net v0.5.6
+    golang.org/x/text v0.3.7
+)
+
+replace (
+    golang.org/x/text => golang.org/x/net v0.9.0
+    golang.org/x/net v1.2.3 => ../net
+    golang.org/x/net => example.com/fork/net v1.4.5
+)
@@ -0,0 +1,10 @@
+module my-library
+
+go 1.17
+
+require github.com/BurntSushi/toml v1.0.0
+
+retract (
+	v1.0.1 // published accidentally
+	[v1.1.0, v1.2.0] // contains a bug
+)
@@ -0,0 +1,27 @@
+module example.com/my-library
+
+go 1.22.5
+
+toolchain go1.23.2
+
+godebug panicnil=1
+
+require (
+	github.com/BurntSushi/toml v1.0.0
+	golang.org/x/net v0.25.0
+	golang.org/x/tools v0.21.0
+)
+
+exclude golang.org/x/net v0.26.0
+
+replace (
+	golang.org/x/net => example.com/fork/net v0.25.1
+	gopkg.in/yaml.v3 => ../yaml
+)
+
+retract (
+	v1.0.1 // published accidentally
+	[v1.1.0, v1.2.0]
+)
+
+tool golang.org/x/tools/cmd/stringer
@@ -0,0 +1,16 @@
+module example.com/my-library
+
+go 1.24
+
+require (
+	golang.org/x/net v0.25.0
+	golang.org/x/tools v0.21.0
+	honnef.co/go/tools v0.4.7 // indirect
+)
+
+tool (
+	golang.org/x/tools/cmd/stringer // used by go generate
+	example.com/my-library/cmd/codegen
+)
+
+tool honnef.co/go/tools/cmd/staticcheck
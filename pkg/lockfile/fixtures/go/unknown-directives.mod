@@ -0,0 +1,13 @@
+module my-library
+
+go 1.99
+
+futuredirective something
+
+futureblock (
+	github.com/BurntSushi/toml v9.9.9
+)
+
+require (
+	github.com/BurntSushi/toml v1.0.0
+)
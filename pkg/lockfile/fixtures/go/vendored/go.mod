@@ -0,0 +1,13 @@
+module my-library
+
+go 1.21
+
+require (
+	github.com/BurntSushi/toml v1.0.0
+	golang.org/x/text v0.3.7
+	gopkg.in/yaml.v2 v2.4.0
+)
+
+require github.com/kr/pretty v0.3.1 // indirect
+
+replace golang.org/x/text => golang.org/x/text v0.3.8
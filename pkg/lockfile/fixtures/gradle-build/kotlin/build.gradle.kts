@@ -0,0 +1,12 @@
+plugins {
+    `java-library`
+}
+
+val kotestVersion = "5.6.2"
+
+dependencies {
+    api("org.apache.commons:commons-lang3:3.12.0")
+    implementation("org.springframework.security:spring-security-crypto:5.7.3")
+    testImplementation("io.kotest:kotest-runner-junit5:$kotestVersion")
+    testImplementation(platform("org.junit:junit-bom:5.9.1"))
+}
@@ -0,0 +1,21 @@
+# This file is maintained automatically by "terraform init".
+# Manual edits may be lost in future updates.
+
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.0.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:6DM4iUK1Zq/7BgLz9bOI+3aDi4L9ZuB1uOdh6mr5JxI=",
+    "zh:0a21bbc0ad8d14ff4b93b95b1f5c7bc1a5d4e6e8a3f28e0c2b0ab2be1e9d5b8c",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.5.1"
+  hashes  = ["h1:VSnd9ZIPyfKHOObuQCaKfnjIHRtR7qTw19Rz8tJxm+k="]
+}
+
+provider "registry.opentofu.org/integrations/github" {
+  version     = "6.2.1"
+  constraints = ">= 6.0.0"
+}
@@ -0,0 +1,159 @@
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// goSumModules are the modules listed in a go.sum, keyed by their path and version
+type goSumModules struct {
+	// listed are the modules that the go.sum has any checksum for
+	listed map[string]struct{}
+	// built are the modules that the go.sum has the checksum of the contents for,
+	// which is only needed if the module provides packages to the build
+	built map[string]struct{}
+}
+
+// parseGoSum parses the lines of a go.sum, which look like either
+// "golang.org/x/text v0.3.7 h1:..." or "golang.org/x/text v0.3.7/go.mod h1:..."
+func parseGoSum(f DepFile) (goSumModules, error) {
+	modules := goSumModules{
+		listed: map[string]struct{}{},
+		built:  map[string]struct{}{},
+	}
+
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) != 3 {
+			continue
+		}
+
+		version, onlyGoMod := strings.CutSuffix(fields[1], "/go.mod")
+		key := fields[0] + "@" + version
+
+		modules.listed[key] = struct{}{}
+
+		if !onlyGoMod {
+			modules.built[key] = struct{}{}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return goSumModules{}, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
+	}
+
+	return modules, nil
+}
+
+// goRequireBlocks returns the require block that each require in the go.mod is in,
+// along with how many require blocks there are, with requires that are not in a
+// block not having one
+func goRequireBlocks(parsedLockfile *modfile.File) (map[*modfile.Require][]*modfile.Require, int) {
+	requires := map[*modfile.Line]*modfile.Require{}
+
+	for _, require := range parsedLockfile.Require {
+		requires[require.Syntax] = require
+	}
+
+	blockOf := map[*modfile.Require][]*modfile.Require{}
+	count := 0
+
+	for _, stmt := range parsedLockfile.Syntax.Stmt {
+		block, ok := stmt.(*modfile.LineBlock)
+		if !ok || len(block.Token) == 0 || block.Token[0] != "require" {
+			continue
+		}
+
+		var blockRequires []*modfile.Require
+
+		for _, line := range block.Line {
+			if require, ok := requires[line]; ok {
+				blockRequires = append(blockRequires, require)
+			}
+		}
+
+		for _, require := range blockRequires {
+			blockOf[require] = blockRequires
+		}
+
+		count++
+	}
+
+	return blockOf, count
+}
+
+// isGoRequireBlockMostly checks if more than half of the requires in the block
+// are the given kind, being either marked as indirect or not
+func isGoRequireBlockMostly(block []*modfile.Require, indirect bool) bool {
+	count := 0
+
+	for _, require := range block {
+		if require.Indirect == indirect {
+			count++
+		}
+	}
+
+	return count*2 > len(block)
+}
+
+// reconcileGoIndirectComments checks that the "// indirect" comments of the requires in
+// the go.mod agree with what its layout and the go.sum say about them, as the comments
+// are maintained by tooling and so can be stale, correcting whether the packages are
+// direct and warning about each require that looks to be mislabeled.
+//
+// Since Go 1.17, direct and indirect requires are kept in separate blocks, so a require
+// is taken to be the same as most of the others in its block when there are several.
+// A module that only has the checksum of its go.mod in the go.sum does not provide any
+// packages to the build, and so cannot be imported by the main module.
+func reconcileGoIndirectComments(packages map[string]PackageDetails, parsedLockfile *modfile.File, goSum goSumModules, path string) {
+	blockOf, blockCount := goRequireBlocks(parsedLockfile)
+
+	for _, require := range parsedLockfile.Require {
+		key := require.Mod.Path + "@" + require.Mod.Version
+		pkg, ok := packages[key]
+
+		if !ok {
+			continue
+		}
+
+		isDirect := !require.Indirect
+		reason := ""
+
+		if block, ok := blockOf[require]; ok && blockCount > 1 {
+			if isDirect && isGoRequireBlockMostly(block, true) {
+				isDirect = false
+				reason = "it is required alongside indirect dependencies"
+			} else if !isDirect && isGoRequireBlockMostly(block, false) {
+				isDirect = true
+				reason = "it is required alongside direct dependencies"
+			}
+		}
+
+		if _, listed := goSum.listed[key]; listed && isDirect {
+			if _, built := goSum.built[key]; !built {
+				isDirect = false
+				reason = "none of its packages are in the build according to the go.sum"
+			}
+		}
+
+		if isDirect == pkg.IsDirect {
+			continue
+		}
+
+		if isDirect {
+			logWarningf("%s is marked as indirect in %s but %s, so it is treated as direct\n", key, path, reason)
+		} else {
+			logWarningf("%s is not marked as indirect in %s but %s, so it is treated as indirect\n", key, path, reason)
+		}
+
+		pkg.IsDirect = isDirect
+		pkg.Transitive = !isDirect
+		packages[key] = pkg
+	}
+}
@@ -0,0 +1,142 @@
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// goVendorModule is a module listed in a vendor/modules.txt, which is
+// only compiled in if any of its packages are listed after it
+type goVendorModule struct {
+	name     string
+	version  string
+	used     bool
+	location models.FilePosition
+}
+
+// parseGoVendorModuleLine parses a line like "# golang.org/x/text v0.3.7", which
+// can also have the replacement of the module like "# golang.org/x/text v0.3.7 => ../text"
+func parseGoVendorModuleLine(line string) (string, string) {
+	original, replacement, replaced := strings.Cut(strings.TrimPrefix(line, "# "), " => ")
+
+	fields := strings.Fields(original)
+	name, version := fields[0], ""
+
+	if len(fields) > 1 {
+		version = fields[1]
+	}
+
+	if replaced {
+		fields = strings.Fields(replacement)
+
+		// The replacement is a local file path, we keep the original package name
+		// to match how replacements in the go.mod are handled
+		if len(fields) == 0 || !hasHostnamePrefix(fields[0]) {
+			return name, ""
+		}
+
+		name, version = fields[0], ""
+
+		if len(fields) > 1 {
+			version = fields[1]
+		}
+	}
+
+	return name, strings.TrimPrefix(version, "v")
+}
+
+// applyGoVendorModules returns the packages of the modules that have been
+// vendored according to the given vendor/modules.txt, which is the exact set
+// of modules that are compiled in, using the details of the given packages
+// from the go.mod for the modules that are also required there
+func applyGoVendorModules(packages map[string]PackageDetails, f DepFile) (map[string]PackageDetails, error) {
+	var modules []*goVendorModule
+
+	scanner := bufio.NewScanner(f)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNumber++
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			continue
+		case strings.HasPrefix(line, "## "):
+			// the annotations of a module, like "## explicit; go 1.17", which says that it is
+			// required by the go.mod but not whether it is a direct dependency or not
+			continue
+		case strings.HasPrefix(line, "# "):
+			name, version := parseGoVendorModuleLine(line)
+
+			modules = append(modules, &goVendorModule{
+				name:    name,
+				version: version,
+				location: models.FilePosition{
+					Line: models.Position{Start: lineNumber, End: lineNumber},
+					Column: models.Position{
+						Start: 1,
+						End:   fileposition.ColumnOfByteIndex(line, len(line)),
+					},
+					Filename: f.Path(),
+				},
+			})
+		default:
+			if len(modules) > 0 {
+				modules[len(modules)-1].used = true
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
+	}
+
+	// the packages are keyed by their Key, which can include their commit,
+	// so they are looked up by the name and version that modules.txt has
+	required := map[string]PackageDetails{}
+
+	for _, pkg := range packages {
+		required[pkg.Name+"@"+pkg.Version] = pkg
+	}
+
+	vendored := map[string]PackageDetails{}
+
+	for _, module := range modules {
+		if !module.used {
+			continue
+		}
+
+		key := module.name + "@" + module.version
+
+		if pkg, ok := required[key]; ok {
+			vendored[key] = pkg
+
+			continue
+		}
+
+		// modules that are not in the go.mod can only be required by other modules
+		vendored[key] = PackageDetails{
+			Name:           module.name,
+			Version:        module.version,
+			Commit:         extractPseudoVersionCommit("v" + module.version),
+			PackageManager: models.Golang,
+			Ecosystem:      GoEcosystem,
+			CompareAs:      GoEcosystem,
+			BlockLocation:  module.location,
+			Transitive:     true,
+			Origin:         "vendor",
+		}
+	}
+
+	// the standard library is never vendored
+	if pkg, ok := packages["stdlib"]; ok {
+		vendored["stdlib"] = pkg
+	}
+
+	return vendored, nil
+}
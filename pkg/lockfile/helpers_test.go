@@ -37,6 +37,50 @@ func expectErrIs(t *testing.T, err error, expected error) {
 	}
 }
 
+func expectParseErrorAt(t *testing.T, err error, line int, column int) {
+	t.Helper()
+
+	var parseErr *lockfile.ParseError
+
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected to get a ParseError, but got \"%v\"", err)
+	}
+
+	if parseErr.Line != line || parseErr.Column != column {
+		t.Errorf(
+			"Expected error to be at line %d, column %d, but was at line %d, column %d",
+			line,
+			column,
+			parseErr.Line,
+			parseErr.Column,
+		)
+	}
+}
+
+func expectEntryErrorFor(t *testing.T, err error, entry string, line int) {
+	t.Helper()
+
+	if !lockfile.OnlyEntryErrors(err) {
+		t.Errorf("Expected to only get EntryErrors, but got \"%v\"", err)
+	}
+
+	var entryErr *lockfile.EntryError
+
+	if !errors.As(err, &entryErr) {
+		t.Fatalf("Expected to get an EntryError, but got \"%v\"", err)
+	}
+
+	if entryErr.Entry != entry || entryErr.Line != line {
+		t.Errorf(
+			"Expected error to be for %s on line %d, but was for %s on line %d",
+			entry,
+			line,
+			entryErr.Entry,
+			entryErr.Line,
+		)
+	}
+}
+
 func packageToString(pkg lockfile.PackageDetails) string {
 	commit := pkg.Commit
 
@@ -141,3 +185,11 @@ func expectPackagesWithoutLocations(t *testing.T, actualPackages []lockfile.Pack
 
 	innerExpectPackages(t, actualPackages, expectedPackages, true)
 }
+
+func expectSkippedPackages(t *testing.T, actualSkipped []lockfile.SkippedPackage, expectedSkipped []lockfile.SkippedPackage) {
+	t.Helper()
+
+	if diff := cmp.Diff(expectedSkipped, actualSkipped, cmpopts.EquateEmpty()); diff != "" {
+		t.Errorf("Skipped packages mismatch (-want +got):\n%s", diff)
+	}
+}
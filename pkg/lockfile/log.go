@@ -0,0 +1,37 @@
+package lockfile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+var (
+	logWriterMu sync.Mutex
+	logWriter   io.Writer
+)
+
+// SetLogWriter sets where warnings encountered while extracting are written,
+// such as when a version cannot be determined; passing nil restores the
+// default of writing them to os.Stderr.
+func SetLogWriter(w io.Writer) {
+	logWriterMu.Lock()
+	defer logWriterMu.Unlock()
+
+	logWriter = w
+}
+
+// logWarningf writes a warning to the log writer, with each warning being written
+// in a single call so that those from concurrent extractions are not interleaved
+func logWarningf(format string, a ...any) {
+	logWriterMu.Lock()
+	defer logWriterMu.Unlock()
+
+	w := logWriter
+	if w == nil {
+		w = os.Stderr
+	}
+
+	_, _ = fmt.Fprintf(w, format, a...)
+}
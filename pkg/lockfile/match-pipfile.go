@@ -47,6 +47,12 @@ func (m PipfileMatcher) Match(sourcefile DepFile, packages []PackageDetails) err
 
 				versionLocation := fileposition.ExtractDelimitedRegexpPositionInBlock([]string{lowerLine}, ".*", lineNumber, "=\\s*\"", "\"")
 				if versionLocation != nil {
+					// exact versions point to the version itself rather than to its operator
+					version := string([]rune(lowerLine)[versionLocation.Column.Start-1 : versionLocation.Column.End-1])
+					if operator, bare := cutPipenvVersionOperator(version); operator == "==" || operator == "===" {
+						versionLocation.Column.Start += len([]rune(version)) - len([]rune(bare))
+					}
+
 					versionLocation.Filename = sourcefile.Path()
 					packages[key].VersionLocation = versionLocation
 				}
@@ -84,7 +84,7 @@ func TestPyprojectTomlMatcher_Match_OnePackage(t *testing.T) {
 			},
 			VersionLocation: &models.FilePosition{
 				Line:     models.Position{Start: 10, End: 10},
-				Column:   models.Position{Start: 10, End: 18},
+				Column:   models.Position{Start: 12, End: 18},
 				Filename: sourceFile.Path(),
 			},
 			IsDirect: true,
@@ -135,7 +135,7 @@ func TestPyprojectTomlMatcher_Match_TransitiveDependencies(t *testing.T) {
 			},
 			VersionLocation: &models.FilePosition{
 				Line:     models.Position{Start: 10, End: 10},
-				Column:   models.Position{Start: 10, End: 18},
+				Column:   models.Position{Start: 12, End: 18},
 				Filename: sourceFile.Path(),
 			},
 			IsDirect: true,
@@ -11,6 +11,7 @@ func MockAllMatchers() {
 	lockfile.NpmExtractor.Matcher = SuccessfulMatcher{}
 	// build.gradle
 	lockfile.GradleExtractor.Matcher = SuccessfulMatcher{}
+	lockfile.BuildscriptGradleExtractor.Matcher = SuccessfulMatcher{}
 	lockfile.GradleVerificationExtractor.Matcher = SuccessfulMatcher{}
 	// Pipfile (pipenv)
 	lockfile.PipenvExtractor.Matcher = SuccessfulMatcher{}
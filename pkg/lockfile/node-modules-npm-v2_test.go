@@ -282,6 +282,7 @@ func TestNodeModulesExtractor_Extract_npm_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "3b1bb80b302c2e552685dc8a029797ec832ea7c9",
+			License:        "SEE LICENSE IN LICENSE",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 26, End: 41},
 				Column:   models.Position{Start: 5, End: 6},
@@ -311,6 +312,7 @@ func TestNodeModulesExtractor_Extract_npm_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "c5a7ba5e0ad98b8db1cb8ce105403dd4b768cced",
+			License:        "MIT",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 50, End: 59},
 				Column:   models.Position{Start: 5, End: 6},
@@ -327,6 +329,7 @@ func TestNodeModulesExtractor_Extract_npm_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "af885e2e890b9ef0875edd2b117305119ee5bdc5",
+			License:        "MIT",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 60, End: 72},
 				Column:   models.Position{Start: 5, End: 6},
@@ -342,6 +345,7 @@ func TestNodeModulesExtractor_Extract_npm_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "be5935f8d2595bcd97b05718ef1eeae08d812e10",
+			License:        "MIT",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 130, End: 142},
 				Column:   models.Position{Start: 5, End: 6},
@@ -358,6 +362,7 @@ func TestNodeModulesExtractor_Extract_npm_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "d5ac0584ee9ae7bd9288220a39780f155b9ad4c8",
+			License:        "MIT",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 73, End: 82},
 				Column:   models.Position{Start: 5, End: 6},
@@ -373,6 +378,7 @@ func TestNodeModulesExtractor_Extract_npm_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "82dcc8e914dabd9305ab9ae580709a7825e824f5",
+			License:        "MIT",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 143, End: 152},
 				Column:   models.Position{Start: 5, End: 6},
@@ -389,6 +395,7 @@ func TestNodeModulesExtractor_Extract_npm_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "d5ac0584ee9ae7bd9288220a39780f155b9ad4c8",
+			License:        "MIT",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 83, End: 92},
 				Column:   models.Position{Start: 5, End: 6},
@@ -404,6 +411,7 @@ func TestNodeModulesExtractor_Extract_npm_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "82ae8802978da40d7f1be5ad5943c9e550ab2c89",
+			License:        "MIT",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 153, End: 162},
 				Column:   models.Position{Start: 5, End: 6},
@@ -420,6 +428,7 @@ func TestNodeModulesExtractor_Extract_npm_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "af885e2e890b9ef0875edd2b117305119ee5bdc5",
+			License:        "MIT",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 93, End: 105},
 				Column:   models.Position{Start: 5, End: 6},
@@ -436,6 +445,7 @@ func TestNodeModulesExtractor_Extract_npm_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "af885e2e890b9ef0875edd2b117305119ee5bdc5",
+			License:        "MIT",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 106, End: 118},
 				Column:   models.Position{Start: 5, End: 6},
@@ -481,6 +491,7 @@ func TestNodeModulesExtractor_Extract_npm_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "280b560161b751ba226d50c7db1e0a14a78c2de0",
+			License:        "MIT",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 166, End: 175},
 				Column:   models.Position{Start: 5, End: 6},
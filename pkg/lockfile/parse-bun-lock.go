@@ -0,0 +1,297 @@
+package lockfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+	"golang.org/x/exp/maps"
+)
+
+var ErrBunLockbNotSupported = errors.New("the binary bun.lockb format is not supported, run bun to generate a bun.lock")
+
+// BunLockfile contains the required dependency information from a bun.lock,
+// where each package is an array starting with its "name@version" specifier
+type BunLockfile struct {
+	LockfileVersion int                          `json:"lockfileVersion"`
+	Packages        map[string][]json.RawMessage `json:"packages"`
+}
+
+// stripJSONC returns the given JSONC content as plain JSON by blanking out
+// comments and trailing commas, keeping newlines so that lines stay the same
+func stripJSONC(content []byte) []byte {
+	stripped := make([]byte, len(content))
+	copy(stripped, content)
+
+	inString := false
+
+	for i := 0; i < len(stripped); i++ {
+		c := stripped[i]
+
+		if inString {
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+		case c == '/' && i+1 < len(stripped) && stripped[i+1] == '/':
+			for ; i < len(stripped) && stripped[i] != '\n'; i++ {
+				stripped[i] = ' '
+			}
+		case c == '/' && i+1 < len(stripped) && stripped[i+1] == '*':
+			end := len(stripped)
+			if index := bytes.Index(stripped[i+2:], []byte("*/")); index != -1 {
+				end = i + 2 + index + 2
+			}
+
+			for ; i < end; i++ {
+				if stripped[i] != '\n' {
+					stripped[i] = ' '
+				}
+			}
+			i--
+		case c == ',':
+			// a comma is trailing if it is only followed by whitespace
+			// and comments before the closing bracket
+			next := skipJSONCComments(stripped, i+1)
+
+			if next < len(stripped) && (stripped[next] == '}' || stripped[next] == ']') {
+				stripped[i] = ' '
+			}
+		}
+	}
+
+	return stripped
+}
+
+// skipJSONCComments returns the index of the first character from the given
+// index that is not whitespace or part of a comment
+func skipJSONCComments(content []byte, i int) int {
+	for i < len(content) {
+		switch {
+		case bytes.ContainsRune([]byte(" \t\r\n"), rune(content[i])):
+			i++
+		case bytes.HasPrefix(content[i:], []byte("//")):
+			for i < len(content) && content[i] != '\n' {
+				i++
+			}
+		case bytes.HasPrefix(content[i:], []byte("/*")):
+			index := bytes.Index(content[i+2:], []byte("*/"))
+			if index == -1 {
+				return len(content)
+			}
+			i += 2 + index + 2
+		default:
+			return i
+		}
+	}
+
+	return i
+}
+
+// findBunPackageLocations returns the position of the entry for each key
+// within the "packages" object of a bun.lock
+func findBunPackageLocations(lines []string, path string) map[string]models.FilePosition {
+	positions := make(map[string]models.FilePosition)
+
+	start := -1
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), `"packages"`) {
+			start = i + 1
+
+			break
+		}
+	}
+
+	if start == -1 {
+		return positions
+	}
+
+	indent := -1
+	key := ""
+	keyLine := 0
+	lastLine := 0
+
+	closeEntry := func() {
+		if key == "" {
+			return
+		}
+
+		last := strings.TrimRight(lines[lastLine], " \t\r,")
+
+		positions[key] = models.FilePosition{
+			Line: models.Position{Start: keyLine + 1, End: lastLine + 1},
+			Column: models.Position{
+				Start: fileposition.GetFirstNonEmptyCharacterIndexInLine(lines[keyLine]),
+				End:   fileposition.ColumnOfByteIndex(last, len(last)),
+			},
+			Filename: path,
+		}
+		key = ""
+	}
+
+	for i := start; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+
+		lineIndent := len(lines[i]) - len(strings.TrimLeft(lines[i], " \t"))
+
+		if indent == -1 {
+			indent = lineIndent
+		}
+
+		if lineIndent < indent {
+			break
+		}
+
+		if lineIndent == indent && strings.HasPrefix(trimmed, `"`) {
+			closeEntry()
+
+			var k string
+			if end := strings.Index(trimmed, `":`); end != -1 && json.Unmarshal([]byte(trimmed[:end+1]), &k) == nil {
+				key = k
+				keyLine = i
+			}
+		}
+
+		lastLine = i
+	}
+
+	closeEntry()
+
+	return positions
+}
+
+// parseBunPackageVersion returns the version and commit of a package based on the
+// version part of its specifier, or false if the package is local to the project
+func parseBunPackageVersion(version string) (string, string, bool) {
+	for _, protocol := range []string{"workspace:", "link:", "file:"} {
+		if strings.HasPrefix(version, protocol) {
+			return "", "", false
+		}
+	}
+
+	if strings.HasPrefix(version, "github:") || strings.HasPrefix(version, "git+") {
+		_, commit, _ := strings.Cut(version, "#")
+
+		return "", commit, true
+	}
+
+	return version, "", true
+}
+
+type BunLockExtractor struct{}
+
+func (e BunLockExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "bun.lock"
+}
+
+func (e BunLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	var parsedLockfile *BunLockfile
+
+	contentBytes, err := io.ReadAll(f)
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	// comments are blanked out rather than removed, so errors keep their position
+	if err := json.Unmarshal(stripJSONC(contentBytes), &parsedLockfile); err != nil {
+		return []PackageDetails{}, newJSONParseError(f.Path(), contentBytes, err)
+	}
+
+	if parsedLockfile == nil {
+		return []PackageDetails{}, nil
+	}
+
+	positions := findBunPackageLocations(fileposition.BytesToLines(contentBytes), f.Path())
+
+	// nested packages can resolve to the same version as another, so they are
+	// visited in order to consistently report the location of the first one
+	keys := make([]string, 0, len(parsedLockfile.Packages))
+	for key := range parsedLockfile.Packages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	packages := make(map[string]PackageDetails, len(keys))
+
+	for _, key := range keys {
+		entry := parsedLockfile.Packages[key]
+
+		if len(entry) == 0 {
+			continue
+		}
+
+		var specifier string
+		if err := json.Unmarshal(entry[0], &specifier); err != nil {
+			return []PackageDetails{}, fmt.Errorf("could not extract %s from %s: %w", key, f.Path(), err)
+		}
+
+		name, version := splitNpmPackageSpecifier(specifier)
+		version, commit, ok := parseBunPackageVersion(version)
+
+		if !ok {
+			continue
+		}
+
+		id := name + "@" + version + "#" + commit
+		if _, exists := packages[id]; exists {
+			continue
+		}
+
+		packages[id] = PackageDetails{
+			Name:           name,
+			Version:        version,
+			Commit:         commit,
+			PackageManager: models.Bun,
+			Ecosystem:      NpmEcosystem,
+			CompareAs:      NpmEcosystem,
+			BlockLocation:  positions[key],
+		}
+	}
+
+	return maps.Values(packages), nil
+}
+
+var _ Extractor = BunLockExtractor{}
+
+// BunLockbExtractor reports that the binary bun.lockb format is not supported,
+// so that users know to generate a text bun.lock instead of it being silently skipped
+type BunLockbExtractor struct{}
+
+func (e BunLockbExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "bun.lockb"
+}
+
+func (e BunLockbExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), ErrBunLockbNotSupported)
+}
+
+var _ Extractor = BunLockbExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("bun.lock", NpmEcosystem, BunLockExtractor{})
+	registerExtractor("bun.lockb", NpmEcosystem, BunLockbExtractor{})
+}
+
+func ParseBunLock(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, BunLockExtractor{})
+}
@@ -0,0 +1,300 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestBunLockExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "bun.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/bun.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/bun.lock/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/bun.lock.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.bun.lock",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.BunLockExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBunLockbExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	e := lockfile.BunLockbExtractor{}
+
+	if !e.ShouldExtract("path/to/my/bun.lockb") {
+		t.Errorf("Expected bun.lockb to be extracted")
+	}
+
+	if e.ShouldExtract("path/to/my/bun.lock") {
+		t.Errorf("Expected bun.lock to not be extracted")
+	}
+}
+
+func TestParseBunLock_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseBunLock("fixtures/bun/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseBunLock_InvalidJson(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseBunLock("fixtures/bun/not-json.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseBunLock_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseBunLock("fixtures/bun/empty.json")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseBunLock_OnePackage(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/bun/one-package.json"))
+	packages, err := lockfile.ParseBunLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "chalk",
+			Version:        "5.3.0",
+			PackageManager: models.Bun,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 12, End: 12},
+				Column:   models.Position{Start: 5, End: 136},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseBunLock_ScopedPackages(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/bun/scoped-packages.json"))
+	packages, err := lockfile.ParseBunLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "@babel/code-frame",
+			Version:        "7.24.7",
+			PackageManager: models.Bun,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 16, End: 16},
+				Column:   models.Position{Start: 5, End: 212},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "@babel/highlight",
+			Version:        "7.24.7",
+			PackageManager: models.Bun,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 17, End: 17},
+				Column:   models.Position{Start: 5, End: 159},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "wrap-ansi",
+			Version:        "8.1.0",
+			PackageManager: models.Bun,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 18, End: 18},
+				Column:   models.Position{Start: 5, End: 190},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "string-width",
+			Version:        "5.1.2",
+			PackageManager: models.Bun,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 19, End: 19},
+				Column:   models.Position{Start: 5, End: 160},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "string-width",
+			Version:        "4.2.3",
+			PackageManager: models.Bun,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 21, End: 21},
+				Column:   models.Position{Start: 5, End: 150},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseBunLock_GitAndWorkspacePackages(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/bun/git-and-workspace-packages.json"))
+	packages, err := lockfile.ParseBunLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "left-pad",
+			Version:        "",
+			Commit:         "5ee2a8e",
+			PackageManager: models.Bun,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 18, End: 18},
+				Column:   models.Position{Start: 5, End: 95},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseBunLock_MultiLinePackages(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/bun/multi-line-packages.json"))
+	packages, err := lockfile.ParseBunLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "chalk",
+			Version:        "5.3.0",
+			PackageManager: models.Bun,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 9},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "wrappy",
+			Version:        "1.0.2",
+			PackageManager: models.Bun,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 5, End: 138},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestExtractDeps_BunLockb(t *testing.T) {
+	t.Parallel()
+
+	_, err := lockfile.ExtractDeps(
+		openTestDepFile("/path/to/my/bun.lockb"),
+		"",
+		map[string]bool{"bun.lockb": true},
+	)
+
+	expectErrIs(t, err, lockfile.ErrBunLockbNotSupported)
+}
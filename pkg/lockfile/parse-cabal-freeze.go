@@ -0,0 +1,133 @@
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+const HackageEcosystem Ecosystem = "Hackage"
+
+const cabalFreezeConstraintsField = "constraints:"
+
+// parseCabalFreezeConstraint parses a single constraint like "any.aeson ==2.0.3.0"
+// found at the given offset of the line, returning false if it does not pin a
+// version, such as constraints that only set flags or require an installed package
+func parseCabalFreezeConstraint(line string, offset int, constraint string, path string) (PackageDetails, bool) {
+	trimmed := strings.TrimSpace(constraint)
+	offset += strings.Index(constraint, trimmed)
+
+	name, version, found := strings.Cut(trimmed, "==")
+	if !found {
+		return PackageDetails{}, false
+	}
+
+	name = strings.TrimSpace(name)
+	version = strings.TrimSpace(version)
+
+	// constraints can be qualified with where they apply, like "any." or "setup."
+	if i := strings.LastIndex(name, "."); i != -1 {
+		name = name[i+1:]
+	}
+
+	if name == "" || version == "" || strings.ContainsAny(version, " \t") {
+		return PackageDetails{}, false
+	}
+
+	versionOffset := offset + strings.LastIndex(trimmed, version)
+
+	return PackageDetails{
+		Name:           name,
+		Version:        version,
+		PackageManager: models.Cabal,
+		Ecosystem:      HackageEcosystem,
+		CompareAs:      HackageEcosystem,
+		BlockLocation: models.FilePosition{
+			Column: models.Position{
+				Start: fileposition.ColumnOfByteIndex(line, offset),
+				End:   fileposition.ColumnOfByteIndex(line, offset+len(trimmed)),
+			},
+			Filename: path,
+		},
+		VersionLocation: &models.FilePosition{
+			Column: models.Position{
+				Start: fileposition.ColumnOfByteIndex(line, versionOffset),
+				End:   fileposition.ColumnOfByteIndex(line, versionOffset+len(version)),
+			},
+			Filename: path,
+		},
+	}, true
+}
+
+type CabalFreezeExtractor struct{}
+
+func (e CabalFreezeExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "cabal.project.freeze"
+}
+
+func (e CabalFreezeExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	packages := make([]PackageDetails, 0)
+	seen := make(map[string]struct{})
+	scanner := bufio.NewScanner(f)
+	inConstraints := false
+	lineNumber := 0
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNumber++
+
+		// fields start at the beginning of a line, with their values
+		// being continued on the lines that are indented after them
+		offset := 0
+		if line != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			inConstraints = strings.HasPrefix(strings.ToLower(line), cabalFreezeConstraintsField)
+
+			offset = len(cabalFreezeConstraintsField)
+		}
+
+		if !inConstraints || strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+
+		for _, constraint := range strings.Split(line[offset:], ",") {
+			pkg, ok := parseCabalFreezeConstraint(line, offset, constraint, f.Path())
+			offset += len(constraint) + 1
+
+			if !ok {
+				continue
+			}
+
+			// the same package can be constrained for different qualifiers like "setup."
+			if _, exists := seen[pkg.Key()]; exists {
+				continue
+			}
+			seen[pkg.Key()] = struct{}{}
+
+			pkg.BlockLocation.Line = models.Position{Start: lineNumber, End: lineNumber}
+			pkg.VersionLocation.Line = models.Position{Start: lineNumber, End: lineNumber}
+
+			packages = append(packages, pkg)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return []PackageDetails{}, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = CabalFreezeExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("cabal.project.freeze", HackageEcosystem, CabalFreezeExtractor{})
+}
+
+func ParseCabalFreeze(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, CabalFreezeExtractor{})
+}
@@ -0,0 +1,222 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestCabalFreezeExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "cabal.project.freeze",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/cabal.project.freeze",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/cabal.project.freeze/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/cabal.project.freeze.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.cabal.project.freeze",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.CabalFreezeExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCabalFreeze_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseCabalFreeze("fixtures/cabal/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseCabalFreeze_NoConstraints(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseCabalFreeze("fixtures/cabal/empty.freeze")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseCabalFreeze_OnePackage(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/cabal/one-package.freeze"))
+	packages, err := lockfile.ParseCabalFreeze(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "aeson",
+			Version:        "2.0.3.0",
+			PackageManager: models.Cabal,
+			Ecosystem:      lockfile.HackageEcosystem,
+			CompareAs:      lockfile.HackageEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 14, End: 33},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 26, End: 33},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseCabalFreeze_ManyPackages(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/cabal/many-packages.freeze"))
+	packages, err := lockfile.ParseCabalFreeze(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "Cabal",
+			Version:        "3.6.3.0",
+			PackageManager: models.Cabal,
+			Ecosystem:      lockfile.HackageEcosystem,
+			CompareAs:      lockfile.HackageEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 14, End: 33},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 26, End: 33},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "aeson",
+			Version:        "2.0.3.0",
+			PackageManager: models.Cabal,
+			Ecosystem:      lockfile.HackageEcosystem,
+			CompareAs:      lockfile.HackageEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 14, End: 33},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 26, End: 33},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "base",
+			Version:        "4.16.4.0",
+			PackageManager: models.Cabal,
+			Ecosystem:      lockfile.HackageEcosystem,
+			CompareAs:      lockfile.HackageEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 14, End: 33},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 25, End: 33},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "text",
+			Version:        "1.2.5.0",
+			PackageManager: models.Cabal,
+			Ecosystem:      lockfile.HackageEcosystem,
+			CompareAs:      lockfile.HackageEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 14, End: 32},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 25, End: 32},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "zlib",
+			Version:        "0.6.3.0",
+			PackageManager: models.Cabal,
+			Ecosystem:      lockfile.HackageEcosystem,
+			CompareAs:      lockfile.HackageEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 34, End: 52},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 45, End: 52},
+				Filename: path,
+			},
+		},
+	})
+}
@@ -3,6 +3,7 @@ package lockfile
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/google/osv-scanner/pkg/models"
 
@@ -12,6 +13,7 @@ import (
 type CargoLockPackage struct {
 	Name    string `toml:"name"`
 	Version string `toml:"version"`
+	Source  string `toml:"source"`
 }
 
 type CargoLockFile struct {
@@ -21,6 +23,33 @@ type CargoLockFile struct {
 
 const CargoEcosystem Ecosystem = "crates.io"
 
+// tryExtractCargoCommit returns the revision a git source is pinned to,
+// which Cargo records as the fragment of the source url
+func tryExtractCargoCommit(source string) string {
+	if !strings.HasPrefix(source, "git+") {
+		return ""
+	}
+
+	_, commit, found := strings.Cut(source, "#")
+	if !found {
+		return ""
+	}
+
+	return commit
+}
+
+// cargoRegistryURL returns the url of the registry that a registry source is
+// for, which Cargo records with a prefix for the protocol used to access it
+func cargoRegistryURL(source string) string {
+	for _, prefix := range []string{"registry+", "sparse+"} {
+		if url, found := strings.CutPrefix(source, prefix); found {
+			return CargoEcosystem.nonDefaultRegistryURL(url)
+		}
+	}
+
+	return ""
+}
+
 type CargoLockExtractor struct{}
 
 func (e CargoLockExtractor) ShouldExtract(path string) bool {
@@ -42,6 +71,8 @@ func (e CargoLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 		packages = append(packages, PackageDetails{
 			Name:           lockPackage.Name,
 			Version:        lockPackage.Version,
+			Commit:         tryExtractCargoCommit(lockPackage.Source),
+			RegistryURL:    cargoRegistryURL(lockPackage.Source),
 			PackageManager: models.Crates,
 			Ecosystem:      CargoEcosystem,
 			CompareAs:      CargoEcosystem,
@@ -55,7 +86,7 @@ var _ Extractor = CargoLockExtractor{}
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("Cargo.lock", CargoLockExtractor{})
+	registerExtractor("Cargo.lock", CargoEcosystem, CargoLockExtractor{})
 }
 
 func ParseCargoLock(pathToLockfile string) ([]PackageDetails, error) {
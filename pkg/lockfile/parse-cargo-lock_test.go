@@ -184,3 +184,74 @@ func TestParseCargoLock_PackageWithBuildString(t *testing.T) {
 		},
 	})
 }
+
+func TestParseCargoLock_PackageWithGitSource(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseCargoLock("fixtures/cargo/package-with-git-source.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "addr2line",
+			Version:        "0.15.2",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+		},
+		{
+			Name:           "rand",
+			Version:        "0.9.0-alpha.0",
+			Commit:         "8bf4d4d2ab84693ce1d4a173c1b9bdcf8b503a4b",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+		},
+	})
+}
+
+func TestParseCargoLock_PackagesWithRegistrySources(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseCargoLock("fixtures/cargo/packages-with-registry-sources.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "addr2line",
+			Version:        "0.15.2",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+		},
+		{
+			Name:           "libc",
+			Version:        "0.2.153",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+		},
+		{
+			Name:           "my-internal-crate",
+			Version:        "1.4.0",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+			RegistryURL:    "https://git.example.com/crates-index",
+		},
+		{
+			Name:           "my-other-crate",
+			Version:        "0.3.1",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+			RegistryURL:    "https://crates.example.com/api/v1/crates",
+		},
+	})
+}
@@ -0,0 +1,252 @@
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+
+	"github.com/BurntSushi/toml"
+)
+
+// cargoTomlDependencyTables are the tables of a Cargo.toml that dependencies are declared in
+var cargoTomlDependencyTables = []string{"dependencies", "dev-dependencies", "build-dependencies"}
+
+// cargoTomlDependencyGroups are the DepGroups of the packages declared in each of the
+// dependency tables, named like those of other ecosystems, with the packages of the
+// "dependencies" table not being in any group as they are required to use the crate
+var cargoTomlDependencyGroups = map[string]string{
+	"dev-dependencies":   "dev",
+	"build-dependencies": "build",
+}
+
+// parseCargoTomlTableHeader returns the dependency table that the given table header
+// is for, along with the name of the dependency if it declares a single dependency
+// like "[dependencies.serde]", returning false if it is not for a dependency table
+func parseCargoTomlTableHeader(header string) (string, string, bool) {
+	// dependencies specific to a platform are declared within a table for the target,
+	// like "[target.'cfg(unix)'.dependencies]"
+	if strings.HasPrefix(header, "target.") {
+		for _, table := range cargoTomlDependencyTables {
+			if i := strings.LastIndex(header, "."+table); i != -1 {
+				header = header[i+1:]
+
+				break
+			}
+		}
+	}
+
+	for _, table := range cargoTomlDependencyTables {
+		if header == table {
+			return table, "", true
+		}
+
+		if name, found := strings.CutPrefix(header, table+"."); found {
+			return table, strings.Trim(name, `"'`), true
+		}
+	}
+
+	return "", "", false
+}
+
+// parseCargoTomlDependency returns the name of the package and the version
+// requirement of a dependency, which is either just the requirement or a table
+// that can rename the package and might not have a requirement at all, such as
+// when the dependency is a path or is inherited from the workspace
+func parseCargoTomlDependency(key string, value any) (string, string) {
+	switch v := value.(type) {
+	case string:
+		return key, v
+	case map[string]any:
+		name := key
+		if pkg, ok := v["package"].(string); ok {
+			name = pkg
+		}
+
+		version, _ := v["version"].(string)
+
+		return name, version
+	}
+
+	return key, ""
+}
+
+// cargoTomlDependencyBlock returns the location of a dependency that has been
+// declared over the given lines, ignoring the whitespace around them
+func cargoTomlDependencyBlock(path string, startLine int, firstLine string, endLine int, lastLine string) models.FilePosition {
+	start := len(firstLine) - len(strings.TrimLeft(firstLine, " \t"))
+	end := len(strings.TrimRight(lastLine, " \t"))
+
+	return models.FilePosition{
+		Line: models.Position{Start: startLine, End: endLine},
+		Column: models.Position{
+			Start: fileposition.ColumnOfByteIndex(firstLine, start),
+			End:   fileposition.ColumnOfByteIndex(lastLine, end),
+		},
+		Filename: path,
+	}
+}
+
+func newCargoTomlPackage(name, version, table string, block models.FilePosition) PackageDetails {
+	var groups []string
+
+	if group, ok := cargoTomlDependencyGroups[table]; ok {
+		groups = []string{group}
+	}
+
+	return PackageDetails{
+		Name:           name,
+		Version:        version,
+		PackageManager: models.Crates,
+		Ecosystem:      CargoEcosystem,
+		CompareAs:      CargoEcosystem,
+		DepGroups:      groups,
+		BlockLocation:  block,
+		IsDirect:       true,
+	}
+}
+
+// CargoTomlExtractor extracts the dependencies declared in a Cargo.toml, keeping their
+// version requirements as they are written, which is useful for libraries as they
+// typically do not commit their Cargo.lock.
+//
+// Nothing is extracted if there is a Cargo.lock next to the Cargo.toml.
+type CargoTomlExtractor struct{}
+
+func (e CargoTomlExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "Cargo.toml"
+}
+
+func (e CargoTomlExtractor) SupportedDepGroups() []string {
+	return []string{"dev", "build"}
+}
+
+func (e CargoTomlExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	if hasSiblingLockfile(f, "Cargo.lock") {
+		return []PackageDetails{}, nil
+	}
+
+	packages := make([]PackageDetails, 0)
+	scanner := bufio.NewScanner(f)
+
+	table := ""
+	lineNumber := 0
+
+	// dependencies declared as their own table, like "[dependencies.serde]",
+	// are only known once the whole of their table has been read
+	tableDependency := ""
+	tableStartLine, tableHeader := 0, ""
+	tableEndLine, tableLastLine := 0, ""
+	var tableBody strings.Builder
+
+	finishTableDependency := func() error {
+		if tableDependency == "" {
+			return nil
+		}
+
+		var value map[string]any
+		if _, err := toml.Decode(tableBody.String(), &value); err != nil {
+			return err
+		}
+
+		if name, version := parseCargoTomlDependency(tableDependency, value); version != "" {
+			packages = append(packages, newCargoTomlPackage(
+				name,
+				version,
+				table,
+				cargoTomlDependencyBlock(f.Path(), tableStartLine, tableHeader, tableEndLine, tableLastLine),
+			))
+		}
+
+		tableDependency = ""
+		tableBody.Reset()
+
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNumber++
+
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "[") {
+			if err := finishTableDependency(); err != nil {
+				return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+			}
+
+			header := strings.Trim(trimmed[:strings.LastIndex(trimmed, "]")+1], "[]")
+
+			var ok bool
+			table, tableDependency, ok = parseCargoTomlTableHeader(strings.TrimSpace(header))
+
+			if !ok {
+				table = ""
+			}
+
+			tableStartLine, tableHeader = lineNumber, line
+			tableEndLine, tableLastLine = lineNumber, line
+
+			continue
+		}
+
+		if table == "" {
+			continue
+		}
+
+		if tableDependency != "" {
+			tableBody.WriteString(line + "\n")
+			tableEndLine, tableLastLine = lineNumber, line
+
+			continue
+		}
+
+		var dependency map[string]any
+
+		// values that continue onto the following lines are not supported,
+		// so they are skipped rather than causing the whole file to fail
+		if _, err := toml.Decode(line, &dependency); err != nil {
+			continue
+		}
+
+		for key, value := range dependency {
+			if name, version := parseCargoTomlDependency(key, value); version != "" {
+				packages = append(packages, newCargoTomlPackage(
+					name,
+					version,
+					table,
+					cargoTomlDependencyBlock(f.Path(), lineNumber, line, lineNumber, line),
+				))
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return []PackageDetails{}, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
+	}
+
+	if err := finishTableDependency(); err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = CargoTomlExtractor{}
+var _ ExtractorWithDepGroups = CargoTomlExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("Cargo.toml", CargoEcosystem, CargoTomlExtractor{})
+}
+
+func ParseCargoToml(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, CargoTomlExtractor{})
+}
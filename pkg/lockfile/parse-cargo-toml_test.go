@@ -0,0 +1,286 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestCargoTomlExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "Cargo.toml",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/Cargo.toml",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/Cargo.toml/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/Cargo.toml.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.Cargo.toml",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.CargoTomlExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCargoToml_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseCargoToml("fixtures/cargo-toml/does-not-exist/Cargo.toml")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseCargoToml_NotToml(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseCargoToml("fixtures/cargo-toml/not-toml/Cargo.toml")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseCargoToml_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseCargoToml("fixtures/cargo-toml/empty/Cargo.toml")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseCargoToml_OnePackage(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/cargo-toml/one-package/Cargo.toml"))
+	packages, err := lockfile.ParseCargoToml(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "serde",
+			Version:        "1.0",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 1, End: 14},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestParseCargoToml_ManyPackages(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/cargo-toml/many-packages/Cargo.toml"))
+	packages, err := lockfile.ParseCargoToml(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "serde",
+			Version:        "^1.0",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 1, End: 52},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "regex",
+			Version:        ">=1.2",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 1, End: 35},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "tokio",
+			Version:        "1.28",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 1, End: 55},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "criterion",
+			Version:        "0.5",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+			DepGroups:      []string{"dev"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 13, End: 13},
+				Column:   models.Position{Start: 1, End: 18},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "cc",
+			Version:        "~1.0.79",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+			DepGroups:      []string{"build"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 16, End: 16},
+				Column:   models.Position{Start: 1, End: 15},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "libc",
+			Version:        "0.2",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 19, End: 19},
+				Column:   models.Position{Start: 1, End: 13},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "rand",
+			Version:        "0.8",
+			PackageManager: models.Crates,
+			Ecosystem:      lockfile.CargoEcosystem,
+			CompareAs:      lockfile.CargoEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 21, End: 23},
+				Column:   models.Position{Start: 1, End: 25},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestParseCargoToml_WithLockfile(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseCargoToml("fixtures/cargo-toml/with-lockfile/Cargo.toml")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	// the Cargo.lock next to the manifest is extracted instead
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestCargoTomlExtractor_ExtractWithOptions_ExcludeDev(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/cargo-toml/many-packages/Cargo.toml")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	packages, err := lockfile.ExtractWithOptions(lockfile.CargoTomlExtractor{}, f, lockfile.ExtractOptions{
+		ExcludeDepGroups: []string{"dev"},
+	})
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	names := make([]string, 0, len(packages))
+	for _, pkg := range packages {
+		names = append(names, pkg.Name)
+	}
+
+	if slices.Contains(names, "criterion") {
+		t.Errorf("Expected the dev dependencies to be excluded but got %v", names)
+	}
+
+	if !slices.Contains(names, "cc") {
+		t.Errorf("Expected the build dependencies to still be extracted but got %v", names)
+	}
+}
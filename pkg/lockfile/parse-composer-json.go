@@ -0,0 +1,189 @@
+package lockfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+type ComposerJSON struct {
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+// isComposerPlatformPackage checks if the given name is of a platform package,
+// like "php" or "ext-json", which are provided by the system rather than being
+// installed by Composer, and which unlike packages do not have a vendor
+func isComposerPlatformPackage(name string) bool {
+	return !strings.Contains(name, "/")
+}
+
+// composerJSONDependencyOffsets returns the byte offsets of where each dependency in the
+// given object of the composer.json starts and ends, from the opening quote of its name
+// to the closing quote of its constraint
+func composerJSONDependencyOffsets(content []byte, object string) map[string][2]int {
+	offsets := map[string][2]int{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+
+	// the opening of the root object
+	if _, err := decoder.Token(); err != nil {
+		return offsets
+	}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return offsets
+		}
+
+		if key != object {
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return offsets
+			}
+
+			continue
+		}
+
+		if delim, err := decoder.Token(); err != nil || delim != json.Delim('{') {
+			return offsets
+		}
+
+		for decoder.More() {
+			// the offset is of the end of the previous token, so the name
+			// starts at the first quote that comes after it
+			start := int(decoder.InputOffset())
+
+			name, err := decoder.Token()
+			if err != nil {
+				return offsets
+			}
+
+			var constraint json.RawMessage
+			if err := decoder.Decode(&constraint); err != nil {
+				return offsets
+			}
+
+			start += bytes.IndexByte(content[start:], '"')
+			offsets[fmt.Sprint(name)] = [2]int{start, int(decoder.InputOffset())}
+		}
+
+		return offsets
+	}
+
+	return offsets
+}
+
+// composerJSONPosition returns the position of the given byte offsets within the content
+func composerJSONPosition(content []byte, start int, end int, path string) models.FilePosition {
+	position := func(index int) (int, int) {
+		lineStart := bytes.LastIndexByte(content[:index], '\n') + 1
+
+		return bytes.Count(content[:index], []byte("\n")) + 1,
+			fileposition.ColumnOfByteIndex(string(content[lineStart:index]), index-lineStart)
+	}
+
+	startLine, startColumn := position(start)
+	endLine, endColumn := position(end)
+
+	return models.FilePosition{
+		Line:     models.Position{Start: startLine, End: endLine},
+		Column:   models.Position{Start: startColumn, End: endColumn},
+		Filename: path,
+	}
+}
+
+// ComposerJSONExtractor extracts the dependencies declared in a composer.json, keeping
+// their version constraints as they are written, which is useful for libraries as they
+// typically do not commit their composer.lock.
+//
+// Platform requirements such as "php" and "ext-json" are not extracted, as they are
+// provided by the system rather than being installed by Composer.
+//
+// Nothing is extracted if there is a composer.lock next to the composer.json.
+type ComposerJSONExtractor struct{}
+
+func (e ComposerJSONExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "composer.json"
+}
+
+func (e ComposerJSONExtractor) SupportedDepGroups() []string {
+	return []string{"dev"}
+}
+
+func (e ComposerJSONExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	if hasSiblingLockfile(f, "composer.lock") {
+		return []PackageDetails{}, nil
+	}
+
+	var parsedFile *ComposerJSON
+
+	contentBytes, err := io.ReadAll(f)
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	if err := json.Unmarshal(contentBytes, &parsedFile); err != nil {
+		return []PackageDetails{}, newJSONParseError(f.Path(), contentBytes, err)
+	}
+
+	if parsedFile == nil {
+		return []PackageDetails{}, nil
+	}
+
+	packages := make([]PackageDetails, 0, len(parsedFile.Require)+len(parsedFile.RequireDev))
+
+	for _, object := range []string{"require", "require-dev"} {
+		dependencies := parsedFile.Require
+		if object == "require-dev" {
+			dependencies = parsedFile.RequireDev
+		}
+
+		offsets := composerJSONDependencyOffsets(contentBytes, object)
+
+		for name, constraint := range dependencies {
+			if isComposerPlatformPackage(name) {
+				continue
+			}
+
+			pkg := PackageDetails{
+				Name:           name,
+				Version:        constraint,
+				PackageManager: models.Composer,
+				Ecosystem:      ComposerEcosystem,
+				CompareAs:      ComposerEcosystem,
+				IsDirect:       true,
+			}
+
+			if object == "require-dev" {
+				pkg.DepGroups = []string{"dev"}
+			}
+
+			if offset, ok := offsets[name]; ok {
+				pkg.BlockLocation = composerJSONPosition(contentBytes, offset[0], offset[1], f.Path())
+			}
+
+			packages = append(packages, pkg)
+		}
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = ComposerJSONExtractor{}
+var _ ExtractorWithDepGroups = ComposerJSONExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("composer.json", ComposerEcosystem, ComposerJSONExtractor{})
+}
+
+func ParseComposerJSON(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, ComposerJSONExtractor{})
+}
@@ -0,0 +1,222 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestComposerJSONExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "composer.json",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/composer.json",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/composer.json/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/composer.json.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.composer.json",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.ComposerJSONExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseComposerJSON_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseComposerJSON("fixtures/composer-json/does-not-exist/composer.json")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseComposerJSON_InvalidJson(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseComposerJSON("fixtures/composer-json/not-json/composer.json")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseComposerJSON_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseComposerJSON("fixtures/composer-json/empty/composer.json")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseComposerJSON_Null(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseComposerJSON("fixtures/composer-json/null/composer.json")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseComposerJSON_OnePackage(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/composer-json/one-package/composer.json"))
+	packages, err := lockfile.ParseComposerJSON(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "monolog/monolog",
+			Version:        "^2.0",
+			PackageManager: models.Composer,
+			Ecosystem:      lockfile.ComposerEcosystem,
+			CompareAs:      lockfile.ComposerEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 5, End: 30},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestParseComposerJSON_ManyPackages(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/composer-json/many-packages/composer.json"))
+	packages, err := lockfile.ParseComposerJSON(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "monolog/monolog",
+			Version:        "^2.0",
+			PackageManager: models.Composer,
+			Ecosystem:      lockfile.ComposerEcosystem,
+			CompareAs:      lockfile.ComposerEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 5, End: 30},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "guzzlehttp/guzzle",
+			Version:        ">=7.4 <8.0",
+			PackageManager: models.Composer,
+			Ecosystem:      lockfile.ComposerEcosystem,
+			CompareAs:      lockfile.ComposerEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 9, End: 10},
+				Column:   models.Position{Start: 5, End: 19},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "symfony/console",
+			Version:        "5.4.*|^6.0",
+			PackageManager: models.Composer,
+			Ecosystem:      lockfile.ComposerEcosystem,
+			CompareAs:      lockfile.ComposerEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 11, End: 11},
+				Column:   models.Position{Start: 5, End: 36},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "phpunit/phpunit",
+			Version:        "^10.0",
+			PackageManager: models.Composer,
+			Ecosystem:      lockfile.ComposerEcosystem,
+			CompareAs:      lockfile.ComposerEcosystem,
+			DepGroups:      []string{"dev"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 14, End: 14},
+				Column:   models.Position{Start: 5, End: 31},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestParseComposerJSON_WithLockfile(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseComposerJSON("fixtures/composer-json/with-lockfile/composer.json")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/google/osv-scanner/pkg/models"
 )
@@ -14,6 +15,13 @@ type ComposerPackage struct {
 	Dist    struct {
 		Reference string `json:"reference"`
 	} `json:"dist"`
+	License []string `json:"license,omitempty"`
+}
+
+// license returns the SPDX expression of the package's licenses, which
+// composer lists separately when the package can be used under any of them
+func (pkg ComposerPackage) license() string {
+	return strings.Join(pkg.License, " OR ")
 }
 
 type ComposerLock struct {
@@ -31,7 +39,15 @@ func (e ComposerLockExtractor) ShouldExtract(path string) bool {
 	return filepath.Base(path) == "composer.lock"
 }
 
+func (e ComposerLockExtractor) SupportedDepGroups() []string {
+	return []string{"dev"}
+}
+
 func (e ComposerLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	return e.ExtractWithOptions(f, ExtractOptions{})
+}
+
+func (e ComposerLockExtractor) ExtractWithOptions(f DepFile, opts ExtractOptions) ([]PackageDetails, error) {
 	var parsedLockfile *ComposerLock
 
 	err := json.NewDecoder(f).Decode(&parsedLockfile)
@@ -52,17 +68,24 @@ func (e ComposerLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 			Name:           composerPackage.Name,
 			Version:        composerPackage.Version,
 			Commit:         composerPackage.Dist.Reference,
+			License:        composerPackage.license(),
 			PackageManager: models.Composer,
 			Ecosystem:      ComposerEcosystem,
 			CompareAs:      ComposerEcosystem,
 		})
 	}
 
+	// dev packages are listed separately, so they can be skipped entirely when excluded
+	if opts.excludesGroup("dev") {
+		return packages, nil
+	}
+
 	for _, composerPackage := range parsedLockfile.PackagesDev {
 		packages = append(packages, PackageDetails{
 			Name:           composerPackage.Name,
 			Version:        composerPackage.Version,
 			Commit:         composerPackage.Dist.Reference,
+			License:        composerPackage.license(),
 			PackageManager: models.Composer,
 			Ecosystem:      ComposerEcosystem,
 			CompareAs:      ComposerEcosystem,
@@ -73,13 +96,15 @@ func (e ComposerLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 	return packages, nil
 }
 
+var _ ExtractorWithOptions = ComposerLockExtractor{}
+
 var ComposerExtractor = ComposerLockExtractor{
 	WithMatcher{Matcher: ComposerMatcher{}},
 }
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("composer.lock", ComposerExtractor)
+	registerExtractor("composer.lock", ComposerEcosystem, ComposerExtractor)
 }
 
 func ParseComposerLock(pathToLockfile string) ([]PackageDetails, error) {
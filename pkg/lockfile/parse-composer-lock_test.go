@@ -106,6 +106,7 @@ func TestParseComposerLock_OnePackage(t *testing.T) {
 			Version:        "2.0.4",
 			PackageManager: models.Composer,
 			Commit:         "4c115873c86ad5bd0ac6d962db70ca53bf8fb874",
+			License:        "MIT",
 			Ecosystem:      lockfile.ComposerEcosystem,
 			CompareAs:      lockfile.ComposerEcosystem,
 		},
@@ -127,6 +128,7 @@ func TestParseComposerLock_OnePackageDev(t *testing.T) {
 			Version:        "2.0.4",
 			PackageManager: models.Composer,
 			Commit:         "4c115873c86ad5bd0ac6d962db70ca53bf8fb874",
+			License:        "MIT",
 			Ecosystem:      lockfile.ComposerEcosystem,
 			CompareAs:      lockfile.ComposerEcosystem,
 			DepGroups:      []string{"dev"},
@@ -149,6 +151,7 @@ func TestParseComposerLock_TwoPackages(t *testing.T) {
 			Version:        "2.0.4",
 			PackageManager: models.Composer,
 			Commit:         "4c115873c86ad5bd0ac6d962db70ca53bf8fb874",
+			License:        "MIT",
 			Ecosystem:      lockfile.ComposerEcosystem,
 			CompareAs:      lockfile.ComposerEcosystem,
 		},
@@ -157,6 +160,7 @@ func TestParseComposerLock_TwoPackages(t *testing.T) {
 			Version:        "1.1.3",
 			PackageManager: models.Composer,
 			Commit:         "11336f6f84e16a720dae9d8e6ed5019efa85a0f9",
+			License:        "BSD-3-Clause",
 			Ecosystem:      lockfile.ComposerEcosystem,
 			CompareAs:      lockfile.ComposerEcosystem,
 			DepGroups:      []string{"dev"},
@@ -164,6 +168,38 @@ func TestParseComposerLock_TwoPackages(t *testing.T) {
 	})
 }
 
+func TestComposerLockExtractor_ExtractWithOptions_ExcludeDev(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/composer/two-packages.json")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	packages, err := lockfile.ExtractWithOptions(
+		lockfile.ComposerExtractor,
+		f,
+		lockfile.ExtractOptions{ExcludeDepGroups: []string{"dev"}},
+	)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "sentry/sdk",
+			Version:        "2.0.4",
+			PackageManager: models.Composer,
+			Commit:         "4c115873c86ad5bd0ac6d962db70ca53bf8fb874",
+			License:        "MIT",
+			Ecosystem:      lockfile.ComposerEcosystem,
+			CompareAs:      lockfile.ComposerEcosystem,
+		},
+	})
+}
+
 func TestParseComposerLock_TwoPackagesAlt(t *testing.T) {
 	t.Parallel()
 
@@ -179,6 +215,7 @@ func TestParseComposerLock_TwoPackagesAlt(t *testing.T) {
 			Version:        "2.0.4",
 			PackageManager: models.Composer,
 			Commit:         "4c115873c86ad5bd0ac6d962db70ca53bf8fb874",
+			License:        "MIT",
 			Ecosystem:      lockfile.ComposerEcosystem,
 			CompareAs:      lockfile.ComposerEcosystem,
 		},
@@ -187,6 +224,7 @@ func TestParseComposerLock_TwoPackagesAlt(t *testing.T) {
 			Version:        "1.1.3",
 			PackageManager: models.Composer,
 			Commit:         "11336f6f84e16a720dae9d8e6ed5019efa85a0f9",
+			License:        "BSD-3-Clause",
 			Ecosystem:      lockfile.ComposerEcosystem,
 			CompareAs:      lockfile.ComposerEcosystem,
 		},
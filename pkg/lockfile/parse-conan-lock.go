@@ -183,6 +183,10 @@ func (e ConanLockExtractor) ShouldExtract(path string) bool {
 	return filepath.Base(path) == "conan.lock"
 }
 
+func (e ConanLockExtractor) SupportedDepGroups() []string {
+	return []string{"requires", "build-requires", "python-requires"}
+}
+
 func (e ConanLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 	var parsedLockfile *ConanLockFile
 
@@ -198,7 +202,7 @@ var _ Extractor = ConanLockExtractor{}
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("conan.lock", ConanLockExtractor{})
+	registerExtractor("conan.lock", ConanEcosystem, ConanLockExtractor{})
 }
 
 func ParseConanLock(pathToLockfile string) ([]PackageDetails, error) {
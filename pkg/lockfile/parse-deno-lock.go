@@ -0,0 +1,108 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+type DenoLockNpmPackage struct {
+	Integrity string `json:"integrity"`
+
+	models.FilePosition
+}
+
+type DenoLockPackages struct {
+	Specifiers map[string]string              `json:"specifiers,omitempty"`
+	Npm        map[string]*DenoLockNpmPackage `json:"npm,omitempty"`
+}
+
+// DenoLockfile contains the required dependency information from a deno.lock,
+// which in v3 nests npm packages under "packages" and in v4 has them at the top level
+type DenoLockfile struct {
+	Version  string                         `json:"version"`
+	Packages DenoLockPackages               `json:"packages"`
+	Npm      map[string]*DenoLockNpmPackage `json:"npm,omitempty"`
+}
+
+// parseDenoNpmPackageKey splits a key like "@std/foo@1.0.0_peer@2.0.0" into its name and version
+func parseDenoNpmPackageKey(key string) (string, string) {
+	name, version := splitNpmPackageSpecifier(key)
+
+	// peer dependencies are appended to the version after an underscore
+	version, _, _ = strings.Cut(version, "_")
+
+	return name, version
+}
+
+type DenoLockExtractor struct{}
+
+func (e DenoLockExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "deno.lock"
+}
+
+func (e DenoLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	var parsedLockfile *DenoLockfile
+
+	contentBytes, err := io.ReadAll(f)
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	if err := json.Unmarshal(contentBytes, &parsedLockfile); err != nil {
+		return []PackageDetails{}, newJSONParseError(f.Path(), contentBytes, err)
+	}
+
+	if parsedLockfile == nil {
+		return []PackageDetails{}, nil
+	}
+
+	npmPackages := parsedLockfile.Npm
+	if npmPackages == nil {
+		npmPackages = parsedLockfile.Packages.Npm
+	}
+
+	fileposition.InJSON("npm", npmPackages, fileposition.BytesToLines(contentBytes), 0)
+
+	packages := make([]PackageDetails, 0, len(npmPackages))
+
+	for key, pkg := range npmPackages {
+		// packages without any details have no position to report them with
+		if pkg == nil {
+			continue
+		}
+
+		name, version := parseDenoNpmPackageKey(key)
+
+		packages = append(packages, PackageDetails{
+			Name:           name,
+			Version:        version,
+			PackageManager: models.Deno,
+			Ecosystem:      NpmEcosystem,
+			CompareAs:      NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     pkg.Line,
+				Column:   pkg.Column,
+				Filename: f.Path(),
+			},
+		})
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = DenoLockExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("deno.lock", NpmEcosystem, DenoLockExtractor{})
+}
+
+func ParseDenoLock(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, DenoLockExtractor{})
+}
@@ -0,0 +1,251 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestDenoLockExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "deno.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/deno.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/deno.lock/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/deno.lock.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.deno.lock",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.DenoLockExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDenoLock_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseDenoLock("fixtures/deno/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseDenoLock_InvalidJson(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseDenoLock("fixtures/deno/not-json.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseDenoLock_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseDenoLock("fixtures/deno/empty.v3.json")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseDenoLock_OnePackage(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/deno/one-package.v3.json"))
+	packages, err := lockfile.ParseDenoLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "chalk",
+			Version:        "5.3.0",
+			PackageManager: models.Deno,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 8, End: 11},
+				Column:   models.Position{Start: 7, End: 8},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseDenoLock_NullPackage(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseDenoLock("fixtures/deno/null-package.v3.json")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "chalk",
+			Version:        "5.3.0",
+			PackageManager: models.Deno,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+		},
+	})
+}
+
+func TestParseDenoLock_ScopedPackages(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/deno/scoped-packages.v3.json"))
+	packages, err := lockfile.ParseDenoLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "@babel/core",
+			Version:        "7.23.0",
+			PackageManager: models.Deno,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 10, End: 13},
+				Column:   models.Position{Start: 7, End: 8},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "@types/node",
+			Version:        "18.16.19",
+			PackageManager: models.Deno,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 14, End: 17},
+				Column:   models.Position{Start: 7, End: 8},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "preact-render-to-string",
+			Version:        "6.2.1",
+			PackageManager: models.Deno,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 18, End: 23},
+				Column:   models.Position{Start: 7, End: 8},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "preact",
+			Version:        "10.18.1",
+			PackageManager: models.Deno,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 24, End: 27},
+				Column:   models.Position{Start: 7, End: 8},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseDenoLock_V4(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/deno/two-packages.v4.json"))
+	packages, err := lockfile.ParseDenoLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "@types/node",
+			Version:        "22.5.4",
+			PackageManager: models.Deno,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 14, End: 19},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "chalk",
+			Version:        "5.3.0",
+			PackageManager: models.Deno,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 20, End: 22},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+	})
+}
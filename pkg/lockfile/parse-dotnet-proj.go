@@ -0,0 +1,344 @@
+package lockfile
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/cachedregexp"
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/internal/utility/filereader"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// dotNetProjExtensions are the extensions of the project files of SDK-style
+// projects, which are named after the project, such as "MyApp.csproj"
+var dotNetProjExtensions = []string{".csproj", ".fsproj", ".vbproj"}
+
+// DotNetProjPackageReference is a package that a project references, whose
+// version can be given either as an attribute or as a child element
+type DotNetProjPackageReference struct {
+	Include        string `xml:"Include,attr"`
+	VersionAttr    string `xml:"Version,attr"`
+	VersionElement string `xml:"Version"`
+	models.FilePosition
+}
+
+func (ref DotNetProjPackageReference) version() string {
+	if ref.VersionAttr != "" {
+		return ref.VersionAttr
+	}
+
+	return strings.TrimSpace(ref.VersionElement)
+}
+
+// DotNetProjProperty is a property that is defined in a property group of a project,
+// which can be used by other values in the project like "$(SomeVersion)"
+type DotNetProjProperty struct {
+	Value string
+	models.FilePosition
+}
+
+type DotNetProjFile struct {
+	PackageReferences []DotNetProjPackageReference
+	// Properties are keyed by their name, with the last definition of a property
+	// being the one that is used, as it is the one that MSBuild would evaluate to
+	Properties map[string]DotNetProjProperty
+}
+
+func (file *DotNetProjFile) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	if start.Name.Local != "Project" {
+		return fmt.Errorf("expected element type <Project> but have <%s>", start.Name.Local)
+	}
+
+	file.PackageReferences = make([]DotNetProjPackageReference, 0)
+	file.Properties = make(map[string]DotNetProjProperty)
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "PropertyGroup":
+				err = file.unmarshalPropertyGroup(decoder, elem)
+			case "ItemGroup":
+				err = file.unmarshalItemGroup(decoder, elem)
+			default:
+				err = decoder.Skip()
+			}
+
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if elem.Name == start.Name {
+				return nil
+			}
+		}
+	}
+}
+
+func (file *DotNetProjFile) unmarshalPropertyGroup(decoder *xml.Decoder, start xml.StartElement) error {
+	for {
+		lineStart, columnStart := decoder.InputPos()
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			property := DotNetProjProperty{}
+			property.SetLineStart(lineStart)
+			property.SetColumnStart(columnStart)
+
+			if err := decoder.DecodeElement(&property.Value, &elem); err != nil {
+				return err
+			}
+
+			lineEnd, columnEnd := decoder.InputPos()
+			property.SetLineEnd(lineEnd)
+			property.SetColumnEnd(columnEnd)
+			property.Value = strings.TrimSpace(property.Value)
+			file.Properties[elem.Name.Local] = property
+		case xml.EndElement:
+			if elem.Name == start.Name {
+				return nil
+			}
+		}
+	}
+}
+
+func (file *DotNetProjFile) unmarshalItemGroup(decoder *xml.Decoder, start xml.StartElement) error {
+	for {
+		lineStart, columnStart := decoder.InputPos()
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			// other items such as project references and files do not declare packages
+			if elem.Name.Local != "PackageReference" {
+				if err := decoder.Skip(); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			ref := DotNetProjPackageReference{}
+			ref.SetLineStart(lineStart)
+			ref.SetColumnStart(columnStart)
+
+			if err := decoder.DecodeElement(&ref, &elem); err != nil {
+				return err
+			}
+
+			lineEnd, columnEnd := decoder.InputPos()
+			ref.SetLineEnd(lineEnd)
+			ref.SetColumnEnd(columnEnd)
+			file.PackageReferences = append(file.PackageReferences, ref)
+		case xml.EndElement:
+			if elem.Name == start.Name {
+				return nil
+			}
+		}
+	}
+}
+
+// resolveProperties replaces the properties used by the given value with their values,
+// returning the name of the first property that could not be resolved if there is one,
+// such as those which are not defined in the project or that are defined in terms of
+// themselves, in which case the value should not be used
+func (file *DotNetProjFile) resolveProperties(value string, seen []string) (string, string) {
+	var unresolved string
+
+	resolved := cachedregexp.MustCompile(`\$\(([^)]+)\)`).ReplaceAllStringFunc(value, func(match string) string {
+		name := match[2 : len(match)-1]
+		property, ok := file.Properties[name]
+
+		if !ok || slices.Contains(seen, name) {
+			if unresolved == "" {
+				unresolved = name
+			}
+
+			return ""
+		}
+
+		nested, nestedUnresolved := file.resolveProperties(property.Value, append(seen, name))
+
+		if unresolved == "" {
+			unresolved = nestedUnresolved
+		}
+
+		return nested
+	})
+
+	return resolved, unresolved
+}
+
+// xmlElementTextPosition returns the position of the text of the given element in the
+// lines of a block, or nil if the element does not have the text on a single line
+func xmlElementTextPosition(block []string, blockStartLine int, element string, value string, path string) *models.FilePosition {
+	if value == "" {
+		return nil
+	}
+
+	re := cachedregexp.MustCompile(
+		`<` + cachedregexp.QuoteMeta(element) + `(?:\s[^>]*)?>\s*(` + cachedregexp.QuoteMeta(value) + `)\s*</` + cachedregexp.QuoteMeta(element) + `\s*>`,
+	)
+
+	for i, line := range block {
+		match := re.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+
+		return &models.FilePosition{
+			Line: models.Position{Start: blockStartLine + i, End: blockStartLine + i},
+			Column: models.Position{
+				Start: fileposition.ColumnOfByteIndex(line, match[2]),
+				End:   fileposition.ColumnOfByteIndex(line, match[3]),
+			},
+			Filename: path,
+		}
+	}
+
+	return nil
+}
+
+// DotNetProjExtractor extracts the packages referenced by SDK-style project files,
+// which are those with any of the extensions of such files unless Extension is set.
+//
+// Nothing is extracted if there is a packages.lock.json next to the project, which
+// is still used by the matcher of the lockfile to enrich the packages of it.
+type DotNetProjExtractor struct {
+	Extension string
+}
+
+func (e DotNetProjExtractor) ShouldExtract(path string) bool {
+	ext := filepath.Ext(path)
+
+	// files that are only an extension, like ".csproj", are not projects
+	if ext == filepath.Base(path) {
+		return false
+	}
+
+	if e.Extension != "" {
+		return ext == e.Extension
+	}
+
+	return slices.Contains(dotNetProjExtensions, ext)
+}
+
+func (e DotNetProjExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	if hasSiblingLockfile(f, "packages.lock.json") {
+		return []PackageDetails{}, nil
+	}
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	var parsedLockfile *DotNetProjFile
+
+	decoder := xml.NewDecoder(bytes.NewReader(b))
+	decoder.CharsetReader = filereader.CharsetDecoder
+
+	if err := decoder.Decode(&parsedLockfile); err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+	}
+
+	lines := fileposition.BytesToLines(b)
+	packages := make([]PackageDetails, 0, len(parsedLockfile.PackageReferences))
+
+	for _, ref := range parsedLockfile.PackageReferences {
+		// references that only update the metadata of a package have no Include
+		if ref.Include == "" {
+			continue
+		}
+
+		block := lines[ref.Line.Start-1 : ref.Line.End]
+		version := ref.version()
+
+		var versionLocation *models.FilePosition
+
+		switch {
+		case strings.Contains(version, "$("):
+			resolved, unresolved := parsedLockfile.resolveProperties(version, nil)
+
+			if unresolved != "" {
+				logWarningf(
+					"could not resolve the MSBuild property %s used by the version of %s in %s, defaulting to no version\n",
+					unresolved,
+					ref.Include,
+					f.Path(),
+				)
+
+				version = ""
+
+				break
+			}
+
+			// the version can only be pointed to when it is all from a single property
+			name := strings.TrimSuffix(strings.TrimPrefix(version, "$("), ")")
+			if property, ok := parsedLockfile.Properties[name]; ok && "$("+name+")" == version && property.Value == resolved {
+				versionLocation = xmlElementTextPosition(
+					lines[property.Line.Start-1:property.Line.End],
+					property.Line.Start,
+					name,
+					resolved,
+					f.Path(),
+				)
+			}
+
+			version = resolved
+		case ref.VersionAttr != "":
+			versionLocation = xmlAttributePosition(block, ref.Line.Start, "Version", version, f.Path())
+		default:
+			versionLocation = xmlElementTextPosition(block, ref.Line.Start, "Version", version, f.Path())
+		}
+
+		packages = append(packages, PackageDetails{
+			Name:    ref.Include,
+			Version: version,
+			BlockLocation: models.FilePosition{
+				Line:     ref.Line,
+				Column:   ref.Column,
+				Filename: f.Path(),
+			},
+			NameLocation:    xmlAttributePosition(block, ref.Line.Start, "Include", ref.Include, f.Path()),
+			VersionLocation: versionLocation,
+			PackageManager:  models.NuGet,
+			Ecosystem:       NuGetEcosystem,
+			CompareAs:       NuGetEcosystem,
+			IsDirect:        true,
+		})
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = DotNetProjExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	for _, ext := range dotNetProjExtensions {
+		registerExtractor(strings.TrimPrefix(ext, "."), NuGetEcosystem, DotNetProjExtractor{Extension: ext})
+	}
+}
+
+func ParseDotNetProj(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, DotNetProjExtractor{})
+}
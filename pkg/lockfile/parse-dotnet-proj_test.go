@@ -0,0 +1,373 @@
+package lockfile_test
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestDotNetProjExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		extension string
+		path      string
+		want      bool
+	}{
+		{
+			name:      "",
+			extension: "",
+			path:      "",
+			want:      false,
+		},
+		{
+			name:      "",
+			extension: "",
+			path:      "MyApp.csproj",
+			want:      true,
+		},
+		{
+			name:      "",
+			extension: "",
+			path:      "path/to/my/MyApp.csproj",
+			want:      true,
+		},
+		{
+			name:      "",
+			extension: "",
+			path:      "path/to/my/MyApp.fsproj",
+			want:      true,
+		},
+		{
+			name:      "",
+			extension: "",
+			path:      "path/to/my/MyApp.vbproj",
+			want:      true,
+		},
+		{
+			name:      "",
+			extension: "",
+			path:      "path/to/my/.csproj",
+			want:      false,
+		},
+		{
+			name:      "",
+			extension: "",
+			path:      "path/to/my/MyApp.csproj/file",
+			want:      false,
+		},
+		{
+			name:      "",
+			extension: "",
+			path:      "path/to/my/MyApp.csproj.file",
+			want:      false,
+		},
+		{
+			name:      "",
+			extension: "",
+			path:      "path/to/my/MyApp.vcxproj",
+			want:      false,
+		},
+		{
+			name:      "only csproj files",
+			extension: ".csproj",
+			path:      "path/to/my/MyApp.csproj",
+			want:      true,
+		},
+		{
+			name:      "only csproj files",
+			extension: ".csproj",
+			path:      "path/to/my/MyApp.fsproj",
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.DotNetProjExtractor{Extension: tt.extension}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDotNetProj_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseDotNetProj("fixtures/dotnet-proj/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseDotNetProj_Invalid(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseDotNetProj("fixtures/dotnet-proj/not-xml.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseDotNetProj_NotProject(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseDotNetProj("fixtures/nuget-packages-config/one-package.config")
+
+	expectErrContaining(t, err, "expected element type <Project> but have <packages>")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseDotNetProj_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseDotNetProj("fixtures/dotnet-proj/empty.csproj")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseDotNetProj_OnePackage(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/dotnet-proj/one-package.csproj"))
+	packages, err := lockfile.ParseDotNetProj(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "Newtonsoft.Json",
+			Version:        "13.0.3",
+			PackageManager: models.NuGet,
+			Ecosystem:      lockfile.NuGetEcosystem,
+			CompareAs:      lockfile.NuGetEcosystem,
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 5, End: 68},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 32, End: 47},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 58, End: 64},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseDotNetProj_MultiplePackages(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/dotnet-proj/multiple-packages.fsproj"))
+	packages, err := lockfile.ParseDotNetProj(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "FSharp.Core",
+			Version:        "8.0.200",
+			PackageManager: models.NuGet,
+			Ecosystem:      lockfile.NuGetEcosystem,
+			CompareAs:      lockfile.NuGetEcosystem,
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 12, End: 12},
+				Column:   models.Position{Start: 5, End: 65},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 12, End: 12},
+				Column:   models.Position{Start: 32, End: 43},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 12, End: 12},
+				Column:   models.Position{Start: 54, End: 61},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "Serilog",
+			Version:        "3.1.1",
+			PackageManager: models.NuGet,
+			Ecosystem:      lockfile.NuGetEcosystem,
+			CompareAs:      lockfile.NuGetEcosystem,
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 13, End: 13},
+				Column:   models.Position{Start: 5, End: 71},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 13, End: 13},
+				Column:   models.Position{Start: 32, End: 39},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 21, End: 26},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "NUnit",
+			Version:        "3.14.0",
+			PackageManager: models.NuGet,
+			Ecosystem:      lockfile.NuGetEcosystem,
+			CompareAs:      lockfile.NuGetEcosystem,
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 14, End: 16},
+				Column:   models.Position{Start: 5, End: 24},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 14, End: 14},
+				Column:   models.Position{Start: 32, End: 37},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 15, End: 15},
+				Column:   models.Position{Start: 16, End: 22},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseDotNetProj_UnresolvedProperty(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/dotnet-proj/unresolved-property.csproj"))
+	packages, err := lockfile.ParseDotNetProj(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "Newtonsoft.Json",
+			Version:        "",
+			PackageManager: models.NuGet,
+			Ecosystem:      lockfile.NuGetEcosystem,
+			CompareAs:      lockfile.NuGetEcosystem,
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 5, End: 82},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 32, End: 47},
+				Filename: path,
+			},
+		},
+	})
+}
+
+//nolint:paralleltest
+func TestParseDotNetProj_UnresolvedProperty_LogsWarning(t *testing.T) {
+	var buffer bytes.Buffer
+
+	lockfile.SetLogWriter(&buffer)
+	t.Cleanup(func() { lockfile.SetLogWriter(nil) })
+
+	path, _ := filepath.Abs("fixtures/dotnet-proj/unresolved-property.csproj")
+
+	_, err := lockfile.ParseDotNetProj(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expected := "could not resolve the MSBuild property NewtonsoftVersion used by the version of Newtonsoft.Json in " + path + ", defaulting to no version\n"
+
+	if buffer.String() != expected {
+		t.Errorf("Expected warning %q to be logged, but got %q", expected, buffer.String())
+	}
+}
+
+func TestParseDotNetProj_WithLockfile(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseDotNetProj("fixtures/dotnet-proj/with-lockfile/App.csproj")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseDotNetProj_WithLockfile_ExtractAllFromDir(t *testing.T) {
+	t.Parallel()
+
+	root, err := filepath.Abs("fixtures/dotnet-proj/with-lockfile")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	sources, err := lockfile.ExtractAllFromDir(root)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	// the packages should only be reported by the lockfile, rather than by both
+	count := 0
+
+	for _, source := range sources {
+		for _, pkg := range source.Packages {
+			if pkg.Package.Name != "Newtonsoft.Json" {
+				continue
+			}
+
+			count++
+
+			if source.Source.Path != filepath.Join(root, "packages.lock.json") {
+				t.Errorf("Expected Newtonsoft.Json to be from packages.lock.json but got %s", source.Source.Path)
+			}
+		}
+	}
+
+	if count != 1 {
+		t.Errorf("Expected Newtonsoft.Json to be reported once but got %d times", count)
+	}
+}
@@ -192,7 +192,7 @@ var GemfileExtractor = GemfileLockExtractor{
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("Gemfile.lock", GemfileExtractor)
+	registerExtractor("Gemfile.lock", BundlerEcosystem, GemfileExtractor)
 }
 
 func ParseGemfileLock(pathToLockfile string) ([]PackageDetails, error) {
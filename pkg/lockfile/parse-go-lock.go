@@ -1,10 +1,11 @@
 package lockfile
 
 import (
+	"errors"
 	"fmt"
 	"io"
-	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/google/osv-scanner/internal/cachedregexp"
@@ -26,12 +27,17 @@ func deduplicatePackages(packages map[string]PackageDetails) map[string]PackageD
 	details := map[string]PackageDetails{}
 
 	for _, detail := range packages {
-		details[detail.Name+"@"+detail.Version] = detail
+		details[detail.Key()] = detail
 	}
 
 	return details
 }
 
+// GoLockExtractor extracts the packages required by a go.mod, or those that are
+// listed in its vendor/modules.txt when the dependencies of the module are vendored.
+//
+// When there is a go.sum next to the go.mod, it is used to check which of the
+// requires are actually direct dependencies, rather than only their comments.
 type GoLockExtractor struct{}
 
 func defaultNonCanonicalVersions(path, version string) (string, error) {
@@ -46,14 +52,57 @@ func defaultNonCanonicalVersions(path, version string) (string, error) {
 	}
 
 	if resolvedVersion == "" {
-		// If it is still not resolved, we default on 0.0.0 as we do with other package managers
-		_, _ = fmt.Fprintf(os.Stderr, "%s@%s is not a canonical path, defaulting to %s\n", path, resolvedVersion, unknownVersion)
+		// If it is still not resolved, we default on 0.0.0 as we do with other package managers,
+		// with what is done with the package being decided by resolveGoVersion
 		return unknownVersion, nil
 	}
 
 	return resolvedVersion, nil
 }
 
+// resolveGoVersion returns the version that a package required or replaced by the
+// directive on the given line should have, and whether it should be kept at all,
+// based on what the given options say to do with versions that could not be resolved
+func resolveGoVersion(path string, version string, line string, opts ExtractOptions) (string, bool) {
+	if version != unknownVersion {
+		return strings.TrimPrefix(version, "v"), true
+	}
+
+	// the version as written is the last field of the directive, as it has been
+	// replaced with the unresolved version in what modfile parsed
+	line, _, _ = strings.Cut(line, "//")
+	fields := strings.Fields(line)
+	raw := fields[len(fields)-1]
+
+	switch opts.OnUnresolvedVersion {
+	case UnresolvedVersionSkip:
+		logWarningf("%s@%s is not a canonical path, skipping it\n", path, raw)
+
+		return "", false
+	case UnresolvedVersionKeepRaw:
+		return raw, true
+	}
+
+	logWarningf("%s@%s is not a canonical path, defaulting to %s\n", path, raw, unknownVersion)
+
+	return "", true
+}
+
+// extractPseudoVersionCommit returns the revision embedded in a Go pseudo-version,
+// or an empty string if the version is not a pseudo-version.
+func extractPseudoVersionCommit(version string) string {
+	if !module.IsPseudoVersion(version) {
+		return ""
+	}
+
+	rev, err := module.PseudoVersionRev(version)
+	if err != nil {
+		return ""
+	}
+
+	return rev
+}
+
 func extractLocations(block []string, start modfile.Position, end modfile.Position, path string, name string, version string) (models.FilePosition, *models.FilePosition, *models.FilePosition) {
 	blockLocation := models.FilePosition{
 		Line:     models.Position{Start: start.Line, End: end.Line},
@@ -74,24 +123,295 @@ func extractLocations(block []string, start modfile.Position, end modfile.Positi
 	return blockLocation, nameLocation, versionLocation
 }
 
+// newModfileParseError returns a ParseError for an error from parsing a go.mod,
+// using the position of the first problem that the parser reported one for
+func newModfileParseError(path string, err error) *ParseError {
+	parseErr := &ParseError{Path: path, Err: err}
+
+	var errs modfile.ErrorList
+	if errors.As(err, &errs) {
+		for _, e := range errs {
+			if e.Pos.Line > 0 {
+				parseErr.Line = e.Pos.Line
+				parseErr.Column = e.Pos.LineRune
+
+				break
+			}
+		}
+	}
+
+	return parseErr
+}
+
 func (e GoLockExtractor) ShouldExtract(path string) bool {
 	return filepath.Base(path) == "go.mod"
 }
 
-func (e GoLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
-	var parsedLockfile *modfile.File
+func (e GoLockExtractor) SupportedDepGroups() []string {
+	return []string{"tool"}
+}
 
+// parseGoModFile parses the go.mod being extracted, returning its lines
+// alongside it so that the locations of its directives can be determined.
+//
+// Malformed require, exclude and replace directives are skipped so that the
+// other directives can still be read, with an EntryError being returned for each
+func parseGoModFile(f DepFile) (*modfile.File, []string, []error, error) {
 	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
 	lines := fileposition.BytesToLines(b)
 
+	// tool directives are blanked out as they are not supported by the version of modfile
+	// being used, keeping the lines so that the positions of the other directives are the same
+	blanked := slices.Clone(lines)
+	_, toolLines := parseGoToolDirectives(lines)
+
+	for _, i := range toolLines {
+		blanked[i] = ""
+	}
+
+	parsedLockfile, err := modfile.Parse(f.Path(), []byte(strings.Join(blanked, "\n")), defaultNonCanonicalVersions)
 	if err == nil {
-		parsedLockfile, err = modfile.Parse(f.Path(), b, defaultNonCanonicalVersions)
+		return parsedLockfile, lines, nil, nil
 	}
 
-	if err != nil {
-		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+	// directives from newer versions of Go are blanked out too, as they are not
+	// needed to extract the packages, rather than the whole go.mod failing to parse;
+	// this is only done if what remains still declares a module so that files which
+	// are not a go.mod at all are not mistaken for one full of unknown directives
+	if hasGoModUnknownDirectiveErrors(err) {
+		directives, directiveLines := parseGoModUnknownDirectives(lines)
+
+		for _, i := range directiveLines {
+			blanked[i] = ""
+		}
+
+		reparsed, reparseErr := modfile.Parse(f.Path(), []byte(strings.Join(blanked, "\n")), defaultNonCanonicalVersions)
+
+		if reparseErr == nil && reparsed.Module != nil {
+			starts := maps.Keys(directives)
+			slices.Sort(starts)
+
+			for _, i := range starts {
+				logWarningf("%s:%d: ignoring unknown directive: %s\n", f.Path(), i+1, directives[i])
+			}
+
+			return reparsed, lines, nil, nil
+		}
+	}
+
+	entryErrs, entryLines, ok := goModEntryErrors(f.Path(), lines, err)
+	if !ok {
+		return nil, nil, nil, newModfileParseError(f.Path(), err)
+	}
+
+	for _, i := range entryLines {
+		blanked[i] = ""
 	}
 
+	parsedLockfile, reparseErr := modfile.Parse(f.Path(), []byte(strings.Join(blanked, "\n")), defaultNonCanonicalVersions)
+	if reparseErr != nil {
+		return nil, nil, nil, newModfileParseError(f.Path(), err)
+	}
+
+	return parsedLockfile, lines, entryErrs, nil
+}
+
+// goModKnownDirectives are the directives that the version of modfile being used
+// supports, besides tool directives which are handled separately
+var goModKnownDirectives = []string{
+	"module", "go", "toolchain", "godebug", "require", "exclude", "replace", "retract", "tool",
+}
+
+// hasGoModUnknownDirectiveErrors checks if any of the errors from parsing a go.mod
+// are for a directive or block of directives that modfile does not support
+func hasGoModUnknownDirectiveErrors(err error) bool {
+	var errs modfile.ErrorList
+	if !errors.As(err, &errs) {
+		return false
+	}
+
+	return slices.ContainsFunc(errs, func(e modfile.Error) bool {
+		msg := e.Err.Error()
+
+		return strings.HasPrefix(msg, "unknown directive: ") || strings.HasPrefix(msg, "unknown block type: ")
+	})
+}
+
+// parseGoModUnknownDirectives returns the directives in the given lines of a go.mod
+// that are not supported by the version of modfile being used, keyed by the index of
+// the line they start on, along with the indexes of all the lines that they are on
+func parseGoModUnknownDirectives(lines []string) (map[int]string, []int) {
+	directives := map[int]string{}
+	var directiveLines []int
+
+	inKnownBlock, inUnknownBlock := false, false
+
+	for i, line := range lines {
+		line, _, _ = strings.Cut(line, "//")
+		fields := strings.Fields(line)
+
+		if inKnownBlock || inUnknownBlock {
+			if inUnknownBlock {
+				directiveLines = append(directiveLines, i)
+			}
+
+			if len(fields) == 1 && fields[0] == ")" {
+				inKnownBlock, inUnknownBlock = false, false
+			}
+
+			continue
+		}
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		verb, opensBlock := strings.CutSuffix(fields[0], "(")
+		opensBlock = opensBlock || (len(fields) > 1 && fields[1] == "(")
+
+		if slices.Contains(goModKnownDirectives, verb) {
+			inKnownBlock = opensBlock
+
+			continue
+		}
+
+		directives[i] = verb
+		directiveLines = append(directiveLines, i)
+		inUnknownBlock = opensBlock
+	}
+
+	return directives, directiveLines
+}
+
+// goModEntryLines returns the directives of the require, exclude and replace
+// directives in the given lines of a go.mod, keyed by the index of their line
+func goModEntryLines(lines []string) map[int]string {
+	entries := map[int]string{}
+	block := ""
+
+	for i, line := range lines {
+		line, _, _ = strings.Cut(line, "//")
+		fields := strings.Fields(line)
+
+		switch {
+		case len(fields) == 0:
+			continue
+		case block != "":
+			if len(fields) == 1 && fields[0] == ")" {
+				block = ""
+			} else {
+				entries[i] = fields[0]
+			}
+		case fields[0] != "require" && fields[0] != "exclude" && fields[0] != "replace":
+			continue
+		case len(fields) > 1 && fields[1] == "(":
+			block = fields[0]
+		case len(fields) > 1:
+			entries[i] = fields[1]
+		}
+	}
+
+	return entries
+}
+
+// goModEntryErrors returns an EntryError for each of the errors from parsing a go.mod, along
+// with the indexes of the lines that they are for, if they are all for require, exclude and
+// replace directives, which can be skipped without affecting the rest of the go.mod
+func goModEntryErrors(path string, lines []string, err error) ([]error, []int, bool) {
+	var errs modfile.ErrorList
+	if !errors.As(err, &errs) {
+		return nil, nil, false
+	}
+
+	entries := goModEntryLines(lines)
+	entryErrs := make([]error, 0, len(errs))
+	entryLines := make([]int, 0, len(errs))
+
+	for _, e := range errs {
+		entry, ok := entries[e.Pos.Line-1]
+		if !ok {
+			return nil, nil, false
+		}
+
+		entryErrs = append(entryErrs, &EntryError{
+			Path:  path,
+			Entry: strings.Trim(entry, `"`),
+			Line:  e.Pos.Line,
+			Err:   e.Err,
+		})
+		entryLines = append(entryLines, e.Pos.Line-1)
+	}
+
+	return entryErrs, entryLines, true
+}
+
+// parseGoToolDirectives returns the packages of the tool directives in the given
+// lines of a go.mod, which were added in Go 1.24, like "tool golang.org/x/tools/cmd/stringer"
+// or a block of them, along with the indexes of the lines that the directives are on
+func parseGoToolDirectives(lines []string) ([]string, []int) {
+	var tools []string
+	var toolLines []int
+
+	inBlock := false
+
+	for i, line := range lines {
+		line, _, _ = strings.Cut(line, "//")
+		fields := strings.Fields(line)
+
+		switch {
+		case inBlock:
+			toolLines = append(toolLines, i)
+
+			if len(fields) == 1 && fields[0] == ")" {
+				inBlock = false
+			} else if len(fields) > 0 {
+				tools = append(tools, strings.Trim(fields[0], `"`))
+			}
+		case len(fields) > 0 && (fields[0] == "tool(" || (fields[0] == "tool" && len(fields) > 1 && fields[1] == "(")):
+			toolLines = append(toolLines, i)
+			inBlock = true
+		case len(fields) > 1 && fields[0] == "tool":
+			toolLines = append(toolLines, i)
+			tools = append(tools, strings.Trim(fields[1], `"`))
+		}
+	}
+
+	return tools, toolLines
+}
+
+// applyGoToolDirectives adds the packages that provide the given tools to the "tool" group,
+// which are the required modules with the longest path that the package of the tool is in
+func applyGoToolDirectives(packages map[string]PackageDetails, tools []string) {
+	for _, tool := range tools {
+		module := ""
+
+		for _, pkg := range packages {
+			if (tool == pkg.Name || strings.HasPrefix(tool, pkg.Name+"/")) && len(pkg.Name) > len(module) {
+				module = pkg.Name
+			}
+		}
+
+		// the tool is provided by the main module itself
+		if module == "" {
+			continue
+		}
+
+		for key, pkg := range packages {
+			if pkg.Name == module && !slices.Contains(pkg.DepGroups, "tool") {
+				pkg.DepGroups = append(pkg.DepGroups, "tool")
+				packages[key] = pkg
+			}
+		}
+	}
+}
+
+// extractGoModRequires returns the packages required by the go.mod, keyed by
+// their path and version, excluding those that are excluded by the go.mod
+func extractGoModRequires(parsedLockfile *modfile.File, lines []string, path string, opts ExtractOptions) map[string]PackageDetails {
 	packages := map[string]PackageDetails{}
 
 	for _, require := range parsedLockfile.Require {
@@ -99,16 +419,17 @@ func (e GoLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 		var end = require.Syntax.End
 		block := lines[start.Line-1 : end.Line]
 		name := require.Mod.Path
-		version := strings.TrimPrefix(require.Mod.Version, "v")
 
-		if require.Mod.Version == unknownVersion {
-			version = ""
+		version, ok := resolveGoVersion(name, require.Mod.Version, lines[start.Line-1], opts)
+		if !ok {
+			continue
 		}
 
-		blockLocation, nameLocation, versionLocation := extractLocations(block, start, end, f.Path(), name, version)
+		blockLocation, nameLocation, versionLocation := extractLocations(block, start, end, path, name, version)
 		packages[require.Mod.Path+"@"+require.Mod.Version] = PackageDetails{
 			Name:            name,
 			Version:         version,
+			Commit:          extractPseudoVersionCommit(require.Mod.Version),
 			PackageManager:  models.Golang,
 			Ecosystem:       GoEcosystem,
 			CompareAs:       GoEcosystem,
@@ -116,95 +437,377 @@ func (e GoLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 			NameLocation:    nameLocation,
 			VersionLocation: versionLocation,
 			IsDirect:        !require.Indirect,
+			Transitive:      require.Indirect,
+			Origin:          "require",
 		}
 	}
 
-	for _, replace := range parsedLockfile.Replace {
-		var start = replace.Syntax.Start
-		var end = replace.Syntax.End
-		block := lines[start.Line-1 : end.Line]
-		var replacements []string
+	// excluded versions are never selected, even if they're required by another module
+	for _, exclude := range parsedLockfile.Exclude {
+		delete(packages, exclude.Mod.Path+"@"+exclude.Mod.Version)
+	}
 
-		isLocalFile := !hasHostnamePrefix(replace.New.Path)
+	tools, _ := parseGoToolDirectives(lines)
+	applyGoToolDirectives(packages, tools)
 
+	// retract directives are not read, as they concern the versions of the module
+	// itself that consumers should avoid rather than any of its dependencies
+
+	return packages
+}
+
+// applyGoReplaces replaces the packages that are replaced by the given replace
+// directives, which are from the file at the given path with the given lines,
+// returning the directives that replaced at least one of the packages
+func applyGoReplaces(packages map[string]PackageDetails, replaces []*modfile.Replace, lines []string, path string, opts ExtractOptions) []*modfile.Replace {
+	// Which replace applies to each package is worked out before any are replaced, so that
+	// the packages that replacements result in are never replaced themselves, and so that
+	// replacing a specific version takes precedence over replacing all versions regardless
+	// of the order that the replaces are in
+	targets := map[string]*modfile.Replace{}
+
+	for _, replace := range replaces {
+		// If the left version is omitted, all versions of the module are replaced.
 		if replace.Old.Version == "" {
-			// If the left version is omitted, all versions of the module are replaced.
 			for k, pkg := range packages {
 				if pkg.Name == replace.Old.Path {
-					replacements = append(replacements, k)
+					targets[k] = replace
 				}
 			}
-		} else {
-			// If a version is present on the left side of the arrow (=>),
-			// only that specific version of the module is replaced
+		}
+	}
+
+	for _, replace := range replaces {
+		// If a version is present on the left side of the arrow (=>),
+		// only that specific version of the module is replaced
+		if replace.Old.Version != "" {
 			s := replace.Old.Path + "@" + replace.Old.Version
 
 			// A `replace` directive has no effect if the module version on the left side is not required.
 			if _, ok := packages[s]; ok {
-				replacements = []string{s}
+				targets[s] = replace
 			}
 		}
+	}
 
-		for _, replacement := range replacements {
-			version := strings.TrimPrefix(replace.New.Version, "v")
-			name := replace.New.Path
+	for replacement, replace := range targets {
+		var start = replace.Syntax.Start
+		var end = replace.Syntax.End
+		block := lines[start.Line-1 : end.Line]
 
-			if replace.New.Version == unknownVersion {
-				version = ""
-			}
+		isLocalFile := !hasHostnamePrefix(replace.New.Path)
 
-			blockLocation, nameLocation, versionLocation := extractLocations(block, start, end, f.Path(), name, version)
+		commit := extractPseudoVersionCommit(replace.New.Version)
+		name := replace.New.Path
 
-			if isLocalFile {
-				// The replacement is a local file path, we keep the original package name and drop everything specific to the replacement
-				name = replace.Old.Path
-				version = ""
-				versionLocation = nil
-				nameLocation = nil
-			}
+		version, ok := resolveGoVersion(name, replace.New.Version, lines[start.Line-1], opts)
+		if !ok {
+			delete(packages, replacement)
+
+			continue
+		}
+
+		blockLocation, nameLocation, versionLocation := extractLocations(block, start, end, path, name, version)
+
+		if isLocalFile {
+			// The replacement is a local file path, we keep the original package name and drop everything specific to the replacement
+			name = replace.Old.Path
+			version = ""
+			commit = ""
+			versionLocation = nil
+			nameLocation = nil
+		}
 
-			packages[replacement] = PackageDetails{
-				Name:            name,
-				Version:         version,
-				PackageManager:  models.Golang,
-				Ecosystem:       GoEcosystem,
-				CompareAs:       GoEcosystem,
-				BlockLocation:   blockLocation,
-				VersionLocation: versionLocation,
-				NameLocation:    nameLocation,
-				IsDirect:        packages[replacement].IsDirect,
+		packages[replacement] = PackageDetails{
+			Name:            name,
+			Version:         version,
+			Commit:          commit,
+			PackageManager:  models.Golang,
+			Ecosystem:       GoEcosystem,
+			CompareAs:       GoEcosystem,
+			BlockLocation:   blockLocation,
+			VersionLocation: versionLocation,
+			NameLocation:    nameLocation,
+			DepGroups:       packages[replacement].DepGroups,
+			IsDirect:        packages[replacement].IsDirect,
+			Transitive:      packages[replacement].Transitive,
+			Origin:          "replace",
+		}
+	}
+
+	applied := make([]*modfile.Replace, 0, len(targets))
+
+	for _, replace := range replaces {
+		for _, target := range targets {
+			if target == replace {
+				applied = append(applied, replace)
+
+				break
 			}
 		}
 	}
 
-	if parsedLockfile.Go != nil && parsedLockfile.Go.Version != "" {
-		packages["stdlib"] = PackageDetails{
-			Name:           "stdlib",
-			Version:        parsedLockfile.Go.Version,
-			PackageManager: models.Golang,
-			Ecosystem:      GoEcosystem,
-			CompareAs:      GoEcosystem,
-			BlockLocation: models.FilePosition{
-				Filename: f.Path(),
-			},
-			IsDirect: true,
+	return applied
+}
+
+// newGoStdlibPackage returns the package for the standard library of the
+// version of Go that is required by the file at the given path
+func newGoStdlibPackage(version string, path string) PackageDetails {
+	return PackageDetails{
+		Name:           "stdlib",
+		Version:        version,
+		PackageManager: models.Golang,
+		Ecosystem:      GoEcosystem,
+		CompareAs:      GoEcosystem,
+		BlockLocation: models.FilePosition{
+			Filename: path,
+		},
+		IsDirect: true,
+		Origin:   "go",
+	}
+}
+
+// collapseGoIncompatibleVersions sets the version that packages whose version has the
+// "+incompatible" suffix are compared with to their version without it
+func collapseGoIncompatibleVersions(packages map[string]PackageDetails) {
+	for key, pkg := range packages {
+		if version, ok := strings.CutSuffix(pkg.Version, "+incompatible"); ok {
+			pkg.CompareVersion = version
+			packages[key] = pkg
 		}
 	}
+}
 
-	return maps.Values(deduplicatePackages(packages)), nil
+func (e GoLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	return e.ExtractWithOptions(f, ExtractOptions{})
+}
+
+func (e GoLockExtractor) ExtractWithOptions(f DepFile, opts ExtractOptions) ([]PackageDetails, error) {
+	info, err := extractGoModInfo(f, opts)
+	if info == nil {
+		return []PackageDetails{}, err
+	}
+
+	return info.Packages, err
+}
+
+// extractGoModInfo extracts the packages of the go.mod along with what else it
+// declares, returning a nil GoModInfo if the go.mod could not be extracted at all
+func extractGoModInfo(f DepFile, opts ExtractOptions) (*GoModInfo, error) {
+	parsedLockfile, lines, entryErrs, err := parseGoModFile(f)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := extractGoModRequires(parsedLockfile, lines, f.Path(), opts)
+
+	// the go.sum only refines which requires are indirect, so the go.mod is still
+	// extracted as it is written if the go.sum cannot be read
+	if sumFile, err := f.Open("go.sum"); err == nil {
+		goSum, err := parseGoSum(sumFile)
+		sumFile.Close()
+
+		if err != nil {
+			logWarningf("%s, so the indirect comments of %s are used as they are\n", err, f.Path())
+		} else {
+			reconcileGoIndirectComments(packages, parsedLockfile, goSum, f.Path())
+		}
+	}
+
+	replaces := applyGoReplaces(packages, parsedLockfile.Replace, lines, f.Path(), opts)
+
+	if parsedLockfile.Go != nil && parsedLockfile.Go.Version != "" && !opts.ExcludeStdlib {
+		packages["stdlib"] = newGoStdlibPackage(parsedLockfile.Go.Version, f.Path())
+	}
+
+	// when dependencies are vendored, only the modules in the vendor directory are compiled in
+	if vendorFile, err := f.Open("vendor/modules.txt"); err == nil {
+		defer vendorFile.Close()
+
+		packages, err = applyGoVendorModules(packages, vendorFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.CollapseIncompatibleVersions {
+		collapseGoIncompatibleVersions(packages)
+	}
+
+	for key, pkg := range packages {
+		if opts.excludes(pkg) {
+			delete(packages, key)
+		}
+	}
+
+	if !opts.NoDedup {
+		packages = deduplicatePackages(packages)
+	}
+
+	info := newGoModInfo(parsedLockfile, lines, replaces)
+	info.Packages = maps.Values(packages)
+
+	return info, errors.Join(entryErrs...)
+}
+
+// ExtractWithReport extracts the packages in the same way as ExtractWithOptions, also
+// reporting the modules which have been replaced with a local directory, as they are
+// kept without a version and so are not scanned
+func (e GoLockExtractor) ExtractWithReport(f DepFile, opts ExtractOptions) ([]PackageDetails, []SkippedPackage, error) {
+	info, err := extractGoModInfo(f, opts)
+	if info == nil {
+		return []PackageDetails{}, []SkippedPackage{}, err
+	}
+
+	skipped := make([]SkippedPackage, 0)
+
+	for _, pkg := range info.Packages {
+		// local replacements are kept without a version, but so are remote
+		// replacements whose version could not be resolved, which are not skipped
+		if pkg.Origin != "replace" || pkg.Version != "" || pkg.Commit != "" {
+			continue
+		}
+
+		isLocal := slices.ContainsFunc(info.Replaces, func(replace GoModReplace) bool {
+			return replace.Old.Path == pkg.Name && !hasHostnamePrefix(replace.New.Path)
+		})
+
+		if !isLocal {
+			continue
+		}
+
+		location := pkg.BlockLocation
+
+		skipped = append(skipped, SkippedPackage{
+			Name:     pkg.Name,
+			Reason:   SkipReasonLocalReplacement,
+			Location: &location,
+		})
+	}
+
+	slices.SortFunc(skipped, func(a, b SkippedPackage) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return info.Packages, skipped, err
 }
 
 var _ Extractor = GoLockExtractor{}
+var _ ExtractorWithOptions = GoLockExtractor{}
+var _ ExtractorWithReport = GoLockExtractor{}
+var _ ExtractorWithDepGroups = GoLockExtractor{}
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("go.mod", GoLockExtractor{})
+	registerExtractor("go.mod", GoEcosystem, GoLockExtractor{})
 }
 
 func ParseGoLock(pathToLockfile string) ([]PackageDetails, error) {
 	return extractFromFile(pathToLockfile, GoLockExtractor{})
 }
 
+// GoModReplace is a replace directive of a go.mod, with the version of
+// Old being empty when every version of the module is replaced, and the
+// version of New being empty when it is replaced with a local directory
+type GoModReplace struct {
+	Old module.Version
+	New module.Version
+}
+
+// GoModRetract is a version or range of versions of the module that its
+// authors have retracted, with Low and High being the same for single versions
+type GoModRetract struct {
+	Low       string
+	High      string
+	Rationale string
+}
+
+// GoModInfo is everything that is declared by a go.mod, for those that need
+// more than its packages without having to parse the go.mod themselves
+type GoModInfo struct {
+	// File is the go.mod as parsed by modfile, which does not have its tool
+	// directives or any others that are from newer versions of Go than modfile
+	File *modfile.File
+	// Packages are those that GoLockExtractor extracts from the go.mod
+	Packages  []PackageDetails
+	Module    string
+	GoVersion string
+	Toolchain string
+	Godebug   map[string]string
+	Tools     []string
+	Excludes  []module.Version
+	Retracts  []GoModRetract
+	// Replaces are the replace directives that replaced a required module,
+	// as those for modules which are not required have no effect
+	Replaces []GoModReplace
+}
+
+// newGoModInfo returns the directives of the parsed go.mod with the given lines,
+// with the replaces being only those which have been applied to its packages
+func newGoModInfo(parsedLockfile *modfile.File, lines []string, replaces []*modfile.Replace) *GoModInfo {
+	info := &GoModInfo{
+		File:     parsedLockfile,
+		Godebug:  make(map[string]string, len(parsedLockfile.Godebug)),
+		Excludes: make([]module.Version, 0, len(parsedLockfile.Exclude)),
+		Retracts: make([]GoModRetract, 0, len(parsedLockfile.Retract)),
+		Replaces: make([]GoModReplace, 0, len(replaces)),
+	}
+
+	if parsedLockfile.Module != nil {
+		info.Module = parsedLockfile.Module.Mod.Path
+	}
+
+	if parsedLockfile.Go != nil {
+		info.GoVersion = parsedLockfile.Go.Version
+	}
+
+	if parsedLockfile.Toolchain != nil {
+		info.Toolchain = parsedLockfile.Toolchain.Name
+	}
+
+	for _, godebug := range parsedLockfile.Godebug {
+		info.Godebug[godebug.Key] = godebug.Value
+	}
+
+	info.Tools, _ = parseGoToolDirectives(lines)
+
+	for _, exclude := range parsedLockfile.Exclude {
+		info.Excludes = append(info.Excludes, exclude.Mod)
+	}
+
+	for _, retract := range parsedLockfile.Retract {
+		info.Retracts = append(info.Retracts, GoModRetract{
+			Low:       retract.Low,
+			High:      retract.High,
+			Rationale: retract.Rationale,
+		})
+	}
+
+	for _, replace := range replaces {
+		info.Replaces = append(info.Replaces, GoModReplace{Old: replace.Old, New: replace.New})
+	}
+
+	return info
+}
+
+// ParseGoModStructured extracts the packages of the given go.mod in the same way as
+// ParseGoLock, along with the rest of what it declares such as its version of Go
+func ParseGoModStructured(pathToLockfile string) (*GoModInfo, error) {
+	f, err := OpenLocalDepFile(pathToLockfile)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	info, err := extractGoModInfo(f, ExtractOptions{})
+	if err != nil && !OnlyEntryErrors(err) {
+		return nil, err
+	}
+
+	return info, err
+}
+
 func hasHostnamePrefix(path string) bool {
 	matcher := cachedregexp.MustCompile("^(\\w+:\\/\\/)?\\w+\\.\\w+.*")
 
@@ -1,11 +1,18 @@
 package lockfile_test
 
 import (
+	"bytes"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"golang.org/x/mod/module"
+
 	"github.com/google/osv-scanner/pkg/models"
 
 	"github.com/google/osv-scanner/pkg/lockfile"
@@ -81,6 +88,53 @@ func TestParseGoLock_Invalid(t *testing.T) {
 	expectPackages(t, packages, []lockfile.PackageDetails{})
 }
 
+func TestParseGoLock_Invalid_Position(t *testing.T) {
+	t.Parallel()
+
+	_, err := lockfile.ParseGoLock("fixtures/go/not-go-mod.txt")
+
+	expectParseErrorAt(t, err, 1, 1)
+}
+
+func TestParseGoLock_OneCorruptPackage(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGoLock("fixtures/go/one-corrupt-package.mod")
+
+	expectEntryErrorFor(t, err, "github.com/corrupt/package", 5)
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "golang.org/x/net",
+			Version:        "0.0.0-20220722155237-a158d28d115b",
+			Commit:         "a158d28d115b",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "golang.org/x/text",
+			Version:        "0.3.7",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       false,
+			Transitive:     true,
+		},
+	})
+}
+
 func TestParseGoLock_NoPackages(t *testing.T) {
 	t.Parallel()
 
@@ -113,6 +167,7 @@ func TestParseGoLock_WithPathMajor(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 2, End: 2},
 				Column:   models.Position{Start: 1, End: 54},
@@ -136,6 +191,7 @@ func TestParseGoLock_WithPathMajor(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 0, End: 0},
 				Column:   models.Position{Start: 0, End: 0},
@@ -166,6 +222,7 @@ func TestParseGoLock_WithoutSupportedVersioning(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 2, End: 2},
 				Column:   models.Position{Start: 1, End: 51},
@@ -184,6 +241,7 @@ func TestParseGoLock_WithoutSupportedVersioning(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 0, End: 0},
 				Column:   models.Position{Start: 0, End: 0},
@@ -194,6 +252,25 @@ func TestParseGoLock_WithoutSupportedVersioning(t *testing.T) {
 	})
 }
 
+//nolint:paralleltest
+func TestParseGoLock_WithoutSupportedVersioning_LogsWarning(t *testing.T) {
+	var buffer bytes.Buffer
+
+	lockfile.SetLogWriter(&buffer)
+	t.Cleanup(func() { lockfile.SetLogWriter(nil) })
+
+	_, err := lockfile.ParseGoLock("fixtures/go/without-supported-versioning.mod")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expected := "github.com/elastic/go-elasticsearch@master is not a canonical path, defaulting to v0.0.0-unresolved-version\n"
+
+	if buffer.String() != expected {
+		t.Errorf("Expected warning %q to be logged, but got %q", expected, buffer.String())
+	}
+}
+
 func TestParseGoLock_OnePackage(t *testing.T) {
 	t.Parallel()
 	dir, err := os.Getwd()
@@ -214,6 +291,7 @@ func TestParseGoLock_OnePackage(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 4, End: 4},
 				Column:   models.Position{Start: 2, End: 35},
@@ -254,6 +332,7 @@ func TestParseGoLock_TwoPackages(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 6, End: 6},
 				Column:   models.Position{Start: 2, End: 35},
@@ -277,6 +356,7 @@ func TestParseGoLock_TwoPackages(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 7, End: 7},
 				Column:   models.Position{Start: 2, End: 25},
@@ -300,6 +380,7 @@ func TestParseGoLock_TwoPackages(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 0, End: 0},
 				Column:   models.Position{Start: 0, End: 0},
@@ -310,6 +391,38 @@ func TestParseGoLock_TwoPackages(t *testing.T) {
 	})
 }
 
+func TestParseGoLock_TwoPackages_CRLF(t *testing.T) {
+	t.Parallel()
+
+	expected, err := lockfile.ParseGoLock("fixtures/go/two-packages.mod")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	packages, err := lockfile.ParseGoLock("fixtures/go/two-packages.crlf.mod")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	// the locations should be exactly the same as with LF line endings,
+	// other than being in a different file
+	for i := range expected {
+		filename := strings.Replace(expected[i].BlockLocation.Filename, "two-packages.mod", "two-packages.crlf.mod", 1)
+
+		expected[i].BlockLocation.Filename = filename
+
+		if expected[i].NameLocation != nil {
+			expected[i].NameLocation.Filename = filename
+		}
+
+		if expected[i].VersionLocation != nil {
+			expected[i].VersionLocation.Filename = filename
+		}
+	}
+
+	expectPackages(t, packages, expected)
+}
+
 func TestParseGoLock_IndirectPackages(t *testing.T) {
 	t.Parallel()
 	dir, err := os.Getwd()
@@ -330,6 +443,7 @@ func TestParseGoLock_IndirectPackages(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 6, End: 6},
 				Column:   models.Position{Start: 2, End: 35},
@@ -353,6 +467,7 @@ func TestParseGoLock_IndirectPackages(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 7, End: 7},
 				Column:   models.Position{Start: 2, End: 25},
@@ -376,6 +491,7 @@ func TestParseGoLock_IndirectPackages(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 11, End: 11},
 				Column:   models.Position{Start: 2, End: 38},
@@ -391,7 +507,8 @@ func TestParseGoLock_IndirectPackages(t *testing.T) {
 				Column:   models.Position{Start: 2, End: 31},
 				Filename: path,
 			},
-			IsDirect: false,
+			IsDirect:   false,
+			Transitive: true,
 		},
 		{
 			Name:           "github.com/mattn/go-isatty",
@@ -399,6 +516,7 @@ func TestParseGoLock_IndirectPackages(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 12, End: 12},
 				Column:   models.Position{Start: 2, End: 36},
@@ -414,14 +532,17 @@ func TestParseGoLock_IndirectPackages(t *testing.T) {
 				Column:   models.Position{Start: 2, End: 28},
 				Filename: path,
 			},
-			IsDirect: false,
+			IsDirect:   false,
+			Transitive: true,
 		},
 		{
 			Name:           "golang.org/x/sys",
 			Version:        "0.0.0-20210630005230-0f9fa26af87c",
+			Commit:         "0f9fa26af87c",
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 13, End: 13},
 				Column:   models.Position{Start: 2, End: 53},
@@ -437,7 +558,8 @@ func TestParseGoLock_IndirectPackages(t *testing.T) {
 				Column:   models.Position{Start: 2, End: 18},
 				Filename: path,
 			},
-			IsDirect: false,
+			IsDirect:   false,
+			Transitive: true,
 		},
 		{
 			Name:           "stdlib",
@@ -445,6 +567,7 @@ func TestParseGoLock_IndirectPackages(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 0, End: 0},
 				Column:   models.Position{Start: 0, End: 0},
@@ -475,6 +598,7 @@ func TestParseGoLock_Replacements_One(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 5, End: 5},
 				Column:   models.Position{Start: 1, End: 63},
@@ -515,6 +639,7 @@ func TestParseGoLock_Replacements_Mixed(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 7, End: 7},
 				Column:   models.Position{Start: 5, End: 59},
@@ -538,6 +663,7 @@ func TestParseGoLock_Replacements_Mixed(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 3, End: 3},
 				Column:   models.Position{Start: 5, End: 28},
@@ -558,6 +684,85 @@ func TestParseGoLock_Replacements_Mixed(t *testing.T) {
 	})
 }
 
+func TestParseGoLock_Replacements_WildcardAndVersion(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/go/replace-wildcard-and-version.mod"))
+	packages, err := lockfile.ParseGoLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "golang.org/x/net",
+			Version:        "",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 5, End: 38},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "example.com/fork/net",
+			Version:        "1.4.5",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 5, End: 52},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 47, End: 52},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 25, End: 45},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "golang.org/x/net",
+			Version:        "0.9.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 5, End: 49},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 44, End: 49},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 26, End: 42},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
 func TestParseGoLock_Replacements_Local(t *testing.T) {
 	t.Parallel()
 	dir, err := os.Getwd()
@@ -578,6 +783,7 @@ func TestParseGoLock_Replacements_Local(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 3, End: 3},
 				Column:   models.Position{Start: 5, End: 38},
@@ -601,6 +807,7 @@ func TestParseGoLock_Replacements_Local(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 7, End: 7},
 				Column:   models.Position{Start: 5, End: 42},
@@ -631,6 +838,7 @@ func TestParseGoLock_Replacements_Different(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 7, End: 7},
 				Column:   models.Position{Start: 5, End: 59},
@@ -654,6 +862,7 @@ func TestParseGoLock_Replacements_Different(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 8, End: 8},
 				Column:   models.Position{Start: 5, End: 59},
@@ -694,6 +903,7 @@ func TestParseGoLock_Replacements_NotRequired(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 2, End: 2},
 				Column:   models.Position{Start: 5, End: 28},
@@ -717,6 +927,7 @@ func TestParseGoLock_Replacements_NotRequired(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 3, End: 3},
 				Column:   models.Position{Start: 5, End: 38},
@@ -757,6 +968,7 @@ func TestParseGoLock_Replacements_NoVersion(t *testing.T) {
 			PackageManager: models.Golang,
 			Ecosystem:      lockfile.GoEcosystem,
 			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
 			BlockLocation: models.FilePosition{
 				Line:     models.Position{Start: 7, End: 7},
 				Column:   models.Position{Start: 5, End: 52},
@@ -776,3 +988,1262 @@ func TestParseGoLock_Replacements_NoVersion(t *testing.T) {
 		},
 	})
 }
+
+func TestParseGoLock_Exclude(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/go/exclude.mod"))
+	packages, err := lockfile.ParseGoLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 2, End: 35},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 30, End: 35},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 2, End: 28},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "stdlib",
+			Version:        "1.17",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 0, End: 0},
+				Column:   models.Position{Start: 0, End: 0},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestParseGoLock_Retract(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/go/retract.mod"))
+	packages, err := lockfile.ParseGoLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 1, End: 42},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 37, End: 42},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 9, End: 35},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "stdlib",
+			Version:        "1.17",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 0, End: 0},
+				Column:   models.Position{Start: 0, End: 0},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestParseGoLock_Godebug(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGoLock("fixtures/go/godebug.mod")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "stdlib",
+			Version:        "1.23",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
+			IsDirect:       true,
+		},
+	})
+}
+
+func TestParseGoLock_UnknownDirectives(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGoLock("fixtures/go/unknown-directives.mod")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "stdlib",
+			Version:        "1.99",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
+			IsDirect:       true,
+		},
+	})
+}
+
+//nolint:paralleltest
+func TestParseGoLock_UnknownDirectives_LogsWarning(t *testing.T) {
+	var buffer bytes.Buffer
+
+	lockfile.SetLogWriter(&buffer)
+	t.Cleanup(func() { lockfile.SetLogWriter(nil) })
+
+	_, err := lockfile.ParseGoLock("fixtures/go/unknown-directives.mod")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path, _ := filepath.Abs("fixtures/go/unknown-directives.mod")
+	expected := path + ":5: ignoring unknown directive: futuredirective\n" +
+		path + ":7: ignoring unknown directive: futureblock\n"
+
+	if buffer.String() != expected {
+		t.Errorf("Expected warnings %q to be logged, but got %q", expected, buffer.String())
+	}
+}
+
+func TestParseGoLock_PseudoVersions(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGoLock("fixtures/go/pseudo-versions.mod")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.3.3-0.20230920152743-5d6cde3a35c2",
+			Commit:         "5d6cde3a35c2",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
+			IsDirect:       true,
+		},
+		{
+			Name:           "golang.org/x/sys",
+			Version:        "0.0.0-20230101000000-abcdef123456",
+			Commit:         "abcdef123456",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "golang.org/x/net",
+			Version:        "0.17.1-0.20231005145523-a8cbbbd7098b",
+			Commit:         "a8cbbbd7098b",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "golang.org/x/text",
+			Version:        "0.14.0-pre.0.20231030141821-2b5bb2c1b83c",
+			Commit:         "2b5bb2c1b83c",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "golang.org/x/sync",
+			Version:        "0.5.0-rc.1.0.20231101094126-10a6c9e2b4ca",
+			Commit:         "10a6c9e2b4ca",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "stdlib",
+			Version:        "1.21",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
+			IsDirect:       true,
+		},
+	})
+}
+
+func TestParseGoLock_MultiByteCharacters(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/go/multi-byte-characters.mod"))
+	packages, err := lockfile.ParseGoLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/tömł",
+			Version:        "1.1.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 1, End: 72},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 67, End: 72},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 39, End: 65},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestParseGoLock_Vendored(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/go/vendored/go.mod"))
+	vendorPath := filepath.FromSlash(filepath.Join(dir, "fixtures/go/vendored/vendor/modules.txt"))
+
+	packages, err := lockfile.ParseGoLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	// gopkg.in/yaml.v2 is required but not vendored, as none of its packages are used
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 2, End: 35},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 2, End: 28},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 30, End: 35},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "github.com/kr/pretty",
+			Version:        "0.3.1",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 11, End: 11},
+				Column:   models.Position{Start: 1, End: 36},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 11, End: 11},
+				Column:   models.Position{Start: 9, End: 29},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 11, End: 11},
+				Column:   models.Position{Start: 31, End: 36},
+				Filename: path,
+			},
+			IsDirect:   false,
+			Transitive: true,
+		},
+		{
+			Name:           "github.com/kr/text",
+			Version:        "0.2.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "vendor",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 1, End: 28},
+				Filename: vendorPath,
+			},
+			IsDirect:   false,
+			Transitive: true,
+		},
+		{
+			Name:           "golang.org/x/text",
+			Version:        "0.3.8",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 13, End: 13},
+				Column:   models.Position{Start: 1, End: 54},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 13, End: 13},
+				Column:   models.Position{Start: 9, End: 26},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 13, End: 13},
+				Column:   models.Position{Start: 49, End: 54},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "stdlib",
+			Version:        "1.21",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
+			BlockLocation: models.FilePosition{
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+//nolint:paralleltest
+func TestParseGoLock_MislabeledIndirect(t *testing.T) {
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	var buffer bytes.Buffer
+
+	lockfile.SetLogWriter(&buffer)
+	t.Cleanup(func() { lockfile.SetLogWriter(nil) })
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/go/mislabeled-indirect/go.mod"))
+	packages, err := lockfile.ParseGoLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expected := "" +
+		"github.com/google/uuid@v1.3.1 is marked as indirect in " + path +
+		" but it is required alongside direct dependencies, so it is treated as direct\n" +
+		"golang.org/x/sync@v0.3.0 is not marked as indirect in " + path +
+		" but none of its packages are in the build according to the go.sum, so it is treated as indirect\n" +
+		"golang.org/x/text@v0.13.0 is not marked as indirect in " + path +
+		" but it is required alongside indirect dependencies, so it is treated as indirect\n"
+
+	if buffer.String() != expected {
+		t.Errorf("Expected warning %q to be logged, but got %q", expected, buffer.String())
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.3.2",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 2, End: 35},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 2, End: 28},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 30, End: 35},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "github.com/google/uuid",
+			Version:        "1.3.1",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 2, End: 31},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 2, End: 24},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 26, End: 31},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "github.com/spf13/cobra",
+			Version:        "1.7.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 2, End: 31},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 2, End: 24},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 26, End: 31},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "golang.org/x/sync",
+			Version:        "0.3.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 2, End: 26},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 2, End: 19},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 21, End: 26},
+				Filename: path,
+			},
+			Transitive: true,
+		},
+		{
+			Name:           "github.com/inconshreveable/mousetrap",
+			Version:        "1.1.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 13, End: 13},
+				Column:   models.Position{Start: 2, End: 45},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 13, End: 13},
+				Column:   models.Position{Start: 2, End: 38},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 13, End: 13},
+				Column:   models.Position{Start: 40, End: 45},
+				Filename: path,
+			},
+			Transitive: true,
+		},
+		{
+			Name:           "github.com/spf13/pflag",
+			Version:        "1.0.5",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 14, End: 14},
+				Column:   models.Position{Start: 2, End: 31},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 14, End: 14},
+				Column:   models.Position{Start: 2, End: 24},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 14, End: 14},
+				Column:   models.Position{Start: 26, End: 31},
+				Filename: path,
+			},
+			Transitive: true,
+		},
+		{
+			Name:           "golang.org/x/text",
+			Version:        "0.13.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 15, End: 15},
+				Column:   models.Position{Start: 2, End: 27},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 15, End: 15},
+				Column:   models.Position{Start: 2, End: 19},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 15, End: 15},
+				Column:   models.Position{Start: 21, End: 27},
+				Filename: path,
+			},
+			Transitive: true,
+		},
+		{
+			Name:           "stdlib",
+			Version:        "1.21",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 0, End: 0},
+				Column:   models.Position{Start: 0, End: 0},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+//nolint:paralleltest
+func TestParseGoLock_UnreadableGoSum(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "go.mod")
+
+	goMod := "module example.com/app\n\ngo 1.21\n\nrequire golang.org/x/sync v0.3.0\n"
+
+	if err := os.WriteFile(path, []byte(goMod), 0o600); err != nil {
+		t.Fatalf("could not write file: %v", err)
+	}
+
+	// lines that are longer than the scanner allows cause the go.sum to fail to be read
+	goSum := "golang.org/x/sync v0.3.0 h1:" + strings.Repeat("a", 70*1024) + "\n"
+
+	if err := os.WriteFile(filepath.Join(dir, "go.sum"), []byte(goSum), 0o600); err != nil {
+		t.Fatalf("could not write file: %v", err)
+	}
+
+	var buffer bytes.Buffer
+
+	lockfile.SetLogWriter(&buffer)
+	t.Cleanup(func() { lockfile.SetLogWriter(nil) })
+
+	packages, err := lockfile.ParseGoLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buffer.String(), "so the indirect comments of "+path+" are used as they are") {
+		t.Errorf("Expected a warning about the go.sum to be logged, but got %q", buffer.String())
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "golang.org/x/sync",
+			Version:        "0.3.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "stdlib",
+			Version:        "1.21",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
+			IsDirect:       true,
+		},
+	})
+}
+
+func TestGoLockExtractor_ExtractWithOptions_NoDedup(t *testing.T) {
+	t.Parallel()
+
+	extract := func(opts lockfile.ExtractOptions) []lockfile.PackageDetails {
+		t.Helper()
+
+		f, err := lockfile.OpenLocalDepFile("fixtures/go/replace-no-version.mod")
+		if err != nil {
+			t.Fatalf("Got unexpected error: %v", err)
+		}
+		defer f.Close()
+
+		packages, err := lockfile.ExtractWithOptions(lockfile.GoLockExtractor{}, f, opts)
+		if err != nil {
+			t.Errorf("Got unexpected error: %v", err)
+		}
+
+		return packages
+	}
+
+	// both versions of golang.org/x/net are replaced with the same fork
+	if deduplicated := extract(lockfile.ExtractOptions{}); len(deduplicated) != 1 {
+		t.Errorf("Expected 1 package when deduplicating, but got %d", len(deduplicated))
+	}
+
+	packages := extract(lockfile.ExtractOptions{NoDedup: true})
+
+	if len(packages) != 2 {
+		t.Fatalf("Expected 2 packages when not deduplicating, but got %d", len(packages))
+	}
+
+	for _, pkg := range packages {
+		if pkg.Name != "example.com/fork/net" || pkg.Version != "1.4.5" {
+			t.Errorf("Expected every package to be example.com/fork/net@1.4.5, but got %s@%s", pkg.Name, pkg.Version)
+		}
+	}
+}
+
+func TestParseGoLock_Tools(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/go/tools.mod"))
+	packages, err := lockfile.ParseGoLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "golang.org/x/net",
+			Version:        "0.25.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 2, End: 26},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 2, End: 18},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 20, End: 26},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "golang.org/x/tools",
+			Version:        "0.21.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			DepGroups:      []string{"tool"},
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 2, End: 28},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 2, End: 20},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 22, End: 28},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "honnef.co/go/tools",
+			Version:        "0.4.7",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			DepGroups:      []string{"tool"},
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 2, End: 27},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 2, End: 20},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 22, End: 27},
+				Filename: path,
+			},
+			IsDirect:   false,
+			Transitive: true,
+		},
+		{
+			Name:           "stdlib",
+			Version:        "1.24",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
+			BlockLocation: models.FilePosition{
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestGoLockExtractor_ExtractWithOptions_ExcludeTools(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/go/tools.mod")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	packages, err := lockfile.ExtractWithOptions(
+		lockfile.GoLockExtractor{},
+		f,
+		lockfile.ExtractOptions{ExcludeDepGroups: []string{"tool"}},
+	)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "golang.org/x/net",
+			Version:        "0.25.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "stdlib",
+			Version:        "1.24",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
+			IsDirect:       true,
+		},
+	})
+}
+
+func TestGoLockExtractor_ExtractWithOptions_ExcludeStdlib(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts lockfile.ExtractOptions
+		want []string
+	}{
+		{
+			name: "included by default",
+			opts: lockfile.ExtractOptions{},
+			want: []string{"github.com/BurntSushi/toml", "gopkg.in/yaml.v2", "stdlib"},
+		},
+		{
+			name: "excluded",
+			opts: lockfile.ExtractOptions{ExcludeStdlib: true},
+			want: []string{"github.com/BurntSushi/toml", "gopkg.in/yaml.v2"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := lockfile.OpenLocalDepFile("fixtures/go/two-packages.mod")
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			defer f.Close()
+
+			packages, err := lockfile.ExtractWithOptions(lockfile.GoLockExtractor{}, f, tt.opts)
+			if err != nil {
+				t.Errorf("Got unexpected error: %v", err)
+			}
+
+			names := make([]string, 0, len(packages))
+			for _, pkg := range packages {
+				names = append(names, pkg.Name)
+			}
+			slices.Sort(names)
+
+			if !slices.Equal(names, tt.want) {
+				t.Errorf("Expected packages %v, but got %v", tt.want, names)
+			}
+		})
+	}
+}
+
+func TestGoLockExtractor_ExtractWithOptions_OnUnresolvedVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		opts lockfile.ExtractOptions
+		want []string
+	}{
+		{
+			name: "defaults to no version",
+			opts: lockfile.ExtractOptions{},
+			want: []string{"github.com/elastic/go-elasticsearch@", "stdlib@1.11"},
+		},
+		{
+			name: "skipped",
+			opts: lockfile.ExtractOptions{OnUnresolvedVersion: lockfile.UnresolvedVersionSkip},
+			want: []string{"stdlib@1.11"},
+		},
+		{
+			name: "kept as written",
+			opts: lockfile.ExtractOptions{OnUnresolvedVersion: lockfile.UnresolvedVersionKeepRaw},
+			want: []string{"github.com/elastic/go-elasticsearch@master", "stdlib@1.11"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			f, err := lockfile.OpenLocalDepFile("fixtures/go/without-supported-versioning.mod")
+			if err != nil {
+				t.Fatalf("Got unexpected error: %v", err)
+			}
+			defer f.Close()
+
+			packages, err := lockfile.ExtractWithOptions(lockfile.GoLockExtractor{}, f, tt.opts)
+			if err != nil {
+				t.Errorf("Got unexpected error: %v", err)
+			}
+
+			got := make([]string, 0, len(packages))
+			for _, pkg := range packages {
+				got = append(got, pkg.Name+"@"+pkg.Version)
+			}
+			slices.Sort(got)
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Expected packages %v, but got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+//nolint:paralleltest
+func TestGoLockExtractor_ExtractWithOptions_OnUnresolvedVersion_SkipLogsWarning(t *testing.T) {
+	var buffer bytes.Buffer
+
+	lockfile.SetLogWriter(&buffer)
+	t.Cleanup(func() { lockfile.SetLogWriter(nil) })
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/go/without-supported-versioning.mod")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	_, err = lockfile.ExtractWithOptions(lockfile.GoLockExtractor{}, f, lockfile.ExtractOptions{
+		OnUnresolvedVersion: lockfile.UnresolvedVersionSkip,
+	})
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expected := "github.com/elastic/go-elasticsearch@master is not a canonical path, skipping it\n"
+
+	if buffer.String() != expected {
+		t.Errorf("Expected warning %q to be logged, but got %q", expected, buffer.String())
+	}
+}
+
+func TestParseGoLock_IncompatibleVersions(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGoLock("fixtures/go/incompatible-versions.mod")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "github.com/docker/docker",
+			Version:        "20.10.24+incompatible",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+	})
+}
+
+func TestGoLockExtractor_ExtractWithOptions_CollapseIncompatibleVersions(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/go/incompatible-versions.mod")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	packages, err := lockfile.ExtractWithOptions(
+		lockfile.GoLockExtractor{},
+		f,
+		lockfile.ExtractOptions{CollapseIncompatibleVersions: true},
+	)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "github.com/docker/docker",
+			Version:        "20.10.24+incompatible",
+			CompareVersion: "20.10.24",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+	})
+
+	for _, pkg := range packages {
+		if pkg.Name != "github.com/docker/docker" {
+			continue
+		}
+
+		if got, want := pkg.VersionToCompare(), "20.10.24"; got != want {
+			t.Errorf("Expected version to compare to be %s, but got %s", want, got)
+		}
+	}
+}
+
+func TestGoLockExtractor_ExtractWithReport(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/go/replace-local.mod")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	packages, skipped, err := lockfile.ExtractWithReport(lockfile.GoLockExtractor{}, f, lockfile.ExtractOptions{})
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	if len(packages) != 2 {
+		t.Errorf("Expected to get 2 packages, but got %d", len(packages))
+	}
+
+	expectSkippedPackages(t, skipped, []lockfile.SkippedPackage{
+		{
+			Name:   "golang.org/x/net",
+			Reason: lockfile.SkipReasonLocalReplacement,
+			Location: &models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 5, End: 42},
+				Filename: f.Path(),
+			},
+		},
+	})
+}
+
+func TestParseGoModStructured_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	info, err := lockfile.ParseGoModStructured("fixtures/go/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+
+	if info != nil {
+		t.Errorf("Expected no info but got %v", info)
+	}
+}
+
+func TestParseGoModStructured_Invalid(t *testing.T) {
+	t.Parallel()
+
+	info, err := lockfile.ParseGoModStructured("fixtures/go/not-go-mod.txt")
+
+	expectErrContaining(t, err, "unknown directive")
+
+	if info != nil {
+		t.Errorf("Expected no info but got %v", info)
+	}
+}
+
+func TestParseGoModStructured(t *testing.T) {
+	t.Parallel()
+
+	info, err := lockfile.ParseGoModStructured("fixtures/go/structured.mod")
+
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	if info.File == nil {
+		t.Errorf("Expected the parsed go.mod to be included")
+	}
+
+	expectPackagesWithoutLocations(t, info.Packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "example.com/fork/net",
+			Version:        "0.25.1",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
+			IsDirect:       true,
+		},
+		{
+			Name:           "golang.org/x/tools",
+			Version:        "0.21.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			DepGroups:      []string{"tool"},
+			Origin:         "require",
+			IsDirect:       true,
+		},
+		{
+			Name:           "stdlib",
+			Version:        "1.22.5",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
+			IsDirect:       true,
+		},
+	})
+
+	want := &lockfile.GoModInfo{
+		Module:    "example.com/my-library",
+		GoVersion: "1.22.5",
+		Toolchain: "go1.23.2",
+		Godebug:   map[string]string{"panicnil": "1"},
+		Tools:     []string{"golang.org/x/tools/cmd/stringer"},
+		Excludes:  []module.Version{{Path: "golang.org/x/net", Version: "v0.26.0"}},
+		Retracts: []lockfile.GoModRetract{
+			{Low: "v1.0.1", High: "v1.0.1", Rationale: "published accidentally"},
+			{Low: "v1.1.0", High: "v1.2.0"},
+		},
+		// the replace of gopkg.in/yaml.v3 has no effect as it is not required
+		Replaces: []lockfile.GoModReplace{
+			{
+				Old: module.Version{Path: "golang.org/x/net"},
+				New: module.Version{Path: "example.com/fork/net", Version: "v0.25.1"},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, info, cmpopts.IgnoreFields(lockfile.GoModInfo{}, "File", "Packages")); diff != "" {
+		t.Errorf("ParseGoModStructured() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestGoLockExtractor_ExtractWithReport_RemoteUnresolved(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/go/replace-remote-unresolved.mod")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	packages, skipped, err := lockfile.ExtractWithReport(lockfile.GoLockExtractor{}, f, lockfile.ExtractOptions{})
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	if len(packages) != 3 {
+		t.Errorf("Expected to get 3 packages, but got %d", len(packages))
+	}
+
+	// the remote replacement has no version as it could not be resolved,
+	// but it is not a local replacement so should not be reported as one
+	expectSkippedPackages(t, skipped, []lockfile.SkippedPackage{
+		{
+			Name:   "golang.org/x/net",
+			Reason: lockfile.SkipReasonLocalReplacement,
+			Location: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 5, End: 42},
+				Filename: f.Path(),
+			},
+		},
+	})
+}
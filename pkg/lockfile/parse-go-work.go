@@ -0,0 +1,139 @@
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"golang.org/x/exp/maps"
+	"golang.org/x/mod/modfile"
+)
+
+// isGoReplaceOverridden checks if the given replace directive from the go.mod of a
+// workspace member is overridden by any of the replace directives of the workspace,
+// which is the case when they replace the same module, or all versions of it
+func isGoReplaceOverridden(replace *modfile.Replace, workspaceReplaces []*modfile.Replace) bool {
+	for _, workspaceReplace := range workspaceReplaces {
+		if workspaceReplace.Old.Path != replace.Old.Path {
+			continue
+		}
+
+		if workspaceReplace.Old.Version == "" || workspaceReplace.Old.Version == replace.Old.Version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GoWorkExtractor extracts the packages required by the modules of a Go workspace,
+// using the go.mod of each module that is used by the go.work.
+//
+// The go.work.sum of the workspace only has the checksums of modules, so
+// it does not need to be read to know which packages are required.
+type GoWorkExtractor struct{}
+
+func (e GoWorkExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "go.work"
+}
+
+func (e GoWorkExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	return e.ExtractWithOptions(f, ExtractOptions{})
+}
+
+func (e GoWorkExtractor) ExtractWithOptions(f DepFile, opts ExtractOptions) ([]PackageDetails, error) {
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	parsedWorkfile, err := modfile.ParseWork(f.Path(), b, defaultNonCanonicalVersions)
+	if err != nil {
+		return []PackageDetails{}, newModfileParseError(f.Path(), err)
+	}
+
+	lines := fileposition.BytesToLines(b)
+	packages := map[string]PackageDetails{}
+	members := map[string]struct{}{}
+
+	var errs []error
+
+	for _, use := range parsedWorkfile.Use {
+		memberFile, err := f.Open(path.Join(use.Path, "go.mod"))
+		if err != nil {
+			return []PackageDetails{}, fmt.Errorf("could not open the go.mod of %s used by %s: %w", use.Path, f.Path(), err)
+		}
+
+		parsedLockfile, memberLines, entryErrs, err := parseGoModFile(memberFile)
+		memberFile.Close()
+
+		if err != nil {
+			return []PackageDetails{}, err
+		}
+
+		errs = append(errs, entryErrs...)
+
+		if parsedLockfile.Module != nil {
+			members[parsedLockfile.Module.Mod.Path] = struct{}{}
+		}
+
+		memberPackages := extractGoModRequires(parsedLockfile, memberLines, memberFile.Path(), opts)
+		memberReplaces := make([]*modfile.Replace, 0, len(parsedLockfile.Replace))
+
+		for _, replace := range parsedLockfile.Replace {
+			if !isGoReplaceOverridden(replace, parsedWorkfile.Replace) {
+				memberReplaces = append(memberReplaces, replace)
+			}
+		}
+
+		applyGoReplaces(memberPackages, memberReplaces, memberLines, memberFile.Path(), opts)
+
+		// the first member to require a package is the one it is reported for
+		for key, pkg := range memberPackages {
+			if _, ok := packages[key]; !ok {
+				packages[key] = pkg
+			}
+		}
+	}
+
+	// the modules of the workspace are used from their directories,
+	// even when they are required by other modules in the workspace
+	for key, pkg := range packages {
+		if _, ok := members[pkg.Name]; ok {
+			delete(packages, key)
+		}
+	}
+
+	applyGoReplaces(packages, parsedWorkfile.Replace, lines, f.Path(), opts)
+
+	if parsedWorkfile.Go != nil && parsedWorkfile.Go.Version != "" && !opts.ExcludeStdlib {
+		packages["stdlib"] = newGoStdlibPackage(parsedWorkfile.Go.Version, f.Path())
+	}
+
+	if opts.CollapseIncompatibleVersions {
+		collapseGoIncompatibleVersions(packages)
+	}
+
+	for key, pkg := range packages {
+		if opts.excludes(pkg) {
+			delete(packages, key)
+		}
+	}
+
+	return maps.Values(deduplicatePackages(packages)), errors.Join(errs...)
+}
+
+var _ Extractor = GoWorkExtractor{}
+var _ ExtractorWithOptions = GoWorkExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("go.work", GoEcosystem, GoWorkExtractor{})
+}
+
+func ParseGoWork(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, GoWorkExtractor{})
+}
@@ -0,0 +1,232 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestGoWorkExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "go.work",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/go.work",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/go.work/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/go.work.sum",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.go.work",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.GoWorkExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGoWork_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGoWork("fixtures/go-work/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseGoWork_Invalid(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGoWork("fixtures/go/not-go-mod.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseGoWork_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGoWork("fixtures/go-work/empty.work")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseGoWork_MemberDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGoWork("fixtures/go-work/missing-member/go.work")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseGoWork_TwoMembers(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/go-work/two-members/go.work"))
+	apiPath := filepath.FromSlash(filepath.Join(dir, "fixtures/go-work/two-members/api/go.mod"))
+	serverPath := filepath.FromSlash(filepath.Join(dir, "fixtures/go-work/two-members/server/go.mod"))
+
+	packages, err := lockfile.ParseGoWork(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.3.2",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "replace",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 1, End: 72},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 9, End: 35},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 67, End: 72},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "golang.org/x/text",
+			Version:        "0.3.7",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 2, End: 26},
+				Filename: apiPath,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 2, End: 19},
+				Filename: apiPath,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 21, End: 26},
+				Filename: apiPath,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "gopkg.in/yaml.v2",
+			Version:        "2.4.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 2, End: 25},
+				Filename: serverPath,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 2, End: 18},
+				Filename: serverPath,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 20, End: 25},
+				Filename: serverPath,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "stdlib",
+			Version:        "1.21",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "go",
+			BlockLocation: models.FilePosition{
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestGoWorkExtractor_ExtractWithOptions_ExcludeStdlib(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/go-work/two-members/go.work")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	packages, err := lockfile.ExtractWithOptions(lockfile.GoWorkExtractor{}, f, lockfile.ExtractOptions{ExcludeStdlib: true})
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	if len(packages) == 0 {
+		t.Errorf("Expected the packages of the members to still be extracted")
+	}
+
+	for _, pkg := range packages {
+		if pkg.Name == "stdlib" {
+			t.Errorf("Expected stdlib to be excluded, but got %s", pkg.Version)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/cachedregexp"
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// gradleBuildDependencyMatcher matches dependencies declared using the string notation,
+// like "implementation 'group:artifact:version'" in Groovy and
+// `implementation("group:artifact:version")` in Kotlin
+var gradleBuildDependencyMatcher = cachedregexp.MustCompile(
+	`^\s*(\w+)\s*\(?\s*["']([^"':\s]+:[^"':\s]+):([^"'\s@:]+)[^"']*["']`,
+)
+
+// gradleBuildVariableMatcher matches simple variables that are set to a string,
+// like "def myVersion = '1.0.0'" in Groovy and `val myVersion = "1.0.0"` in Kotlin
+var gradleBuildVariableMatcher = cachedregexp.MustCompile(
+	`^\s*(?:def\s+|val\s+|var\s+|ext\.)?(\w+)\s*=\s*["']([^"'$]+)["']`,
+)
+
+// resolveGradleBuildVersion resolves a version that references a variable like "$myVersion"
+// or "${myVersion}" using the given variables, returning false if it cannot be resolved
+func resolveGradleBuildVersion(version string, variables map[string]string) (string, bool) {
+	if !strings.HasPrefix(version, "$") {
+		return version, true
+	}
+
+	name := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(version, "$"), "{"), "}")
+	value, ok := variables[name]
+
+	return value, ok
+}
+
+func newGradleBuildPosition(line string, lineNumber int, start, end int, path string) *models.FilePosition {
+	return &models.FilePosition{
+		Line: models.Position{Start: lineNumber, End: lineNumber},
+		Column: models.Position{
+			Start: fileposition.ColumnOfByteIndex(line, start),
+			End:   fileposition.ColumnOfByteIndex(line, end),
+		},
+		Filename: path,
+	}
+}
+
+// GradleBuildExtractor extracts the dependencies that are declared in a build.gradle
+// or build.gradle.kts on a best-effort basis, as they are arbitrary scripts.
+//
+// Nothing is extracted if there is a gradle.lockfile next to the build script.
+type GradleBuildExtractor struct{}
+
+func (e GradleBuildExtractor) ShouldExtract(path string) bool {
+	base := filepath.Base(path)
+
+	return base == "build.gradle" || base == "build.gradle.kts"
+}
+
+func (e GradleBuildExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	if hasSiblingLockfile(f, "gradle.lockfile") {
+		return []PackageDetails{}, nil
+	}
+
+	var lines []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return []PackageDetails{}, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
+	}
+
+	variables := map[string]string{}
+
+	for _, line := range lines {
+		if match := gradleBuildVariableMatcher.FindStringSubmatch(line); match != nil {
+			variables[match[1]] = match[2]
+		}
+	}
+
+	packages := make([]PackageDetails, 0)
+
+	for i, line := range lines {
+		lineNumber := i + 1
+		match := gradleBuildDependencyMatcher.FindStringSubmatchIndex(line)
+
+		if match == nil {
+			continue
+		}
+
+		configuration := line[match[2]:match[3]]
+		name := line[match[4]:match[5]]
+		version := line[match[6]:match[7]]
+
+		pkg := PackageDetails{
+			Name:           name,
+			PackageManager: models.Gradle,
+			Ecosystem:      MavenEcosystem,
+			CompareAs:      MavenEcosystem,
+			DepGroups:      []string{configuration},
+			BlockLocation: *newGradleBuildPosition(
+				line,
+				lineNumber,
+				len(line)-len(strings.TrimLeft(line, " \t")),
+				len(strings.TrimRight(line, " \t")),
+				f.Path(),
+			),
+			NameLocation: newGradleBuildPosition(line, lineNumber, match[4], match[5], f.Path()),
+			IsDirect:     true,
+		}
+
+		if resolved, ok := resolveGradleBuildVersion(version, variables); ok {
+			pkg.Version = resolved
+
+			// the location of the version is only known if it is in the declaration
+			if resolved == version {
+				pkg.VersionLocation = newGradleBuildPosition(line, lineNumber, match[6], match[7], f.Path())
+			}
+		} else {
+			logWarningf("%s@%s could not be resolved in %s, defaulting to an empty version\n", name, version, f.Path())
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = GradleBuildExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("build.gradle", MavenEcosystem, GradleBuildExtractor{})
+}
+
+func ParseGradleBuild(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, GradleBuildExtractor{})
+}
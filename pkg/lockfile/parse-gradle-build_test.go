@@ -0,0 +1,338 @@
+package lockfile_test
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestGradleBuildExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "build.gradle",
+			want: true,
+		},
+		{
+			name: "",
+			path: "build.gradle.kts",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/build.gradle",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/build.gradle.kts",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/build.gradle/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/build.gradle.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/settings.gradle",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.build.gradle",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.GradleBuildExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseGradleBuild_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGradleBuild("fixtures/gradle-build/does-not-exist/build.gradle")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseGradleBuild_NoDependencies(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGradleBuild("fixtures/gradle-build/no-dependencies/build.gradle")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseGradleBuild_WithLockfile(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseGradleBuild("fixtures/gradle-build/with-lockfile/build.gradle")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	// the gradle.lockfile next to the build script is extracted instead
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+//nolint:paralleltest
+func TestParseGradleBuild_Groovy(t *testing.T) {
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/gradle-build/groovy/build.gradle"))
+
+	var buffer bytes.Buffer
+
+	lockfile.SetLogWriter(&buffer)
+	t.Cleanup(func() { lockfile.SetLogWriter(nil) })
+
+	packages, err := lockfile.ParseGradleBuild(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	warning := "org.slf4j:slf4j-api@${slf4jVersion} could not be resolved in " + path + ", defaulting to an empty version\n"
+
+	if buffer.String() != warning {
+		t.Errorf("Expected warning %q to be logged, but got %q", warning, buffer.String())
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "com.google.guava:guava",
+			Version:        "32.1.2-jre",
+			PackageManager: models.Gradle,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"implementation"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 5, End: 55},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 21, End: 43},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 44, End: 54},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "com.fasterxml.jackson.core:jackson-databind",
+			Version:        "2.15.2",
+			PackageManager: models.Gradle,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"implementation"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 5, End: 81},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 21, End: 64},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "org.postgresql:postgresql",
+			Version:        "42.6.0",
+			PackageManager: models.Gradle,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"runtimeOnly"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 5, End: 51},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 18, End: 43},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 44, End: 50},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "junit:junit",
+			Version:        "4.13.2",
+			PackageManager: models.Gradle,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"testImplementation"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 11, End: 11},
+				Column:   models.Position{Start: 5, End: 44},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 11, End: 11},
+				Column:   models.Position{Start: 25, End: 36},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 11, End: 11},
+				Column:   models.Position{Start: 37, End: 43},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "org.slf4j:slf4j-api",
+			Version:        "",
+			PackageManager: models.Gradle,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"implementation"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 12, End: 12},
+				Column:   models.Position{Start: 5, End: 57},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 12, End: 12},
+				Column:   models.Position{Start: 21, End: 40},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestParseGradleBuild_Kotlin(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/gradle-build/kotlin/build.gradle.kts"))
+
+	packages, err := lockfile.ParseGradleBuild(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "org.apache.commons:commons-lang3",
+			Version:        "3.12.0",
+			PackageManager: models.Gradle,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"api"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 5, End: 51},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 10, End: 42},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 43, End: 49},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "org.springframework.security:spring-security-crypto",
+			Version:        "5.7.3",
+			PackageManager: models.Gradle,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"implementation"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 5, End: 80},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 21, End: 72},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 73, End: 78},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "io.kotest:kotest-runner-junit5",
+			Version:        "5.6.2",
+			PackageManager: models.Gradle,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"testImplementation"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 5, End: 72},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 25, End: 55},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
@@ -12,6 +12,10 @@ import (
 const (
 	gradleLockFileCommentPrefix = "#"
 	gradleLockFileEmptyPrefix   = "empty="
+
+	// gradleBuildscriptGroup is the group of dependencies that are locked in the
+	// buildscript-gradle.lockfile, which are needed to build rather than to run the project
+	gradleBuildscriptGroup = "buildscript"
 )
 
 func isGradleLockFileDepLine(line string) bool {
@@ -44,18 +48,32 @@ type GradleLockExtractor struct {
 }
 
 func (e GradleLockExtractor) ShouldExtract(path string) bool {
-	base := filepath.Base(path)
+	return filepath.Base(path) == "gradle.lockfile"
+}
 
-	for _, lockfile := range []string{"buildscript-gradle.lockfile", "gradle.lockfile"} {
-		if lockfile == base {
-			return true
-		}
-	}
+func (e GradleLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	return extractGradleLock(f, nil)
+}
 
-	return false
+// BuildscriptGradleLockExtractor extracts the plugin classpath of a project, which Gradle
+// locks separately from its other dependencies using the same format
+type BuildscriptGradleLockExtractor struct {
+	WithMatcher
 }
 
-func (e GradleLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+func (e BuildscriptGradleLockExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "buildscript-gradle.lockfile"
+}
+
+func (e BuildscriptGradleLockExtractor) SupportedDepGroups() []string {
+	return []string{gradleBuildscriptGroup}
+}
+
+func (e BuildscriptGradleLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	return extractGradleLock(f, []string{gradleBuildscriptGroup})
+}
+
+func extractGradleLock(f DepFile, depGroups []string) ([]PackageDetails, error) {
 	pkgs := make([]PackageDetails, 0)
 	scanner := bufio.NewScanner(f)
 
@@ -70,6 +88,7 @@ func (e GradleLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 			continue
 		}
 
+		pkg.DepGroups = append([]string(nil), depGroups...)
 		pkgs = append(pkgs, pkg)
 	}
 
@@ -84,11 +103,20 @@ var GradleExtractor = GradleLockExtractor{
 	WithMatcher{Matcher: BuildGradleMatcher{}},
 }
 
+var BuildscriptGradleExtractor = BuildscriptGradleLockExtractor{
+	WithMatcher{Matcher: BuildGradleMatcher{}},
+}
+
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("gradle.lockfile", GradleExtractor)
+	registerExtractor("gradle.lockfile", MavenEcosystem, GradleExtractor)
+	registerExtractor("buildscript-gradle.lockfile", MavenEcosystem, BuildscriptGradleExtractor)
 }
 
 func ParseGradleLock(pathToLockfile string) ([]PackageDetails, error) {
 	return extractFromFile(pathToLockfile, GradleExtractor)
 }
+
+func ParseBuildscriptGradleLock(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, BuildscriptGradleExtractor)
+}
@@ -32,51 +32,88 @@ func TestGradleLockExtractor_ShouldExtract(t *testing.T) {
 		{
 			name: "",
 			path: "buildscript-gradle.lockfile",
+			want: false,
+		},
+		{
+			name: "",
+			path: "gradle.lockfile",
 			want: true,
 		},
 		{
 			name: "",
-			path: "path/to/my/buildscript-gradle.lockfile",
+			path: "path/to/my/gradle.lockfile",
 			want: true,
 		},
 		{
 			name: "",
-			path: "path/to/my/buildscript-gradle.lockfile/file",
+			path: "path/to/my/gradle.lockfile/file",
 			want: false,
 		},
 		{
 			name: "",
-			path: "path/to/my/buildscript-gradle.lockfile.file",
+			path: "path/to/my/gradle.lockfile.file",
 			want: false,
 		},
 		{
 			name: "",
-			path: "path.to.my.buildscript-gradle.lockfile",
+			path: "path.to.my.gradle.lockfile",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.GradleLockExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildscriptGradleLockExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
 			want: false,
 		},
 		{
 			name: "",
-			path: "gradle.lockfile",
+			path: "buildscript-gradle.lockfile",
 			want: true,
 		},
 		{
 			name: "",
-			path: "path/to/my/gradle.lockfile",
+			path: "path/to/my/buildscript-gradle.lockfile",
 			want: true,
 		},
 		{
 			name: "",
-			path: "path/to/my/gradle.lockfile/file",
+			path: "path/to/my/buildscript-gradle.lockfile/file",
 			want: false,
 		},
 		{
 			name: "",
-			path: "path/to/my/gradle.lockfile.file",
+			path: "path/to/my/buildscript-gradle.lockfile.file",
 			want: false,
 		},
 		{
 			name: "",
-			path: "path.to.my.gradle.lockfile",
+			path: "path.to.my.buildscript-gradle.lockfile",
+			want: false,
+		},
+		{
+			name: "",
+			path: "gradle.lockfile",
 			want: false,
 		},
 	}
@@ -84,7 +121,7 @@ func TestGradleLockExtractor_ShouldExtract(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			e := lockfile.GradleLockExtractor{}
+			e := lockfile.BuildscriptGradleLockExtractor{}
 			got := e.ShouldExtract(tt.path)
 			if got != tt.want {
 				t.Errorf("Extract() got = %v, want %v", got, tt.want)
@@ -150,6 +187,39 @@ func TestParseGradleLock_OnePackage(t *testing.T) {
 	})
 }
 
+func TestParseBuildscriptGradleLock_OnePackage(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/gradle-lockfile/buildscript"))
+	packages, err := lockfile.ParseBuildscriptGradleLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "org.jetbrains.kotlin:kotlin-gradle-plugin",
+			Version:        "1.9.22",
+			PackageManager: models.Gradle,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"buildscript"},
+		},
+		{
+			Name:           "org.jetbrains.kotlin:kotlin-stdlib",
+			Version:        "1.9.22",
+			PackageManager: models.Gradle,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"buildscript"},
+		},
+	})
+}
+
 //nolint:paralleltest
 func TestParseGradleLock_OnePackage_MatcherFailed(t *testing.T) {
 	dir, err := os.Getwd()
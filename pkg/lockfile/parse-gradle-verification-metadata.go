@@ -54,7 +54,7 @@ var GradleVerificationExtractor = GradleVerificationMetadataExtractor{
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("gradle/verification-metadata.xml", GradleVerificationMetadataExtractor{})
+	registerExtractor("gradle/verification-metadata.xml", MavenEcosystem, GradleVerificationMetadataExtractor{})
 }
 
 func ParseGradleVerificationMetadata(pathToLockfile string) ([]PackageDetails, error) {
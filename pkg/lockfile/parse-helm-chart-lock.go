@@ -0,0 +1,140 @@
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"unicode/utf8"
+
+	"github.com/google/osv-scanner/pkg/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+type HelmChartLockDependency struct {
+	Name       string `yaml:"name"`
+	Repository string `yaml:"repository"`
+	Version    string `yaml:"version"`
+}
+
+type HelmChartLockfile struct {
+	// the nodes of the dependencies are kept so that their positions are known
+	Dependencies []yaml.Node `yaml:"dependencies"`
+	Digest       string      `yaml:"digest"`
+	Generated    string      `yaml:"generated"`
+}
+
+const HelmEcosystem Ecosystem = "Helm"
+
+// helmNodeEndColumn returns the 1-based column after the end of the given scalar
+// node, including the quotes around its value if it has any
+func helmNodeEndColumn(node *yaml.Node) int {
+	end := node.Column + utf8.RuneCountInString(node.Value)
+
+	if node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) != 0 {
+		end += 2
+	}
+
+	return end
+}
+
+// helmLastNode returns the last node within the given node, which is where it ends
+func helmLastNode(node *yaml.Node) *yaml.Node {
+	for len(node.Content) > 0 {
+		node = node.Content[len(node.Content)-1]
+	}
+
+	return node
+}
+
+// helmScalarPosition returns the position of the value of the given key
+// of the mapping node, or nil if the mapping does not have that key
+func helmScalarPosition(node *yaml.Node, key string, path string) *models.FilePosition {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != key {
+			continue
+		}
+
+		value := node.Content[i+1]
+
+		return &models.FilePosition{
+			Line:     models.Position{Start: value.Line, End: value.Line},
+			Column:   models.Position{Start: value.Column, End: helmNodeEndColumn(value)},
+			Filename: path,
+		}
+	}
+
+	return nil
+}
+
+type HelmChartLockExtractor struct{}
+
+func (e HelmChartLockExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "Chart.lock"
+}
+
+func (e HelmChartLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	packages := []PackageDetails{}
+
+	decoder := yaml.NewDecoder(f)
+
+	// every document of the lockfile is read, in case it has been split into multiple
+	for {
+		var parsedLockfile *HelmChartLockfile
+
+		err := decoder.Decode(&parsedLockfile)
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+		}
+
+		if parsedLockfile == nil {
+			continue
+		}
+
+		for i := range parsedLockfile.Dependencies {
+			node := &parsedLockfile.Dependencies[i]
+
+			var dependency HelmChartLockDependency
+
+			if err := node.Decode(&dependency); err != nil {
+				return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+			}
+
+			last := helmLastNode(node)
+
+			packages = append(packages, PackageDetails{
+				Name:           dependency.Name,
+				Version:        dependency.Version,
+				PackageManager: models.Helm,
+				Ecosystem:      HelmEcosystem,
+				CompareAs:      HelmEcosystem,
+				BlockLocation: models.FilePosition{
+					Line:     models.Position{Start: node.Line, End: last.Line},
+					Column:   models.Position{Start: node.Column, End: helmNodeEndColumn(last)},
+					Filename: f.Path(),
+				},
+				NameLocation:    helmScalarPosition(node, "name", f.Path()),
+				VersionLocation: helmScalarPosition(node, "version", f.Path()),
+			})
+		}
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = HelmChartLockExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("Chart.lock", HelmEcosystem, HelmChartLockExtractor{})
+}
+
+func ParseHelmChartLock(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, HelmChartLockExtractor{})
+}
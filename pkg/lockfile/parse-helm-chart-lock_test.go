@@ -0,0 +1,217 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestHelmChartLockExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "Chart.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/Chart.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/Chart.lock/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/Chart.lock.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.Chart.lock",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.HelmChartLockExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseHelmChartLock_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseHelmChartLock("fixtures/helm/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseHelmChartLock_InvalidYaml(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseHelmChartLock("fixtures/helm/not-yaml.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseHelmChartLock_NoDependencies(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseHelmChartLock("fixtures/helm/empty.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseHelmChartLock_OneDependency(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/helm/one-dependency.lock"))
+	packages, err := lockfile.ParseHelmChartLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "postgresql",
+			Version:        "12.5.6",
+			PackageManager: models.Helm,
+			Ecosystem:      lockfile.HelmEcosystem,
+			CompareAs:      lockfile.HelmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 2, End: 4},
+				Column:   models.Position{Start: 3, End: 18},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 9, End: 19},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 12, End: 18},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseHelmChartLock_ManyDependencies(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/helm/many-dependencies.lock"))
+	packages, err := lockfile.ParseHelmChartLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "postgresql",
+			Version:        "12.5.6",
+			PackageManager: models.Helm,
+			Ecosystem:      lockfile.HelmEcosystem,
+			CompareAs:      lockfile.HelmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 2, End: 4},
+				Column:   models.Position{Start: 3, End: 18},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 9, End: 19},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 12, End: 18},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "redis",
+			Version:        "17.11.3",
+			PackageManager: models.Helm,
+			Ecosystem:      lockfile.HelmEcosystem,
+			CompareAs:      lockfile.HelmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 5, End: 7},
+				Column:   models.Position{Start: 3, End: 21},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 9, End: 14},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 12, End: 21},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "common",
+			Version:        "2.4.0",
+			PackageManager: models.Helm,
+			Ecosystem:      lockfile.HelmEcosystem,
+			CompareAs:      lockfile.HelmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 8, End: 10},
+				Column:   models.Position{Start: 3, End: 17},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 9, End: 15},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 12, End: 17},
+				Filename: path,
+			},
+		},
+	})
+}
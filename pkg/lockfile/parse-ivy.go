@@ -0,0 +1,197 @@
+package lockfile
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/cachedregexp"
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/internal/utility/filereader"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+type IvyDependency struct {
+	Org  string `xml:"org,attr"`
+	Name string `xml:"name,attr"`
+	Rev  string `xml:"rev,attr"`
+	Conf string `xml:"conf,attr"`
+	models.FilePosition
+}
+
+type IvyDependencyHolder struct {
+	Dependencies []IvyDependency
+}
+
+type IvyFile struct {
+	XMLName      xml.Name            `xml:"ivy-module"`
+	Dependencies IvyDependencyHolder `xml:"dependencies"`
+}
+
+// ivyDefaultConfs are the configurations of a module that are used by default,
+// which like the "compile" scope of Maven do not put dependencies in a group
+var ivyDefaultConfs = []string{"*", "default", "compile"}
+
+// depGroups returns the configurations of the module that the dependency is used by,
+// which are those on the left of "->" in each of the mappings of its conf attribute,
+// or none if it is used by a default configuration
+func (dep IvyDependency) depGroups() []string {
+	var groups []string
+
+	for _, mapping := range strings.Split(dep.Conf, ";") {
+		masterConfs, _, _ := strings.Cut(mapping, "->")
+
+		for _, conf := range strings.Split(masterConfs, ",") {
+			conf = strings.TrimSpace(conf)
+
+			if conf == "" {
+				continue
+			}
+
+			if slices.Contains(ivyDefaultConfs, conf) {
+				return nil
+			}
+
+			if !slices.Contains(groups, conf) {
+				groups = append(groups, conf)
+			}
+		}
+	}
+
+	return groups
+}
+
+// xmlAttributePosition returns the position of the value of the given attribute
+// in the lines of an element, or nil if the attribute is not in those lines
+func xmlAttributePosition(block []string, blockStartLine int, attr string, value string, path string) *models.FilePosition {
+	if value == "" {
+		return nil
+	}
+
+	re := cachedregexp.MustCompile(`(?:^|\s)` + cachedregexp.QuoteMeta(attr) + `\s*=\s*["'](` + cachedregexp.QuoteMeta(value) + `)["']`)
+
+	for i, line := range block {
+		match := re.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+
+		return &models.FilePosition{
+			Line: models.Position{Start: blockStartLine + i, End: blockStartLine + i},
+			Column: models.Position{
+				Start: fileposition.ColumnOfByteIndex(line, match[2]),
+				End:   fileposition.ColumnOfByteIndex(line, match[3]),
+			},
+			Filename: path,
+		}
+	}
+
+	return nil
+}
+
+func (holder *IvyDependencyHolder) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	holder.Dependencies = make([]IvyDependency, 0)
+
+	for {
+		lineStart, columnStart := decoder.InputPos()
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			// other elements such as exclude and override do not declare dependencies
+			if elem.Name.Local != "dependency" {
+				if err := decoder.Skip(); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			dependency := IvyDependency{}
+			dependency.SetLineStart(lineStart)
+			dependency.SetColumnStart(columnStart)
+
+			if err := decoder.DecodeElement(&dependency, &elem); err != nil {
+				return err
+			}
+
+			lineEnd, columnEnd := decoder.InputPos()
+			dependency.SetLineEnd(lineEnd)
+			dependency.SetColumnEnd(columnEnd)
+			holder.Dependencies = append(holder.Dependencies, dependency)
+		case xml.EndElement:
+			if elem.Name == start.Name {
+				return nil
+			}
+		}
+	}
+}
+
+type IvyExtractor struct{}
+
+func (e IvyExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "ivy.xml"
+}
+
+func (e IvyExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	var parsedLockfile *IvyFile
+
+	decoder := xml.NewDecoder(bytes.NewReader(b))
+	decoder.CharsetReader = filereader.CharsetDecoder
+
+	if err := decoder.Decode(&parsedLockfile); err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+	}
+
+	lines := fileposition.BytesToLines(b)
+	packages := make([]PackageDetails, 0, len(parsedLockfile.Dependencies.Dependencies))
+
+	for _, dependency := range parsedLockfile.Dependencies.Dependencies {
+		block := lines[dependency.Line.Start-1 : dependency.Line.End]
+
+		nameLocation := xmlAttributePosition(block, dependency.Line.Start, "name", dependency.Name, f.Path())
+		versionLocation := xmlAttributePosition(block, dependency.Line.Start, "rev", dependency.Rev, f.Path())
+
+		packages = append(packages, PackageDetails{
+			Name:    dependency.Org + ":" + dependency.Name,
+			Version: dependency.Rev,
+			BlockLocation: models.FilePosition{
+				Line:     dependency.Line,
+				Column:   dependency.Column,
+				Filename: f.Path(),
+			},
+			NameLocation:    nameLocation,
+			VersionLocation: versionLocation,
+			PackageManager:  models.Ivy,
+			Ecosystem:       MavenEcosystem,
+			CompareAs:       MavenEcosystem,
+			DepGroups:       dependency.depGroups(),
+			IsDirect:        true,
+		})
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = IvyExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("ivy.xml", MavenEcosystem, IvyExtractor{})
+}
+
+func ParseIvy(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, IvyExtractor{})
+}
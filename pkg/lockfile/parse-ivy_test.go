@@ -0,0 +1,246 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestIvyExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "ivy.xml",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/ivy.xml",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/ivy.xml/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/ivy.xml.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.ivy.xml",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.IvyExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIvy_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseIvy("fixtures/ivy/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseIvy_Invalid(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseIvy("fixtures/ivy/not-xml.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseIvy_NoDependencies(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseIvy("fixtures/ivy/empty.xml")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseIvy_OneDependency(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/ivy/one-dependency.xml"))
+	packages, err := lockfile.ParseIvy(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "org.apache.commons:commons-lang3",
+			Version:        "3.12.0",
+			PackageManager: models.Ivy,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 5, End: 77},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 48, End: 61},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 68, End: 74},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseIvy_ManyDependencies(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/ivy/many-dependencies.xml"))
+	packages, err := lockfile.ParseIvy(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "org.apache.commons:commons-lang3",
+			Version:        "3.12.0",
+			PackageManager: models.Ivy,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 5, End: 101},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 48, End: 61},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 68, End: 74},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "junit:junit",
+			Version:        "4.13.2",
+			PackageManager: models.Ivy,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"test"},
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 11, End: 11},
+				Column:   models.Position{Start: 5, End: 77},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 11, End: 11},
+				Column:   models.Position{Start: 35, End: 40},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 11, End: 11},
+				Column:   models.Position{Start: 47, End: 53},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "org.slf4j:slf4j-simple",
+			Version:        "1.7.36",
+			PackageManager: models.Ivy,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"runtime", "test"},
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 12, End: 15},
+				Column:   models.Position{Start: 5, End: 18},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 12, End: 12},
+				Column:   models.Position{Start: 39, End: 51},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 12, End: 12},
+				Column:   models.Position{Start: 58, End: 64},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "com.google.guava:guava",
+			Version:        "latest.release",
+			PackageManager: models.Ivy,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 17, End: 17},
+				Column:   models.Position{Start: 5, End: 75},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 17, End: 17},
+				Column:   models.Position{Start: 46, End: 51},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 17, End: 17},
+				Column:   models.Position{Start: 58, End: 72},
+				Filename: path,
+			},
+		},
+	})
+}
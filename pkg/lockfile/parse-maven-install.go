@@ -0,0 +1,101 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+	"golang.org/x/exp/maps"
+)
+
+type MavenInstallArtifact struct {
+	Version string `json:"version"`
+
+	models.FilePosition
+}
+
+// MavenInstallFile contains the artifacts pinned by rules_jvm_external, which are
+// keyed by their coordinates without the version, such as "com.google.guava:guava"
+type MavenInstallFile struct {
+	Artifacts map[string]*MavenInstallArtifact `json:"artifacts"`
+}
+
+type MavenInstallExtractor struct{}
+
+func (e MavenInstallExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "maven_install.json"
+}
+
+func (e MavenInstallExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	var parsedLockfile *MavenInstallFile
+
+	contentBytes, err := io.ReadAll(f)
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	if err := json.Unmarshal(contentBytes, &parsedLockfile); err != nil {
+		return []PackageDetails{}, newJSONParseError(f.Path(), contentBytes, err)
+	}
+
+	if parsedLockfile == nil {
+		return []PackageDetails{}, nil
+	}
+
+	fileposition.InJSON("artifacts", parsedLockfile.Artifacts, fileposition.BytesToLines(contentBytes), 0)
+
+	packages := map[string]PackageDetails{}
+	coordinates := maps.Keys(parsedLockfile.Artifacts)
+
+	// the coordinates are sorted so that the artifact without a classifier
+	// is the one whose location is used when there are several of a package
+	slices.Sort(coordinates)
+
+	for _, coordinate := range coordinates {
+		artifact := parsedLockfile.Artifacts[coordinate]
+
+		// artifacts with a classifier have their packaging and classifier after their
+		// group and artifact ids, but they are still versions of the same package
+		parts := strings.Split(coordinate, ":")
+		if artifact == nil || len(parts) < 2 || artifact.Version == "" {
+			continue
+		}
+
+		pkg := PackageDetails{
+			Name:           parts[0] + ":" + parts[1],
+			Version:        artifact.Version,
+			PackageManager: models.Bazel,
+			Ecosystem:      MavenEcosystem,
+			CompareAs:      MavenEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     artifact.Line,
+				Column:   artifact.Column,
+				Filename: f.Path(),
+			},
+		}
+
+		if _, ok := packages[pkg.Key()]; ok {
+			continue
+		}
+
+		packages[pkg.Key()] = pkg
+	}
+
+	return maps.Values(packages), nil
+}
+
+var _ Extractor = MavenInstallExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("maven_install.json", MavenEcosystem, MavenInstallExtractor{})
+}
+
+func ParseMavenInstall(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, MavenInstallExtractor{})
+}
@@ -0,0 +1,177 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestMavenInstallExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "maven_install.json",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/maven_install.json",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/maven_install.json/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/maven_install.json.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.maven_install.json",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.MavenInstallExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseMavenInstall_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseMavenInstall("fixtures/bazel/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseMavenInstall_InvalidJson(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseMavenInstall("fixtures/bazel/not-json.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseMavenInstall_NoArtifacts(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseMavenInstall("fixtures/bazel/empty.json")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseMavenInstall_OneArtifact(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/bazel/one-artifact.json"))
+	packages, err := lockfile.ParseMavenInstall(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "com.google.guava:guava",
+			Version:        "31.1-jre",
+			PackageManager: models.Bazel,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 6, End: 11},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseMavenInstall_ManyArtifacts(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/bazel/many-artifacts.json"))
+	packages, err := lockfile.ParseMavenInstall(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "com.google.guava:guava",
+			Version:        "31.1-jre",
+			PackageManager: models.Bazel,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 6, End: 11},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "com.google.guava:failureaccess",
+			Version:        "1.0.1",
+			PackageManager: models.Bazel,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 12, End: 17},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "io.netty:netty-transport-native-epoll",
+			Version:        "4.1.94.Final",
+			PackageManager: models.Bazel,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 18, End: 23},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+	})
+}
@@ -113,8 +113,7 @@ func (mld MavenLockDependency) resolvePropertiesValue(lockfile MavenLockFile, fi
 		}
 
 		if !ok {
-			fmt.Fprintf(
-				os.Stderr,
+			logWarningf(
 				"Failed to resolve a property. fieldToResolve \"%s\" could not be found for \"%s\" (%s)\n",
 				string(bytes),
 				lockfile.GroupID+":"+lockfile.ArtifactID,
@@ -249,6 +248,10 @@ func (e MavenLockExtractor) ShouldExtract(path string) bool {
 	return filepath.Base(path) == "pom.xml"
 }
 
+func (e MavenLockExtractor) SupportedDepGroups() []string {
+	return []string{"provided", "runtime", "test", "system", "import"}
+}
+
 /**
 ** This function merge a child lockfile into the parent one.
 ** It copies all information originating from the child in it, overriding any common properties/dependencies
@@ -370,7 +373,7 @@ func (e MavenLockExtractor) decodeMavenFile(f DepFile, depth int, visitedPath ma
 	parentPath := e.resolveParentFilename(parsedLockfile.Parent, f.Path())
 	if _, err := os.Stat(parentPath); errors.Is(err, os.ErrNotExist) {
 		// If the parent pom does not exist, it still can be in an external repository, but it is unreachable from the parser
-		_, _ = fmt.Fprintf(os.Stderr, "Maven lockfile parser couldn't reach the parent because it is not locally defined\n")
+		logWarningf("Maven lockfile parser couldn't reach the parent because it is not locally defined\n")
 		return parsedLockfile, nil
 	}
 
@@ -454,7 +457,8 @@ func (e MavenLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 		details[finalName] = pkgDetails
 	}
 
-	// If a dependency is declared and have not specified its version, then use the one declared in the managed dependencies
+	// If a dependency is declared and have not specified its version, then use the one declared in the managed dependencies.
+	// Managed dependencies imported from a BOM are not resolved, as the BOM is usually only available in a remote repository
 	for _, lockPackage := range parsedLockfile.ManagedDependencies.Dependencies {
 		resolvedGroupID, _ := lockPackage.ResolveGroupID(*parsedLockfile)
 		resolvedArtifactID, _ := lockPackage.ResolveArtifactID(*parsedLockfile)
@@ -481,7 +485,8 @@ func (e MavenLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 			pkgDetails.Version = resolvedVersion
 			pkgDetails.VersionLocation = versionPosition
 		}
-		if scope := strings.TrimSpace(lockPackage.Scope); scope != "" && scope != "compile" {
+		// The managed scope is only used when the dependency has not specified its own
+		if scope := strings.TrimSpace(lockPackage.Scope); scope != "" && scope != "compile" && len(pkgDetails.DepGroups) == 0 {
 			// Only append non-default scope (compile is the default scope).
 			pkgDetails.DepGroups = append(pkgDetails.DepGroups, scope)
 		}
@@ -527,7 +532,7 @@ var _ Extractor = MavenLockExtractor{}
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("pom.xml", MavenLockExtractor{})
+	registerExtractor("pom.xml", MavenEcosystem, MavenLockExtractor{})
 }
 
 func ParseMavenLock(pathToLockfile string) ([]PackageDetails, error) {
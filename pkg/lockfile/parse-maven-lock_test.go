@@ -372,6 +372,113 @@ func TestParseMavenLock_WithDependencyManagement(t *testing.T) {
 	})
 }
 
+func TestParseMavenLock_WithManagedVersionsOnly(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/maven/with-managed-versions-only.xml"))
+	packages, err := lockfile.ParseMavenLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	// spring-boot-starter-web is managed by an imported BOM, which is not resolved
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "com.fasterxml.jackson.core:jackson-databind",
+			Version:        "2.15.2",
+			PackageManager: models.Maven,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 40, End: 43},
+				Column:   models.Position{Start: 5, End: 18},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 42, End: 42},
+				Column:   models.Position{Start: 19, End: 35},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 22, End: 28},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "junit:junit",
+			Version:        "4.13.2",
+			PackageManager: models.Maven,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"test"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 44, End: 47},
+				Column:   models.Position{Start: 5, End: 18},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 46, End: 46},
+				Column:   models.Position{Start: 19, End: 24},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 27, End: 27},
+				Column:   models.Position{Start: 18, End: 24},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "org.springframework.boot:spring-boot-starter-web",
+			Version:        "",
+			PackageManager: models.Maven,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 48, End: 51},
+				Column:   models.Position{Start: 5, End: 18},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 50, End: 50},
+				Column:   models.Position{Start: 19, End: 42},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "org.mockito:mockito-core",
+			Version:        "5.5.0",
+			PackageManager: models.Maven,
+			Ecosystem:      lockfile.MavenEcosystem,
+			CompareAs:      lockfile.MavenEcosystem,
+			DepGroups:      []string{"provided"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 52, End: 56},
+				Column:   models.Position{Start: 5, End: 18},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 54, End: 54},
+				Column:   models.Position{Start: 19, End: 31},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 33, End: 33},
+				Column:   models.Position{Start: 18, End: 23},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
 func TestParseMavenLock_Interpolation(t *testing.T) {
 	t.Parallel()
 	dir, err := os.Getwd()
@@ -3,7 +3,6 @@ package lockfile
 import (
 	"bufio"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
@@ -44,8 +43,7 @@ func (e MixLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 		})
 
 		if len(fields) < 4 {
-			_, _ = fmt.Fprintf(
-				os.Stderr,
+			logWarningf(
 				"Found less than four fields when parsing a line that looks like a dependency in a mix.lock - please report this!\n",
 			)
 
@@ -85,7 +83,7 @@ var _ Extractor = MixLockExtractor{}
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("mix.lock", MixLockExtractor{})
+	registerExtractor("mix.lock", MixEcosystem, MixLockExtractor{})
 }
 
 func ParseMixLock(pathToLockfile string) ([]PackageDetails, error) {
@@ -0,0 +1,142 @@
+package lockfile
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+	"golang.org/x/exp/maps"
+)
+
+// FlakeLockLocked is where an input of a flake is locked to, which depending on
+// its type is either a repository on a forge like GitHub identified by its owner
+// and name, or the url of a git repository
+type FlakeLockLocked struct {
+	Type  string `json:"type"`
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	URL   string `json:"url"`
+	Rev   string `json:"rev"`
+}
+
+type FlakeLockNode struct {
+	Locked *FlakeLockLocked `json:"locked"`
+
+	models.FilePosition
+}
+
+// FlakeLockFile contains the inputs of a flake and of the flakes that it uses,
+// which are keyed by the name that they are referred to by in the inputs of others
+type FlakeLockFile struct {
+	Nodes   map[string]*FlakeLockNode `json:"nodes"`
+	Root    string                    `json:"root"`
+	Version int                       `json:"version"`
+}
+
+const NixEcosystem Ecosystem = "Nix"
+
+// flakeInputName returns the name of the repository that an input is locked to,
+// which is its owner and name for forges, or otherwise its url without the scheme
+// or ".git" suffix, and an empty string if it is not locked to a repository
+func flakeInputName(locked FlakeLockLocked) string {
+	if locked.Owner != "" && locked.Repo != "" {
+		return locked.Owner + "/" + locked.Repo
+	}
+
+	location := locked.URL
+
+	if _, after, found := strings.Cut(location, "://"); found {
+		location = after
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(location, "/"), ".git")
+}
+
+type FlakeLockExtractor struct{}
+
+func (e FlakeLockExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "flake.lock"
+}
+
+func (e FlakeLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	var parsedLockfile *FlakeLockFile
+
+	contentBytes, err := io.ReadAll(f)
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	if err := json.Unmarshal(contentBytes, &parsedLockfile); err != nil {
+		return []PackageDetails{}, newJSONParseError(f.Path(), contentBytes, err)
+	}
+
+	if parsedLockfile == nil {
+		return []PackageDetails{}, nil
+	}
+
+	fileposition.InJSON("nodes", parsedLockfile.Nodes, fileposition.BytesToLines(contentBytes), 0)
+
+	root := parsedLockfile.Root
+	if root == "" {
+		root = "root"
+	}
+
+	packages := map[string]PackageDetails{}
+	names := maps.Keys(parsedLockfile.Nodes)
+
+	// the names are sorted so that the node whose location is used is always the
+	// same when an input is locked to the same revision by several flakes
+	slices.Sort(names)
+
+	for _, name := range names {
+		node := parsedLockfile.Nodes[name]
+
+		// the root node is the flake itself, and inputs that are not locked to a
+		// revision of a repository such as paths and tarballs have nothing to check
+		if name == root || node == nil || node.Locked == nil || node.Locked.Rev == "" {
+			continue
+		}
+
+		pkgName := flakeInputName(*node.Locked)
+		if pkgName == "" {
+			continue
+		}
+
+		pkg := PackageDetails{
+			Name:           pkgName,
+			Commit:         node.Locked.Rev,
+			PackageManager: models.Nix,
+			Ecosystem:      NixEcosystem,
+			CompareAs:      NixEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     node.Line,
+				Column:   node.Column,
+				Filename: f.Path(),
+			},
+		}
+
+		if _, ok := packages[pkg.Key()]; ok {
+			continue
+		}
+
+		packages[pkg.Key()] = pkg
+	}
+
+	return maps.Values(packages), nil
+}
+
+var _ Extractor = FlakeLockExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("flake.lock", NixEcosystem, FlakeLockExtractor{})
+}
+
+func ParseFlakeLock(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, FlakeLockExtractor{})
+}
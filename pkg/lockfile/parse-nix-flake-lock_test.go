@@ -0,0 +1,194 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestFlakeLockExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "flake.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/flake.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/flake.lock/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/flake.lock.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.flake.lock",
+			want: false,
+		},
+		{
+			name: "",
+			path: "flake.nix",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.FlakeLockExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFlakeLock_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseFlakeLock("fixtures/nix/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseFlakeLock_InvalidJson(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseFlakeLock("fixtures/nix/not-json.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseFlakeLock_NoInputs(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseFlakeLock("fixtures/nix/empty.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseFlakeLock_OneInput(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/nix/one-input.lock"))
+	packages, err := lockfile.ParseFlakeLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "NixOS/nixpkgs",
+			Commit:         "205fd4226592cc83fd4c0885a3e4c9c400efabb5",
+			PackageManager: models.Nix,
+			Ecosystem:      lockfile.NixEcosystem,
+			CompareAs:      lockfile.NixEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 3, End: 18},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseFlakeLock_ManyInputs(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/nix/many-inputs.lock"))
+	packages, err := lockfile.ParseFlakeLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "numtide/flake-utils",
+			Commit:         "b1d9ab70662946ef0850d488da1c9019f3a9752a",
+			PackageManager: models.Nix,
+			Ecosystem:      lockfile.NixEcosystem,
+			CompareAs:      lockfile.NixEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 3, End: 20},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "NixOS/nixpkgs",
+			Commit:         "205fd4226592cc83fd4c0885a3e4c9c400efabb5",
+			PackageManager: models.Nix,
+			Ecosystem:      lockfile.NixEcosystem,
+			CompareAs:      lockfile.NixEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 33, End: 48},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "nix-systems/default",
+			Commit:         "da67096a3b9bf56a91d16901293e51ba5b49a27e",
+			PackageManager: models.Nix,
+			Ecosystem:      lockfile.NixEcosystem,
+			CompareAs:      lockfile.NixEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 73, End: 87},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "git.example.com/team/tools",
+			Commit:         "8f3c2a1b0e9d8c7b6a5f4e3d2c1b0a9f8e7d6c5b",
+			PackageManager: models.Nix,
+			Ecosystem:      lockfile.NixEcosystem,
+			CompareAs:      lockfile.NixEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 88, End: 105},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+	})
+}
@@ -747,3 +747,46 @@ func TestParseNpmLock_v1_SamePackageDifferentGroups(t *testing.T) {
 		},
 	})
 }
+
+func TestParseNpmLock_v1_CustomRegistry(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/npm/custom-registry.v1.json"))
+	packages, err := lockfile.ParseNpmLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "@my-org/utils",
+			Version:        "2.1.0",
+			PackageManager: models.NPM,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			IsDirect:       true,
+			RegistryURL:    "https://npm.example.com/repository/npm-private",
+		},
+		{
+			Name:           "balanced-match",
+			Version:        "1.0.2",
+			PackageManager: models.NPM,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			IsDirect:       true,
+		},
+		{
+			Name:           "wrappy",
+			Version:        "1.0.2",
+			PackageManager: models.NPM,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			IsDirect:       true,
+			RegistryURL:    "https://npm.example.com/repository/npm-private",
+		},
+	})
+}
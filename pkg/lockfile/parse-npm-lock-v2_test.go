@@ -86,6 +86,34 @@ func TestParseNpmLock_v2_OnePackage(t *testing.T) {
 	})
 }
 
+// the packages are listed in both sections for backwards compatibility, with
+// only those in "packages" being used so that they are not counted twice
+func TestParseNpmLock_v2_BothSections(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/npm/both-sections.v2.json"))
+	packages, err := lockfile.ParseNpmLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "wrappy",
+			Version:        "1.0.2",
+			PackageManager: models.NPM,
+			TargetVersions: []string{"^1.0.0"},
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			IsDirect:       true,
+		},
+	})
+}
+
 //nolint:paralleltest
 func TestParseNpmLock_v2_OnePackage_MatcherFailed(t *testing.T) {
 	dir, err := os.Getwd()
@@ -362,6 +390,7 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "3b1bb80b302c2e552685dc8a029797ec832ea7c9",
+			License:        "SEE LICENSE IN LICENSE",
 			IsDirect:       true,
 		},
 		{
@@ -380,6 +409,7 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "c5a7ba5e0ad98b8db1cb8ce105403dd4b768cced",
+			License:        "MIT",
 			DepGroups:      []string{"dev"},
 			IsDirect:       true,
 		},
@@ -391,6 +421,7 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "af885e2e890b9ef0875edd2b117305119ee5bdc5",
+			License:        "MIT",
 			DepGroups:      []string{"dev"},
 			IsDirect:       true,
 		},
@@ -401,8 +432,8 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "be5935f8d2595bcd97b05718ef1eeae08d812e10",
+			License:        "MIT",
 			DepGroups:      []string{"dev"},
-			IsDirect:       false,
 		},
 		{
 			Name:           "is-number-2",
@@ -412,6 +443,7 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "d5ac0584ee9ae7bd9288220a39780f155b9ad4c8",
+			License:        "MIT",
 			DepGroups:      []string{"dev"},
 			IsDirect:       true,
 		},
@@ -422,8 +454,8 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "82dcc8e914dabd9305ab9ae580709a7825e824f5",
+			License:        "MIT",
 			DepGroups:      []string{"dev"},
-			IsDirect:       false,
 		},
 		{
 			Name:           "is-number-3",
@@ -433,6 +465,7 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "d5ac0584ee9ae7bd9288220a39780f155b9ad4c8",
+			License:        "MIT",
 			DepGroups:      []string{"dev"},
 			IsDirect:       true,
 		},
@@ -443,8 +476,8 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "82ae8802978da40d7f1be5ad5943c9e550ab2c89",
+			License:        "MIT",
 			DepGroups:      []string{"dev"},
-			IsDirect:       false,
 		},
 		{
 			Name:           "is-number-4",
@@ -454,6 +487,7 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "af885e2e890b9ef0875edd2b117305119ee5bdc5",
+			License:        "MIT",
 			DepGroups:      []string{"dev"},
 			IsDirect:       true,
 		},
@@ -465,6 +499,7 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "af885e2e890b9ef0875edd2b117305119ee5bdc5",
+			License:        "MIT",
 			DepGroups:      []string{"dev"},
 			IsDirect:       true,
 		},
@@ -475,7 +510,6 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "",
-			IsDirect:       false,
 		},
 		{
 			Name:           "raven-js",
@@ -495,6 +529,7 @@ func TestParseNpmLock_v2_Commits(t *testing.T) {
 			Ecosystem:      lockfile.NpmEcosystem,
 			CompareAs:      lockfile.NpmEcosystem,
 			Commit:         "280b560161b751ba226d50c7db1e0a14a78c2de0",
+			License:        "MIT",
 			DepGroups:      []string{"dev"},
 			IsDirect:       true,
 		},
@@ -626,6 +661,77 @@ func TestParseNpmLock_v2_OptionalPackage(t *testing.T) {
 	})
 }
 
+func TestParseNpmLock_v2_DependencyFlags(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNpmLock("fixtures/npm/dependency-flags.v2.json")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "wrappy",
+			Version:        "1.0.2",
+			PackageManager: models.NPM,
+			TargetVersions: []string{"^1.0.0"},
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			IsDirect:       true,
+		},
+		{
+			Name:           "eslint",
+			Version:        "8.57.0",
+			PackageManager: models.NPM,
+			TargetVersions: []string{"^8.0.0"},
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			DepGroups:      []string{"dev"},
+			IsDirect:       true,
+		},
+		{
+			Name:           "fsevents",
+			Version:        "2.3.3",
+			PackageManager: models.NPM,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			DepGroups:      []string{"optional"},
+		},
+		{
+			Name:           "chokidar",
+			Version:        "3.6.0",
+			PackageManager: models.NPM,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			DepGroups:      []string{"dev", "optional"},
+		},
+		{
+			Name:           "supports-color",
+			Version:        "5.5.0",
+			PackageManager: models.NPM,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			DepGroups:      []string{"dev", "optional"},
+		},
+		{
+			Name:           "react",
+			Version:        "18.2.0",
+			PackageManager: models.NPM,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			DepGroups:      []string{"peer"},
+		},
+		{
+			Name:           "loose-envify",
+			Version:        "1.4.0",
+			PackageManager: models.NPM,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			DepGroups:      []string{"dev", "peer"},
+		},
+	})
+}
+
 func TestParseNpmLock_v2_SamePackageDifferentGroups(t *testing.T) {
 	t.Parallel()
 
@@ -659,3 +765,43 @@ func TestParseNpmLock_v2_SamePackageDifferentGroups(t *testing.T) {
 		},
 	})
 }
+
+func TestParseNpmLock_v2_CustomRegistry(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/npm/custom-registry.v2.json"))
+	packages, err := lockfile.ParseNpmLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "@my-org/utils",
+			Version:        "2.1.0",
+			PackageManager: models.NPM,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			RegistryURL:    "https://npm.example.com/repository/npm-private",
+		},
+		{
+			Name:           "balanced-match",
+			Version:        "1.0.2",
+			PackageManager: models.NPM,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+		},
+		{
+			Name:           "wrappy",
+			Version:        "1.0.2",
+			PackageManager: models.NPM,
+			Ecosystem:      lockfile.NpmEcosystem,
+			CompareAs:      lockfile.NpmEcosystem,
+			RegistryURL:    "https://npm.example.com/repository/npm-private",
+		},
+	})
+}
@@ -21,6 +21,7 @@ import (
 type NpmLockDependency struct {
 	// For an aliased package, Version is like "npm:[name]@[version]"
 	Version      string                        `json:"version"`
+	Resolved     string                        `json:"resolved"`
 	Dependencies map[string]*NpmLockDependency `json:"dependencies,omitempty"`
 
 	Dev      bool `json:"dev,omitempty"`
@@ -45,6 +46,7 @@ type NpmLockPackage struct {
 	Name     string `json:"name"`
 	Version  string `json:"version"`
 	Resolved string `json:"resolved"`
+	License  string `json:"license,omitempty"`
 
 	Dependencies         map[string]string `json:"dependencies,omitempty"`
 	DevDependencies      map[string]string `json:"devDependencies,omitempty"`
@@ -54,6 +56,7 @@ type NpmLockPackage struct {
 	Dev         bool `json:"dev,omitempty"`
 	DevOptional bool `json:"devOptional,omitempty"`
 	Optional    bool `json:"optional,omitempty"`
+	Peer        bool `json:"peer,omitempty"`
 
 	Link bool `json:"link,omitempty"`
 
@@ -174,15 +177,31 @@ func parseNpmLockDependencies(dependencies map[string]*NpmLockDependency, path s
 				Column:   detail.Column,
 				Filename: path,
 			},
-			Commit:    commit,
-			DepGroups: detail.depGroups(),
-			IsDirect:  true,
+			Commit:      commit,
+			DepGroups:   detail.depGroups(),
+			IsDirect:    true,
+			RegistryURL: npmRegistryURL(name, detail.Resolved),
 		})
 	}
 
 	return details
 }
 
+// npmRegistryURL returns the url of the registry that a package was resolved
+// from, which is what is before the name of the package in its tarball url
+func npmRegistryURL(name string, resolved string) string {
+	if !strings.HasPrefix(resolved, "https://") && !strings.HasPrefix(resolved, "http://") {
+		return ""
+	}
+
+	registry, _, found := strings.Cut(resolved, "/"+name+"/-/")
+	if !found {
+		return ""
+	}
+
+	return NpmEcosystem.nonDefaultRegistryURL(registry)
+}
+
 func extractNpmPackageName(name string) string {
 	maybeScope := path.Base(path.Dir(name))
 	pkgName := path.Base(name)
@@ -194,23 +213,43 @@ func extractNpmPackageName(name string) string {
 	return pkgName
 }
 
+// splitNpmPackageSpecifier splits a specifier like "@scope/name@1.0.0" into its name and version
+func splitNpmPackageSpecifier(specifier string) (string, string) {
+	if specifier == "" {
+		return "", ""
+	}
+
+	// the name of scoped packages starts with an "@", so we skip it when looking for the version
+	index := strings.Index(specifier[1:], "@")
+
+	if index == -1 {
+		return specifier, ""
+	}
+
+	return specifier[:index+1], specifier[index+2:]
+}
+
 func extractRootKeyPackageName(name string) string {
 	_, right, _ := strings.Cut(name, "/")
 	return right
 }
 
 func (pkg NpmLockPackage) depGroups() []string {
-	if pkg.Dev {
-		return []string{"dev"}
+	var groups []string
+
+	// "devOptional" is set when the package is both needed by a dev dependency and
+	// by an optional dependency, so it is treated as being in both groups
+	if pkg.Dev || pkg.DevOptional {
+		groups = append(groups, "dev")
 	}
-	if pkg.Optional {
-		return []string{"optional"}
+	if pkg.Optional || pkg.DevOptional {
+		groups = append(groups, "optional")
 	}
-	if pkg.DevOptional {
-		return []string{"dev", "optional"}
+	if pkg.Peer {
+		groups = append(groups, "peer")
 	}
 
-	return nil
+	return groups
 }
 
 func parseNpmLockPackages(packages map[string]*NpmLockPackage, path string) map[string]PackageDetails {
@@ -291,13 +330,15 @@ func parseNpmLockPackages(packages map[string]*NpmLockPackage, path string) map[
 				Ecosystem:      NpmEcosystem,
 				CompareAs:      NpmEcosystem,
 				Commit:         commit,
+				License:        detail.License,
 				BlockLocation: models.FilePosition{
 					Line:     detail.Line,
 					Column:   detail.Column,
 					Filename: path,
 				},
-				DepGroups: detail.depGroups(),
-				IsDirect:  isDirect,
+				DepGroups:   detail.depGroups(),
+				IsDirect:    isDirect,
+				RegistryURL: npmRegistryURL(finalName, detail.Resolved),
 			})
 		}
 	}
@@ -305,8 +346,28 @@ func parseNpmLockPackages(packages map[string]*NpmLockPackage, path string) map[
 	return details
 }
 
+// usesNpmLockPackages checks if the packages of the lockfile should be read from its
+// "packages" section rather than its "dependencies" section, based on its version.
+//
+// Lockfiles of version 1 only have "dependencies", version 3 only have "packages", and
+// version 2 has both for backwards compatibility, in which case "packages" is used as
+// it has the same packages with more details, so that they are not counted twice
+func usesNpmLockPackages(lockfile NpmLockfile) bool {
+	switch lockfile.Version {
+	case 1:
+		return false
+	case 2:
+		return lockfile.Packages != nil || lockfile.Dependencies == nil
+	case 3:
+		return true
+	}
+
+	// the version is unknown, so the lockfile is read based on what it has
+	return lockfile.Packages != nil
+}
+
 func parseNpmLock(lockfile NpmLockfile, lines []string) map[string]PackageDetails {
-	if lockfile.Packages != nil {
+	if usesNpmLockPackages(lockfile) {
 		fileposition.InJSON("packages", lockfile.Packages, lines, 0)
 
 		return parseNpmLockPackages(lockfile.Packages, lockfile.SourceFile)
@@ -325,6 +386,10 @@ func (e NpmLockExtractor) ShouldExtract(path string) bool {
 	return filepath.Base(path) == "package-lock.json"
 }
 
+func (e NpmLockExtractor) SupportedDepGroups() []string {
+	return []string{"dev", "optional", "peer"}
+}
+
 func (e NpmLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 	var parsedLockfile *NpmLockfile
 
@@ -337,7 +402,7 @@ func (e NpmLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 	decoder := json.NewDecoder(strings.NewReader(contentString))
 
 	if err := decoder.Decode(&parsedLockfile); err != nil {
-		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+		return []PackageDetails{}, newJSONParseError(f.Path(), contentBytes, err)
 	}
 	parsedLockfile.SourceFile = f.Path()
 
@@ -350,7 +415,7 @@ var NpmExtractor = NpmLockExtractor{
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("package-lock.json", NpmExtractor)
+	registerExtractor("package-lock.json", NpmEcosystem, NpmExtractor)
 }
 
 func ParseNpmLock(pathToLockfile string) ([]PackageDetails, error) {
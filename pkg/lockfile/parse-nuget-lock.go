@@ -88,7 +88,7 @@ var NuGetExtractor = NuGetLockExtractor{
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("packages.lock.json", NuGetExtractor)
+	registerExtractor("packages.lock.json", NuGetEcosystem, NuGetExtractor)
 }
 
 func ParseNuGetLock(pathToLockfile string) ([]PackageDetails, error) {
@@ -0,0 +1,134 @@
+package lockfile
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/internal/utility/filereader"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+type NuGetPackagesConfigPackage struct {
+	ID              string `xml:"id,attr"`
+	Version         string `xml:"version,attr"`
+	TargetFramework string `xml:"targetFramework,attr"`
+	models.FilePosition
+}
+
+// NuGetPackagesConfigFile is a packages.config file, which is how projects declared
+// the packages they use before they were referenced within the project file itself
+type NuGetPackagesConfigFile struct {
+	Packages []NuGetPackagesConfigPackage
+}
+
+func (file *NuGetPackagesConfigFile) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	if start.Name.Local != "packages" {
+		return fmt.Errorf("expected element type <packages> but have <%s>", start.Name.Local)
+	}
+
+	file.Packages = make([]NuGetPackagesConfigPackage, 0)
+
+	for {
+		lineStart, columnStart := decoder.InputPos()
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			if elem.Name.Local != "package" {
+				if err := decoder.Skip(); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			pkg := NuGetPackagesConfigPackage{}
+			pkg.SetLineStart(lineStart)
+			pkg.SetColumnStart(columnStart)
+
+			if err := decoder.DecodeElement(&pkg, &elem); err != nil {
+				return err
+			}
+
+			lineEnd, columnEnd := decoder.InputPos()
+			pkg.SetLineEnd(lineEnd)
+			pkg.SetColumnEnd(columnEnd)
+			file.Packages = append(file.Packages, pkg)
+		case xml.EndElement:
+			if elem.Name == start.Name {
+				return nil
+			}
+		}
+	}
+}
+
+type NuGetPackagesConfigExtractor struct{}
+
+func (e NuGetPackagesConfigExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "packages.config"
+}
+
+func (e NuGetPackagesConfigExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	var parsedLockfile *NuGetPackagesConfigFile
+
+	decoder := xml.NewDecoder(bytes.NewReader(b))
+	decoder.CharsetReader = filereader.CharsetDecoder
+
+	if err := decoder.Decode(&parsedLockfile); err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+	}
+
+	lines := fileposition.BytesToLines(b)
+	packages := make([]PackageDetails, 0, len(parsedLockfile.Packages))
+
+	for _, pkg := range parsedLockfile.Packages {
+		block := lines[pkg.Line.Start-1 : pkg.Line.End]
+
+		details := PackageDetails{
+			Name:    pkg.ID,
+			Version: pkg.Version,
+			BlockLocation: models.FilePosition{
+				Line:     pkg.Line,
+				Column:   pkg.Column,
+				Filename: f.Path(),
+			},
+			NameLocation:    xmlAttributePosition(block, pkg.Line.Start, "id", pkg.ID, f.Path()),
+			VersionLocation: xmlAttributePosition(block, pkg.Line.Start, "version", pkg.Version, f.Path()),
+			PackageManager:  models.NuGet,
+			Ecosystem:       NuGetEcosystem,
+			CompareAs:       NuGetEcosystem,
+			IsDirect:        true,
+		}
+
+		if pkg.TargetFramework != "" {
+			details.DepGroups = []string{pkg.TargetFramework}
+		}
+
+		packages = append(packages, details)
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = NuGetPackagesConfigExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("packages.config", NuGetEcosystem, NuGetPackagesConfigExtractor{})
+}
+
+func ParseNuGetPackagesConfig(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, NuGetPackagesConfigExtractor{})
+}
@@ -0,0 +1,233 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestNuGetPackagesConfigExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "packages.config",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/packages.config",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/packages.config/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/packages.config.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.packages.config",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.NuGetPackagesConfigExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseNuGetPackagesConfig_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNuGetPackagesConfig("fixtures/nuget-packages-config/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseNuGetPackagesConfig_Invalid(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNuGetPackagesConfig("fixtures/nuget-packages-config/not-xml.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseNuGetPackagesConfig_NotPackagesConfig(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNuGetPackagesConfig("fixtures/ivy/one-dependency.xml")
+
+	expectErrContaining(t, err, "expected element type <packages> but have <ivy-module>")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseNuGetPackagesConfig_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseNuGetPackagesConfig("fixtures/nuget-packages-config/empty.config")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseNuGetPackagesConfig_OnePackage(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/nuget-packages-config/one-package.config"))
+	packages, err := lockfile.ParseNuGetPackagesConfig(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "Newtonsoft.Json",
+			Version:        "13.0.1",
+			PackageManager: models.NuGet,
+			Ecosystem:      lockfile.NuGetEcosystem,
+			CompareAs:      lockfile.NuGetEcosystem,
+			DepGroups:      []string{"net472"},
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 3, End: 77},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 16, End: 31},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 42, End: 48},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseNuGetPackagesConfig_MultiplePackages(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/nuget-packages-config/multiple-packages.config"))
+	packages, err := lockfile.ParseNuGetPackagesConfig(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "Newtonsoft.Json",
+			Version:        "13.0.1",
+			PackageManager: models.NuGet,
+			Ecosystem:      lockfile.NuGetEcosystem,
+			CompareAs:      lockfile.NuGetEcosystem,
+			DepGroups:      []string{"net472"},
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 3, End: 77},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 16, End: 31},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 42, End: 48},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "NUnit",
+			Version:        "3.13.3",
+			PackageManager: models.NuGet,
+			Ecosystem:      lockfile.NuGetEcosystem,
+			CompareAs:      lockfile.NuGetEcosystem,
+			DepGroups:      []string{"net472"},
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 3, End: 96},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 16, End: 21},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 32, End: 38},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "log4net",
+			Version:        "2.0.15",
+			PackageManager: models.NuGet,
+			Ecosystem:      lockfile.NuGetEcosystem,
+			CompareAs:      lockfile.NuGetEcosystem,
+			IsDirect:       true,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 6, End: 9},
+				Column:   models.Position{Start: 3, End: 5},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 9, End: 16},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 14, End: 20},
+				Filename: path,
+			},
+		},
+	})
+}
@@ -2,6 +2,7 @@ package lockfile
 
 import (
 	"fmt"
+	"io"
 	"path/filepath"
 
 	"github.com/google/osv-scanner/pkg/models"
@@ -29,16 +30,32 @@ func (p PdmLockExtractor) ShouldExtract(path string) bool {
 	return filepath.Base(path) == "pdm.lock"
 }
 
+func (p PdmLockExtractor) SupportedDepGroups() []string {
+	return []string{"dev", "optional"}
+}
+
 func (p PdmLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 	var parsedLockFile *PdmLockFile
 
-	_, err := toml.NewDecoder(f).Decode(&parsedLockFile)
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	_, err = toml.Decode(string(content), &parsedLockFile)
 	if err != nil {
 		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
 	}
 	packages := make([]PackageDetails, 0, len(parsedLockFile.Packages))
 
-	for _, pkg := range parsedLockFile.Packages {
+	blocks := tomlPackageBlocks(f.Path(), content)
+
+	// the blocks can only be matched up with the packages if every one was found
+	if len(blocks) != len(parsedLockFile.Packages) {
+		blocks = nil
+	}
+
+	for i, pkg := range parsedLockFile.Packages {
 		details := PackageDetails{
 			Name:           pkg.Name,
 			Version:        pkg.Version,
@@ -47,6 +64,10 @@ func (p PdmLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 			CompareAs:      PdmEcosystem,
 		}
 
+		if blocks != nil {
+			details.BlockLocation = blocks[i]
+		}
+
 		var optional = true
 		for _, gr := range pkg.Groups {
 			if gr == "dev" {
@@ -74,7 +95,7 @@ var _ Extractor = PdmLockExtractor{}
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("pdm.lock", PdmLockExtractor{})
+	registerExtractor("pdm.lock", PipEcosystem, PdmLockExtractor{})
 }
 
 func ParsePdmLock(pathToLockfile string) ([]PackageDetails, error) {
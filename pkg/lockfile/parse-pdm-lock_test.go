@@ -2,6 +2,8 @@ package lockfile_test
 
 import (
 	"io/fs"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/osv-scanner/pkg/models"
@@ -107,7 +109,7 @@ func TestParsePdmLock_SinglePackage(t *testing.T) {
 	packages, err := lockfile.ParsePdmLock("fixtures/pdm/single-package.toml")
 
 	expectNilErr(t, err)
-	expectPackages(t, packages, []lockfile.PackageDetails{
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
 		{
 			Name:           "toml",
 			Version:        "0.10.2",
@@ -121,7 +123,13 @@ func TestParsePdmLock_SinglePackage(t *testing.T) {
 func TestParsePdmLock_TwoPackages(t *testing.T) {
 	t.Parallel()
 
-	packages, err := lockfile.ParsePdmLock("fixtures/pdm/two-packages.toml")
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/pdm/two-packages.toml"))
+	packages, err := lockfile.ParsePdmLock(path)
 
 	expectNilErr(t, err)
 	expectPackages(t, packages, []lockfile.PackageDetails{
@@ -131,6 +139,11 @@ func TestParsePdmLock_TwoPackages(t *testing.T) {
 			PackageManager: models.Pdm,
 			Ecosystem:      lockfile.PdmEcosystem,
 			CompareAs:      lockfile.PdmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 21, End: 30},
+				Column:   models.Position{Start: 1, End: 2},
+				Filename: path,
+			},
 		},
 		{
 			Name:           "six",
@@ -138,6 +151,11 @@ func TestParsePdmLock_TwoPackages(t *testing.T) {
 			PackageManager: models.Pdm,
 			Ecosystem:      lockfile.PdmEcosystem,
 			CompareAs:      lockfile.PdmEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 10, End: 19},
+				Column:   models.Position{Start: 1, End: 2},
+				Filename: path,
+			},
 		},
 	})
 }
@@ -148,7 +166,7 @@ func TestParsePdmLock_PackageWithDevDependencies(t *testing.T) {
 	packages, err := lockfile.ParsePdmLock("fixtures/pdm/dev-dependency.toml")
 
 	expectNilErr(t, err)
-	expectPackages(t, packages, []lockfile.PackageDetails{
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
 		{
 			Name:           "toml",
 			Version:        "0.10.2",
@@ -181,7 +199,7 @@ func TestParsePdmLock_PackageWithOptionalDependency(t *testing.T) {
 	packages, err := lockfile.ParsePdmLock("fixtures/pdm/optional-dependency.toml")
 
 	expectNilErr(t, err)
-	expectPackages(t, packages, []lockfile.PackageDetails{
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
 		{
 			Name:           "toml",
 			Version:        "0.10.2",
@@ -214,7 +232,7 @@ func TestParsePdmLock_PackageWithGitDependency(t *testing.T) {
 	packages, err := lockfile.ParsePdmLock("fixtures/pdm/git-dependency.toml")
 
 	expectNilErr(t, err)
-	expectPackages(t, packages, []lockfile.PackageDetails{
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
 		{
 			Name:           "toml",
 			Version:        "0.10.2",
@@ -2,25 +2,78 @@ package lockfile
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"path/filepath"
+	"slices"
+	"strings"
 
+	"github.com/google/osv-scanner/internal/cachedregexp"
+	"github.com/google/osv-scanner/internal/utility/fileposition"
 	"github.com/google/osv-scanner/pkg/models"
 
 	"golang.org/x/exp/maps"
 )
 
 type PipenvPackage struct {
-	Version string `json:"version"`
+	Version  string   `json:"version"`
+	Git      string   `json:"git,omitempty"`
+	Ref      string   `json:"ref,omitempty"`
+	Editable bool     `json:"editable,omitempty"`
+	Path     string   `json:"path,omitempty"`
+	Hashes   []string `json:"hashes,omitempty"`
+	// Index is the name of the source that the package is installed from
+	Index string `json:"index,omitempty"`
+}
+
+// PipenvSource is one of the package indexes of the Pipfile that
+// the lockfile was generated from, which packages refer to by name
+type PipenvSource struct {
+	Name      string `json:"name"`
+	URL       string `json:"url"`
+	VerifySSL bool   `json:"verify_ssl"`
+}
+
+type PipenvMeta struct {
+	Sources []PipenvSource `json:"sources"`
 }
 
 type PipenvLock struct {
+	Meta        PipenvMeta               `json:"_meta"`
 	Packages    map[string]PipenvPackage `json:"default"`
 	PackagesDev map[string]PipenvPackage `json:"develop"`
 }
 
+// pipenvRawLock is a Pipfile.lock whose packages have not been decoded yet,
+// so that they can be decoded one at a time
+type pipenvRawLock struct {
+	Meta        PipenvMeta                 `json:"_meta"`
+	Packages    map[string]json.RawMessage `json:"default"`
+	PackagesDev map[string]json.RawMessage `json:"develop"`
+}
+
+// registryURLs returns the urls of the sources of the lockfile by their names,
+// leaving out those of the default index so only packages that are installed
+// from other indexes have their RegistryURL set
+func (meta PipenvMeta) registryURLs() map[string]string {
+	urls := make(map[string]string, len(meta.Sources))
+
+	for _, source := range meta.Sources {
+		if url := PipenvEcosystem.nonDefaultRegistryURL(source.URL); url != "" {
+			urls[source.Name] = url
+		}
+	}
+
+	return urls
+}
+
 const PipenvEcosystem = PipEcosystem
 
+// pipenvEditableGroup is the group of packages that are installed in editable mode,
+// which are usually local packages that are not expected to be matched against vulnerabilities
+const pipenvEditableGroup = "editable"
+
 type PipenvLockExtractor struct {
 	WithMatcher
 }
@@ -29,54 +82,234 @@ func (e PipenvLockExtractor) ShouldExtract(path string) bool {
 	return filepath.Base(path) == "Pipfile.lock"
 }
 
+func (e PipenvLockExtractor) SupportedDepGroups() []string {
+	return []string{"dev", pipenvEditableGroup}
+}
+
 func (e PipenvLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
-	var parsedLockfile *PipenvLock
+	return e.ExtractWithOptions(f, ExtractOptions{})
+}
+
+func (e PipenvLockExtractor) ExtractWithOptions(f DepFile, opts ExtractOptions) ([]PackageDetails, error) {
+	packages, _, err := e.ExtractWithReport(f, opts)
 
-	err := json.NewDecoder(f).Decode(&parsedLockfile)
+	return packages, err
+}
 
+// ExtractWithReport extracts the packages in the same way as ExtractWithOptions,
+// also reporting the packages which were skipped as they do not have a version
+func (e PipenvLockExtractor) ExtractWithReport(f DepFile, opts ExtractOptions) ([]PackageDetails, []SkippedPackage, error) {
+	var rawLockfile *pipenvRawLock
+
+	contentBytes, err := io.ReadAll(f)
 	if err != nil {
-		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+		return []PackageDetails{}, nil, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	if err := json.Unmarshal(contentBytes, &rawLockfile); err != nil {
+		return []PackageDetails{}, nil, newJSONParseError(f.Path(), contentBytes, err)
+	}
+
+	lines := fileposition.BytesToLines(contentBytes)
+
+	parsedLockfile := &PipenvLock{}
+	var errs, errsDev []error
+
+	if rawLockfile != nil {
+		parsedLockfile.Meta = rawLockfile.Meta
+		parsedLockfile.Packages, errs = decodePipenvPackages(rawLockfile.Packages, "default", lines, f.Path())
+		parsedLockfile.PackagesDev, errsDev = decodePipenvPackages(rawLockfile.PackagesDev, "develop", lines, f.Path())
 	}
 
 	details := make(map[string]PackageDetails)
 
-	addPkgDetails(details, parsedLockfile.Packages, "")
-	addPkgDetails(details, parsedLockfile.PackagesDev, "dev")
+	registryURLs := parsedLockfile.Meta.registryURLs()
+
+	skipped := addPkgDetails(details, parsedLockfile.Packages, "", registryURLs)
+	skippedDev := addPkgDetails(details, parsedLockfile.PackagesDev, "dev", registryURLs)
+
+	packages := maps.Values(details)
+
+	skippedPackages := newPipenvSkippedPackages(skipped, parsedLockfile.Packages, "", lines, f.Path())
+	skippedPackages = append(skippedPackages, newPipenvSkippedPackages(skippedDev, parsedLockfile.PackagesDev, "dev", lines, f.Path())...)
+
+	if opts.Verbose {
+		for _, pkg := range skippedPackages {
+			logWarningf("%s does not have a version in %s, so it is normally skipped\n", pkg.Name, f.Path())
+		}
+
+		packages = append(packages, skippedPackages...)
+	}
+
+	filtered := make([]PackageDetails, 0, len(packages))
+	for _, pkg := range packages {
+		if !opts.excludes(pkg) {
+			filtered = append(filtered, pkg)
+		}
+	}
+
+	report := make([]SkippedPackage, 0, len(skippedPackages))
+	for _, pkg := range skippedPackages {
+		if !opts.excludes(pkg) {
+			report = append(report, SkippedPackage{
+				Name:     pkg.Name,
+				Reason:   SkipReasonNoVersion,
+				Location: pkg.NameLocation,
+			})
+		}
+	}
 
-	return maps.Values(details), nil
+	return filtered, report, errors.Join(append(errs, errsDev...)...)
 }
 
-func addPkgDetails(details map[string]PackageDetails, packages map[string]PipenvPackage, group string) {
-	for name, pipenvPackage := range packages {
-		if pipenvPackage.Version == "" {
+// pipenvNameLocation returns where the name of the given package is
+// within the given section of the lockfile, if it can be found
+func pipenvNameLocation(lines []string, section string, name string) *models.FilePosition {
+	sectionStart := 0
+	if position := fileposition.ExtractDelimitedStringPositionInBlock(lines, section, 1, `"`, `":`); position != nil {
+		sectionStart = position.Line.Start
+	}
+
+	return fileposition.ExtractDelimitedStringPositionInBlock(lines[sectionStart:], name, sectionStart+1, `"`, `":`)
+}
+
+// decodePipenvPackages decodes the packages of the given section of the lockfile one at
+// a time, so that a malformed package does not stop the others from being extracted
+func decodePipenvPackages(raw map[string]json.RawMessage, section string, lines []string, path string) (map[string]PipenvPackage, []error) {
+	packages := make(map[string]PipenvPackage, len(raw))
+
+	var errs []error
+
+	names := maps.Keys(raw)
+	slices.Sort(names)
+
+	for _, name := range names {
+		var pipenvPackage PipenvPackage
+
+		if err := json.Unmarshal(raw[name], &pipenvPackage); err != nil {
+			entryErr := &EntryError{Path: path, Entry: name, Err: err}
+			if location := pipenvNameLocation(lines, section, name); location != nil {
+				entryErr.Line = location.Line.Start
+			}
+
+			errs = append(errs, entryErr)
+
 			continue
 		}
 
-		version := pipenvPackage.Version[2:]
+		packages[name] = pipenvPackage
+	}
+
+	return packages, errs
+}
+
+// newPipenvSkippedPackages returns the packages with the given names which were skipped
+// as they do not have a version, along with where their names are in the lockfile so
+// that it can be seen why they were skipped
+func newPipenvSkippedPackages(names []string, packages map[string]PipenvPackage, group string, lines []string, path string) []PackageDetails {
+	section := "default"
+	if group == "dev" {
+		section = "develop"
+	}
+
+	details := make([]PackageDetails, 0, len(names))
+
+	for _, name := range names {
+		pkgDetails := PackageDetails{
+			Name:           name,
+			Version:        "",
+			PackageManager: models.Pipfile,
+			Ecosystem:      PipenvEcosystem,
+			CompareAs:      PipenvEcosystem,
+			NameLocation:   pipenvNameLocation(lines, section, name),
+		}
+		if pkgDetails.NameLocation != nil {
+			pkgDetails.NameLocation.Filename = path
+		}
+		if group != "" {
+			pkgDetails.DepGroups = append(pkgDetails.DepGroups, group)
+		}
+		if packages[name].Editable {
+			pkgDetails.DepGroups = append(pkgDetails.DepGroups, pipenvEditableGroup)
+		}
+		details = append(details, pkgDetails)
+	}
+
+	return details
+}
+
+// cutPipenvVersionOperator returns the operator that the given version is prefixed with,
+// like "==" or "===", along with the version without it, as Pipfile.lock and Pipfile
+// files store versions with their operator but some versions of pipenv omit it
+func cutPipenvVersionOperator(version string) (string, string) {
+	match := cachedregexp.MustCompile(`^\s*(===|==|~=|!=|<=|>=|<|>)?\s*`).FindString(version)
+
+	return strings.TrimSpace(match), version[len(match):]
+}
+
+// addPkgDetails adds the given packages to the details, returning the
+// names of those that were skipped as they do not have a version
+func addPkgDetails(details map[string]PackageDetails, packages map[string]PipenvPackage, group string, registryURLs map[string]string) []string {
+	var skipped []string
+
+	for name, pipenvPackage := range packages {
+		var commit, key string
+
+		_, version := cutPipenvVersionOperator(pipenvPackage.Version)
+
+		switch {
+		case version != "":
+			key = name + "@" + version
+		case pipenvPackage.Git != "" && pipenvPackage.Ref != "":
+			// packages installed from git are pinned to a ref rather than a version
+			commit = pipenvPackage.Ref
+			key = name + "@" + commit
+		case pipenvPackage.Editable && pipenvPackage.Path != "":
+			key = name + "@" + pipenvPackage.Path
+		default:
+			skipped = append(skipped, name)
+
+			continue
+		}
 
-		if _, ok := details[name+"@"+version]; !ok {
+		if _, ok := details[key]; !ok {
 			pkgDetails := PackageDetails{
 				Name:           name,
 				Version:        version,
+				Commit:         commit,
 				PackageManager: models.Pipfile,
 				Ecosystem:      PipenvEcosystem,
 				CompareAs:      PipenvEcosystem,
+				RegistryURL:    registryURLs[pipenvPackage.Index],
 			}
 			if group != "" {
 				pkgDetails.DepGroups = append(pkgDetails.DepGroups, group)
 			}
-			details[name+"@"+version] = pkgDetails
+			if pipenvPackage.Editable {
+				pkgDetails.DepGroups = append(pkgDetails.DepGroups, pipenvEditableGroup)
+			}
+			if len(pipenvPackage.Hashes) > 0 {
+				pkgDetails.Hashes = pipenvPackage.Hashes
+			}
+			details[key] = pkgDetails
 		}
 	}
+
+	slices.Sort(skipped)
+
+	return skipped
 }
 
+var _ ExtractorWithOptions = PipenvLockExtractor{}
+var _ ExtractorWithReport = PipenvLockExtractor{}
+
 var PipenvExtractor = PipenvLockExtractor{
 	WithMatcher{Matcher: PipfileMatcher{}},
 }
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("Pipfile.lock", PipenvExtractor)
+	registerExtractor("Pipfile.lock", PipEcosystem, PipenvExtractor)
 }
 
 func ParsePipenvLock(pathToLockfile string) ([]PackageDetails, error) {
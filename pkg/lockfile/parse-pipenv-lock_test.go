@@ -2,6 +2,7 @@ package lockfile_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"errors"
 	"io"
 	"io/fs"
@@ -86,6 +87,79 @@ func TestParsePipenvLock_InvalidJson(t *testing.T) {
 	expectPackages(t, packages, []lockfile.PackageDetails{})
 }
 
+func TestParsePipenvLock_InvalidJson_Position(t *testing.T) {
+	t.Parallel()
+
+	_, err := lockfile.ParsePipenvLock("fixtures/pipenv/trailing-comma.json")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectParseErrorAt(t, err, 5, 5)
+
+	var syntaxErr *json.SyntaxError
+
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("Expected error to wrap a json.SyntaxError, but got \"%v\"", err)
+	}
+}
+
+func TestParsePipenvLock_OneCorruptPackage(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePipenvLock("fixtures/pipenv/one-corrupt-package.json")
+
+	expectEntryErrorFor(t, err, "jinja2", 23)
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "itsdangerous",
+			Version:        "2.1.2",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+		},
+		{
+			Name:           "markupsafe",
+			Version:        "2.1.1",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			DepGroups:      []string{"dev"},
+		},
+	})
+}
+
+func TestParsePipenvLock_VersionOperators(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePipenvLock("fixtures/pipenv/version-operators.json")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "itsdangerous",
+			Version:        "2.1.2",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+		},
+		{
+			Name:           "jinja2",
+			Version:        "3.1.2",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+		},
+		{
+			Name:           "markupsafe",
+			Version:        "2.1.1",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+		},
+	})
+}
+
 func TestParsePipenvLock_NoPackages(t *testing.T) {
 	t.Parallel()
 
@@ -118,6 +192,10 @@ func TestParsePipenvLock_OnePackage(t *testing.T) {
 			PackageManager: models.Pipfile,
 			Ecosystem:      lockfile.PipenvEcosystem,
 			CompareAs:      lockfile.PipenvEcosystem,
+			Hashes: []string{
+				"sha256:0212a68688482dc52b2d45013df70d169f542b7394fc744c02a57374a4207003",
+				"sha256:089cf3dbf0cd6c100f02945abeb18484bd1ee57a079aefd52cffd17fba910b88",
+			},
 		},
 	})
 }
@@ -164,6 +242,10 @@ func TestParsePipenvLock_OnePackage_MatcherFailed(t *testing.T) {
 			PackageManager: models.Pipfile,
 			Ecosystem:      lockfile.PipenvEcosystem,
 			CompareAs:      lockfile.PipenvEcosystem,
+			Hashes: []string{
+				"sha256:0212a68688482dc52b2d45013df70d169f542b7394fc744c02a57374a4207003",
+				"sha256:089cf3dbf0cd6c100f02945abeb18484bd1ee57a079aefd52cffd17fba910b88",
+			},
 		},
 	})
 
@@ -191,7 +273,11 @@ func TestParsePipenvLock_OnePackageDev(t *testing.T) {
 			PackageManager: models.Pipfile,
 			Ecosystem:      lockfile.PipenvEcosystem,
 			CompareAs:      lockfile.PipenvEcosystem,
-			DepGroups:      []string{"dev"},
+			Hashes: []string{
+				"sha256:0212a68688482dc52b2d45013df70d169f542b7394fc744c02a57374a4207003",
+				"sha256:089cf3dbf0cd6c100f02945abeb18484bd1ee57a079aefd52cffd17fba910b88",
+			},
+			DepGroups: []string{"dev"},
 		},
 	})
 }
@@ -216,6 +302,10 @@ func TestParsePipenvLock_TwoPackages(t *testing.T) {
 			PackageManager: models.Pipfile,
 			Ecosystem:      lockfile.PipenvEcosystem,
 			CompareAs:      lockfile.PipenvEcosystem,
+			Hashes: []string{
+				"sha256:2c2349112351b88699d8d4b6b075022c0808887cb7ad10069318a8b0bc88db44",
+				"sha256:5dbbc68b317e5e42f327f9021763545dc3fc3bfe22e6deb96aaf1fc38874156a",
+			},
 		},
 		{
 			Name:           "markupsafe",
@@ -223,7 +313,11 @@ func TestParsePipenvLock_TwoPackages(t *testing.T) {
 			PackageManager: models.Pipfile,
 			Ecosystem:      lockfile.PipenvEcosystem,
 			CompareAs:      lockfile.PipenvEcosystem,
-			DepGroups:      []string{"dev"},
+			Hashes: []string{
+				"sha256:0212a68688482dc52b2d45013df70d169f542b7394fc744c02a57374a4207003",
+				"sha256:089cf3dbf0cd6c100f02945abeb18484bd1ee57a079aefd52cffd17fba910b88",
+			},
+			DepGroups: []string{"dev"},
 		},
 	})
 }
@@ -248,6 +342,10 @@ func TestParsePipenvLock_TwoPackagesAlt(t *testing.T) {
 			PackageManager: models.Pipfile,
 			Ecosystem:      lockfile.PipenvEcosystem,
 			CompareAs:      lockfile.PipenvEcosystem,
+			Hashes: []string{
+				"sha256:2c2349112351b88699d8d4b6b075022c0808887cb7ad10069318a8b0bc88db44",
+				"sha256:5dbbc68b317e5e42f327f9021763545dc3fc3bfe22e6deb96aaf1fc38874156a",
+			},
 		},
 		{
 			Name:           "markupsafe",
@@ -255,6 +353,10 @@ func TestParsePipenvLock_TwoPackagesAlt(t *testing.T) {
 			PackageManager: models.Pipfile,
 			Ecosystem:      lockfile.PipenvEcosystem,
 			CompareAs:      lockfile.PipenvEcosystem,
+			Hashes: []string{
+				"sha256:0212a68688482dc52b2d45013df70d169f542b7394fc744c02a57374a4207003",
+				"sha256:089cf3dbf0cd6c100f02945abeb18484bd1ee57a079aefd52cffd17fba910b88",
+			},
 		},
 	})
 }
@@ -279,6 +381,10 @@ func TestParsePipenvLock_MultiplePackages(t *testing.T) {
 			PackageManager: models.Pipfile,
 			Ecosystem:      lockfile.PipenvEcosystem,
 			CompareAs:      lockfile.PipenvEcosystem,
+			Hashes: []string{
+				"sha256:2c2349112351b88699d8d4b6b075022c0808887cb7ad10069318a8b0bc88db44",
+				"sha256:5dbbc68b317e5e42f327f9021763545dc3fc3bfe22e6deb96aaf1fc38874156a",
+			},
 		},
 		{
 			Name:           "pluggy",
@@ -293,7 +399,11 @@ func TestParsePipenvLock_MultiplePackages(t *testing.T) {
 			PackageManager: models.Pipfile,
 			Ecosystem:      lockfile.PipenvEcosystem,
 			CompareAs:      lockfile.PipenvEcosystem,
-			DepGroups:      []string{"dev"},
+			Hashes: []string{
+				"sha256:4224373bacce55f955a878bf9cfa763c1e360858e330072059e10bad68531159",
+				"sha256:74134bbf457f031a36d68416e1509f34bd5ccc019f0bcc952c7b909d06b37bd3",
+			},
+			DepGroups: []string{"dev"},
 		},
 		{
 			Name:           "markupsafe",
@@ -301,6 +411,10 @@ func TestParsePipenvLock_MultiplePackages(t *testing.T) {
 			PackageManager: models.Pipfile,
 			Ecosystem:      lockfile.PipenvEcosystem,
 			CompareAs:      lockfile.PipenvEcosystem,
+			Hashes: []string{
+				"sha256:0212a68688482dc52b2d45013df70d169f542b7394fc744c02a57374a4207003",
+				"sha256:089cf3dbf0cd6c100f02945abeb18484bd1ee57a079aefd52cffd17fba910b88",
+			},
 		},
 	})
 }
@@ -314,5 +428,208 @@ func TestParsePipenvLock_PackageWithoutVersion(t *testing.T) {
 		t.Errorf("Got unexpected error: %v", err)
 	}
 
-	expectPackages(t, packages, []lockfile.PackageDetails{})
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "markupsafe",
+			Version:        "",
+			Commit:         "b36054111bc1e8bbadb5d0d60158feb72926f467",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			DepGroups:      []string{"editable"},
+		},
+		{
+			Name:           "itsdangerous",
+			Version:        "",
+			Commit:         "de09cad488a4d7c7bbcbcdb8e1c2dfde64325f48",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			DepGroups:      []string{"dev", "editable"},
+		},
+	})
+}
+
+func TestPipenvLockExtractor_ExtractWithOptions_Verbose(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/pipenv/no-version.json")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	packages, err := lockfile.ExtractWithOptions(
+		lockfile.PipenvExtractor,
+		f,
+		lockfile.ExtractOptions{Verbose: true},
+	)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "markupsafe",
+			Version:        "",
+			Commit:         "b36054111bc1e8bbadb5d0d60158feb72926f467",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			DepGroups:      []string{"editable"},
+		},
+		{
+			Name:           "unpinned",
+			Version:        "",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 24, End: 24},
+				Column:   models.Position{Start: 10, End: 18},
+				Filename: f.Path(),
+			},
+		},
+		{
+			Name:           "itsdangerous",
+			Version:        "",
+			Commit:         "de09cad488a4d7c7bbcbcdb8e1c2dfde64325f48",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			DepGroups:      []string{"dev", "editable"},
+		},
+	})
+}
+
+func TestPipenvLockExtractor_ExtractWithReport(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/pipenv/no-version.json")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	packages, skipped, err := lockfile.ExtractWithReport(lockfile.PipenvExtractor, f, lockfile.ExtractOptions{})
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "markupsafe",
+			Version:        "",
+			Commit:         "b36054111bc1e8bbadb5d0d60158feb72926f467",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			DepGroups:      []string{"editable"},
+		},
+		{
+			Name:           "itsdangerous",
+			Version:        "",
+			Commit:         "de09cad488a4d7c7bbcbcdb8e1c2dfde64325f48",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			DepGroups:      []string{"dev", "editable"},
+		},
+	})
+
+	expectSkippedPackages(t, skipped, []lockfile.SkippedPackage{
+		{
+			Name:   "unpinned",
+			Reason: lockfile.SkipReasonNoVersion,
+			Location: &models.FilePosition{
+				Line:     models.Position{Start: 24, End: 24},
+				Column:   models.Position{Start: 10, End: 18},
+				Filename: f.Path(),
+			},
+		},
+	})
+}
+
+func TestParsePipenvLock_EditablePackage(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePipenvLock("fixtures/pipenv/editable-package.json")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "my-local-package",
+			Version:        "",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			DepGroups:      []string{"editable"},
+		},
+		{
+			Name:           "markupsafe",
+			Version:        "2.1.1",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			Hashes: []string{
+				"sha256:0212a68688482dc52b2d45013df70d169f542b7394fc744c02a57374a4207003",
+			},
+		},
+	})
+}
+
+func TestParsePipenvLock_CustomSource(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/pipenv/custom-source.json"))
+	packages, err := lockfile.ParsePipenvLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "itsdangerous",
+			Version:        "2.1.2",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			Hashes: []string{
+				"sha256:2c2349112351b88699d8d4b6b075022c0808887cb7ad10069318a8b0bc88db44",
+				"sha256:5dbbc68b317e5e42f327f9021763545dc3fc3bfe22e6deb96aaf1fc38874156a",
+			},
+		},
+		{
+			Name:           "my-internal-package",
+			Version:        "0.4.0",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			Hashes: []string{
+				"sha256:1f3d2e4c5b6a79880f1e2d3c4b5a69788f9e0d1c2b3a4958677f8e9d0c1b2a3f",
+			},
+			RegistryURL: "https://pypi.example.com/simple",
+		},
+		{
+			Name:           "markupsafe",
+			Version:        "2.1.1",
+			PackageManager: models.Pipfile,
+			Ecosystem:      lockfile.PipenvEcosystem,
+			CompareAs:      lockfile.PipenvEcosystem,
+			Hashes: []string{
+				"sha256:0212a68688482dc52b2d45013df70d169f542b7394fc744c02a57374a4207003",
+			},
+			DepGroups:   []string{"dev"},
+			RegistryURL: "https://pypi.example.com/simple",
+		},
+	})
 }
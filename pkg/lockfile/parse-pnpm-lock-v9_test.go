@@ -56,6 +56,7 @@ func TestParsePnpmLock_v9_OnePackageDev(t *testing.T) {
 			TargetVersions: []string{"^8.11.3"},
 			Ecosystem:      lockfile.PnpmEcosystem,
 			CompareAs:      lockfile.PnpmEcosystem,
+			DepGroups:      []string{"dev"},
 			IsDirect:       true,
 		},
 	})
@@ -334,7 +335,227 @@ func TestParsePnpmLock_v9_MixedGroups(t *testing.T) {
 			TargetVersions: []string{"^7.0.0"},
 			Ecosystem:      lockfile.PnpmEcosystem,
 			CompareAs:      lockfile.PnpmEcosystem,
+			DepGroups:      []string{"dev"},
 			IsDirect:       true,
 		},
 	})
 }
+
+func TestParsePnpmLock_v9_Workspaces(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePnpmLock("fixtures/pnpm/workspaces.v9.yaml")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "acorn",
+			Version:        "8.11.3",
+			PackageManager: models.Pnpm,
+			TargetVersions: []string{"^8.11.3"},
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			DepGroups:      []string{"dev"},
+			IsDirect:       true,
+			Origin:         "packages/b",
+		},
+		{
+			Name:           "ansi-regex",
+			Version:        "5.0.1",
+			PackageManager: models.Pnpm,
+			TargetVersions: []string{"^5.0.0"},
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			IsDirect:       true,
+		},
+		{
+			Name:           "is-number",
+			Version:        "7.0.0",
+			PackageManager: models.Pnpm,
+			TargetVersions: []string{"^7.0.0"},
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			DepGroups:      []string{"dev"},
+			IsDirect:       true,
+			Origin:         "packages/a",
+		},
+		{
+			Name:           "uuid",
+			Version:        "8.3.2",
+			PackageManager: models.Pnpm,
+			TargetVersions: []string{"^8.0.0"},
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			IsDirect:       true,
+			Origin:         "packages/b",
+		},
+	})
+}
+
+func TestParsePnpmLock_v9_MultipleDocuments(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePnpmLock("fixtures/pnpm/multiple-documents.v9.yaml")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "pnpm",
+			Version:        "9.15.0",
+			PackageManager: models.Pnpm,
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+		},
+		{
+			Name:           "acorn",
+			Version:        "8.11.3",
+			PackageManager: models.Pnpm,
+			TargetVersions: []string{"^8.11.3"},
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			IsDirect:       true,
+		},
+	})
+}
+
+func TestParsePnpmLock_v9_WorkspaceProtocol(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePnpmLock("fixtures/pnpm/workspace-protocol.v9.yaml")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	// packages of the workspace are local, so they are not extracted by default
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "is-number",
+			Version:        "7.0.0",
+			PackageManager: models.Pnpm,
+			TargetVersions: []string{"^7.0.0"},
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			IsDirect:       true,
+			Origin:         "packages/lib",
+		},
+		{
+			Name:           "uuid",
+			Version:        "8.3.2",
+			PackageManager: models.Pnpm,
+			TargetVersions: []string{"^8.0.0"},
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			IsDirect:       true,
+			Origin:         "packages/app",
+		},
+	})
+}
+
+func TestPnpmLockExtractor_ExtractWithOptions_IncludeWorkspacePackages(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/pnpm/workspace-protocol.v9.yaml")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	packages, err := lockfile.ExtractWithOptions(
+		lockfile.PnpmExtractor,
+		f,
+		lockfile.ExtractOptions{IncludeWorkspacePackages: true},
+	)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "@my/lib",
+			Version:        "1.0.0",
+			PackageManager: models.Pnpm,
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			DepGroups:      []string{"workspace"},
+			IsDirect:       true,
+			Origin:         "packages/app",
+		},
+		{
+			Name:           "@my/utils",
+			Version:        "",
+			PackageManager: models.Pnpm,
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			DepGroups:      []string{"workspace"},
+			IsDirect:       true,
+		},
+		{
+			Name:           "is-number",
+			Version:        "7.0.0",
+			PackageManager: models.Pnpm,
+			TargetVersions: []string{"^7.0.0"},
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			IsDirect:       true,
+			Origin:         "packages/lib",
+		},
+		{
+			Name:           "uuid",
+			Version:        "8.3.2",
+			PackageManager: models.Pnpm,
+			TargetVersions: []string{"^8.0.0"},
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			IsDirect:       true,
+			Origin:         "packages/app",
+		},
+	})
+}
+
+func TestPnpmLockExtractor_ExtractWithOptions_ExcludeWorkspaceGroup(t *testing.T) {
+	t.Parallel()
+
+	f, err := lockfile.OpenLocalDepFile("fixtures/pnpm/workspace-protocol.v9.yaml")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+	defer f.Close()
+
+	packages, err := lockfile.ExtractWithOptions(
+		lockfile.PnpmExtractor,
+		f,
+		lockfile.ExtractOptions{IncludeWorkspacePackages: true, ExcludeDepGroups: []string{"workspace"}},
+	)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "is-number",
+			Version:        "7.0.0",
+			PackageManager: models.Pnpm,
+			TargetVersions: []string{"^7.0.0"},
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			IsDirect:       true,
+			Origin:         "packages/lib",
+		},
+		{
+			Name:           "uuid",
+			Version:        "8.3.2",
+			PackageManager: models.Pnpm,
+			TargetVersions: []string{"^8.0.0"},
+			Ecosystem:      lockfile.PnpmEcosystem,
+			CompareAs:      lockfile.PnpmEcosystem,
+			IsDirect:       true,
+			Origin:         "packages/app",
+		},
+	})
+}
@@ -1,16 +1,19 @@
 package lockfile
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/google/osv-scanner/pkg/models"
 
 	"github.com/google/osv-scanner/internal/cachedregexp"
+	"golang.org/x/exp/maps"
 	"gopkg.in/yaml.v3"
 )
 
@@ -39,14 +42,19 @@ type (
 	PnpmDependencies map[string]PnpmLockDependency
 )
 
-type PnpmImporters struct {
-	Dot struct {
-		Dependencies         PnpmDependencies `yaml:"dependencies,omitempty"`
-		OptionalDependencies PnpmDependencies `yaml:"optionalDependencies,omitempty"`
-		DevDependencies      PnpmDependencies `yaml:"devDependencies,omitempty"`
-	} `yaml:".,omitempty"`
+type PnpmImporter struct {
+	// Specifiers are how the dependencies of the importer are specified in lockfiles
+	// before v6.0, which later versions have as part of the dependencies themselves
+	Specifiers           PnpmSpecifiers   `yaml:"specifiers,omitempty"`
+	Dependencies         PnpmDependencies `yaml:"dependencies,omitempty"`
+	OptionalDependencies PnpmDependencies `yaml:"optionalDependencies,omitempty"`
+	DevDependencies      PnpmDependencies `yaml:"devDependencies,omitempty"`
 }
 
+// PnpmImporters are the packages of a workspace keyed by their path,
+// with the root of the workspace being "."
+type PnpmImporters map[string]PnpmImporter
+
 type PnpmLockfile struct {
 	Version              string           `yaml:"lockfileVersion"`
 	Packages             PnpmLockPackages `yaml:"packages,omitempty"`
@@ -187,8 +195,158 @@ func getVersionInfo(name string, maps ...map[string]PnpmLockDependency) (specifi
 	return "", "", false
 }
 
-func parsePnpmLock(lockfile PnpmLockfile) []PackageDetails {
+// pnpmImporterPaths returns the paths of the importers of the lockfile, with the root
+// of the workspace first so that how it declares a package takes priority
+func pnpmImporterPaths(importers PnpmImporters) []string {
+	paths := maps.Keys(importers)
+
+	slices.SortFunc(paths, func(a, b string) int {
+		switch {
+		case a == b:
+			return 0
+		case a == ".":
+			return -1
+		case b == ".":
+			return 1
+		}
+
+		return cmp.Compare(a, b)
+	})
+
+	return paths
+}
+
+// pnpmVersionMatches checks if the version of a dependency declared by an importer is the
+// given version of a package, which it can be followed by the versions of its peers
+func pnpmVersionMatches(declared string, version string) bool {
+	return declared == version || strings.HasPrefix(declared, version+"(")
+}
+
+// pnpmImporterDepGroups returns the groups of a package based on how it is declared
+// by the importers of the lockfile, which is "dev" if every importer that declares
+// it does so as a dev dependency, for lockfiles where the packages themselves do
+// not say if they are dev dependencies
+func pnpmImporterDepGroups(name string, version string, importers PnpmImporters) []string {
+	dev := false
+
+	for _, importer := range importers {
+		for _, dependencies := range []PnpmDependencies{importer.Dependencies, importer.OptionalDependencies} {
+			if dependency, ok := dependencies[name]; ok && pnpmVersionMatches(dependency.Version, version) {
+				return nil
+			}
+		}
+
+		if dependency, ok := importer.DevDependencies[name]; ok && pnpmVersionMatches(dependency.Version, version) {
+			dev = true
+		}
+	}
+
+	if dev {
+		return []string{"dev"}
+	}
+
+	return nil
+}
+
+// pnpmWorkspaceProtocol is the prefix of the specifiers of dependencies on
+// other packages of the workspace, like "workspace:*" and "workspace:^"
+const pnpmWorkspaceProtocol = "workspace:"
+
+// pnpmWorkspaceGroup is the group of the packages of a workspace that other
+// packages of it depend on, which are not published to a registry
+const pnpmWorkspaceGroup = "workspace"
+
+// pnpmWorkspaceDependency is a dependency that an importer has on another
+// package of the workspace, which is linked to it rather than being installed
+// unless it is injected, in which case it is one of the packages of the lockfile
+type pnpmWorkspaceDependency struct {
+	name     string
+	version  string
+	importer string
+}
+
+// pnpmWorkspaceDependencies returns the dependencies that the importers of the
+// lockfile have on other packages of the workspace, with those of the root of the
+// workspace first, along with those of lockfiles for projects without a workspace
+func pnpmWorkspaceDependencies(lockfile PnpmLockfile) []pnpmWorkspaceDependency {
+	var workspaceDeps []pnpmWorkspaceDependency
+
+	add := func(path string, specifiers PnpmSpecifiers, dependencies ...PnpmDependencies) {
+		for _, deps := range dependencies {
+			names := maps.Keys(deps)
+			slices.Sort(names)
+
+			for _, name := range names {
+				specifier := deps[name].Specifier
+				if specifier == "" {
+					specifier = specifiers[name]
+				}
+
+				if strings.HasPrefix(specifier, pnpmWorkspaceProtocol) {
+					workspaceDeps = append(workspaceDeps, pnpmWorkspaceDependency{
+						name:     name,
+						version:  deps[name].Version,
+						importer: path,
+					})
+				}
+			}
+		}
+	}
+
+	add(".", lockfile.Specifiers, lockfile.Dependencies, lockfile.OptionalDependencies, lockfile.DevDependencies)
+
+	for _, path := range pnpmImporterPaths(lockfile.Importers) {
+		importer := lockfile.Importers[path]
+
+		add(path, importer.Specifiers, importer.Dependencies, importer.OptionalDependencies, importer.DevDependencies)
+	}
+
+	return workspaceDeps
+}
+
+// pnpmLinkedWorkspacePackages returns the packages of the workspace that are linked
+// to the importers which depend on them, which are attributed to the first importer
+// that does so. Their versions are not known as the lockfile only has their path
+func pnpmLinkedWorkspacePackages(workspaceDeps []pnpmWorkspaceDependency) []PackageDetails {
+	var packages []PackageDetails
+
+	seen := make(map[string]bool)
+
+	for _, dep := range workspaceDeps {
+		if !strings.HasPrefix(dep.version, "link:") || seen[dep.name] {
+			continue
+		}
+
+		seen[dep.name] = true
+
+		pkg := PackageDetails{
+			Name:           dep.name,
+			PackageManager: models.Pnpm,
+			Ecosystem:      PnpmEcosystem,
+			CompareAs:      PnpmEcosystem,
+			DepGroups:      []string{pnpmWorkspaceGroup},
+			IsDirect:       true,
+		}
+
+		if dep.importer != "." {
+			pkg.Origin = dep.importer
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	return packages
+}
+
+func parsePnpmLock(lockfile PnpmLockfile, opts ExtractOptions) []PackageDetails {
 	packages := make([]PackageDetails, 0, len(lockfile.Packages))
+	importerPaths := pnpmImporterPaths(lockfile.Importers)
+	workspaceDeps := pnpmWorkspaceDependencies(lockfile)
+
+	workspaceNames := make(map[string]bool, len(workspaceDeps))
+	for _, dep := range workspaceDeps {
+		workspaceNames[dep.name] = true
+	}
 
 	for s, pkg := range lockfile.Packages {
 		name, version := extractPnpmPackageNameAndVersion(s, lockfile.Version)
@@ -219,6 +377,14 @@ func parsePnpmLock(lockfile PnpmLockfile) []PackageDetails {
 			continue
 		}
 
+		// packages of the workspace that are injected rather than linked are
+		// installed from their directory, like other packages from a file
+		isWorkspacePackage := workspaceNames[name] && strings.Contains(s, "file:")
+
+		if isWorkspacePackage && !opts.IncludeWorkspacePackages {
+			continue
+		}
+
 		commit := pkg.Resolution.Commit
 
 		if strings.HasPrefix(pkg.Resolution.Tarball, "https://codeload.github.com") {
@@ -235,10 +401,20 @@ func parsePnpmLock(lockfile PnpmLockfile) []PackageDetails {
 			depGroups = append(depGroups, "dev")
 		}
 
+		// v9.0 no longer says if packages are dev dependencies
+		if lockfile.Version == "9.0" {
+			depGroups = pnpmImporterDepGroups(name, version, lockfile.Importers)
+		}
+
+		if isWorkspacePackage {
+			depGroups = append(depGroups, pnpmWorkspaceGroup)
+		}
+
 		var targetVersions []string
 		var targetVersion string
 		var dependencyVersion string
 		var isDirect bool
+		var origin string
 
 		// Find target and dependency version
 		if sp, ok := lockfile.Specifiers[name]; ok {
@@ -249,11 +425,28 @@ func parsePnpmLock(lockfile PnpmLockfile) []PackageDetails {
 				isDirect = true
 				dependencyVersion = v
 			}
-		} else if sp, v, f := getVersionInfo(name, lockfile.Dependencies, lockfile.Importers.Dot.Dependencies, lockfile.Importers.Dot.OptionalDependencies, lockfile.Importers.Dot.DevDependencies); f {
+		} else if sp, v, f := getVersionInfo(name, lockfile.Dependencies); f {
 			// lockfile version >6.0
 			targetVersion = sp
 			dependencyVersion = v
 			isDirect = true
+		} else {
+			// the dependencies of workspace packages are attributed to the first one that declares them
+			for _, path := range importerPaths {
+				importer := lockfile.Importers[path]
+
+				if sp, v, f := getVersionInfo(name, importer.Dependencies, importer.OptionalDependencies, importer.DevDependencies); f {
+					targetVersion = sp
+					dependencyVersion = v
+					isDirect = true
+
+					if path != "." {
+						origin = path
+					}
+
+					break
+				}
+			}
 		}
 
 		// Sanitize the target/dependency version
@@ -279,9 +472,14 @@ func parsePnpmLock(lockfile PnpmLockfile) []PackageDetails {
 			Commit:         commit,
 			DepGroups:      depGroups,
 			IsDirect:       isDirect,
+			Origin:         origin,
 		})
 	}
 
+	if opts.IncludeWorkspacePackages {
+		packages = append(packages, pnpmLinkedWorkspacePackages(workspaceDeps)...)
+	}
+
 	return packages
 }
 
@@ -293,30 +491,63 @@ func (e PnpmLockExtractor) ShouldExtract(path string) bool {
 	return filepath.Base(path) == "pnpm-lock.yaml"
 }
 
+func (e PnpmLockExtractor) SupportedDepGroups() []string {
+	return []string{"dev", pnpmWorkspaceGroup}
+}
+
 func (e PnpmLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
-	var parsedLockfile *PnpmLockfile
+	return e.ExtractWithOptions(f, ExtractOptions{})
+}
+
+// ExtractWithOptions extracts the packages of the lockfile, omitting the packages of the
+// workspace that other packages of it depend on unless IncludeWorkspacePackages is set
+func (e PnpmLockExtractor) ExtractWithOptions(f DepFile, opts ExtractOptions) ([]PackageDetails, error) {
+	var packages []PackageDetails
+
+	decoder := yaml.NewDecoder(f)
 
-	err := yaml.NewDecoder(f).Decode(&parsedLockfile)
+	// lockfiles can have multiple documents, such as one for the package manager
+	// itself that comes before the one for the dependencies of the project
+	for {
+		var parsedLockfile *PnpmLockfile
 
-	if err != nil && !errors.Is(err, io.EOF) {
-		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+		err := decoder.Decode(&parsedLockfile)
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+		}
+
+		// this will happen if the document is empty
+		if parsedLockfile == nil {
+			continue
+		}
+
+		packages = append(packages, parsePnpmLock(*parsedLockfile, opts)...)
 	}
 
-	// this will happen if the file is empty
-	if parsedLockfile == nil {
-		parsedLockfile = &PnpmLockfile{}
+	filtered := make([]PackageDetails, 0, len(packages))
+	for _, pkg := range packages {
+		if !opts.excludes(pkg) {
+			filtered = append(filtered, pkg)
+		}
 	}
 
-	return parsePnpmLock(*parsedLockfile), nil
+	return filtered, nil
 }
 
+var _ ExtractorWithOptions = PnpmLockExtractor{}
+
 var PnpmExtractor = PnpmLockExtractor{
 	WithMatcher{Matcher: PackageJSONMatcher{}},
 }
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("pnpm-lock.yaml", PnpmExtractor)
+	registerExtractor("pnpm-lock.yaml", NpmEcosystem, PnpmExtractor)
 }
 
 func ParsePnpmLock(pathToLockfile string) ([]PackageDetails, error) {
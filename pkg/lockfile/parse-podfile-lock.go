@@ -0,0 +1,132 @@
+package lockfile
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/cachedregexp"
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
+)
+
+const CocoaPodsEcosystem Ecosystem = "CocoaPods"
+
+// PodfileLockfile contains the required dependency information from a Podfile.lock,
+// where each pod is either a plain "Name (version)" entry or a mapping of that
+// entry to the pods it depends on
+type PodfileLockfile struct {
+	Pods []yaml.Node `yaml:"PODS"`
+}
+
+// parsePodSpec splits an entry like "AFNetworking/Serialization (4.0.1)" into the
+// name of its pod and its version, as subspecs are released as part of their parent
+func parsePodSpec(spec string) (string, string, bool) {
+	re := cachedregexp.MustCompile(`^([^\s(]+) \(([^)]+)\)$`)
+
+	matches := re.FindStringSubmatch(strings.TrimSpace(spec))
+
+	if matches == nil {
+		return "", "", false
+	}
+
+	name, _, _ := strings.Cut(matches[1], "/")
+
+	return name, matches[2], true
+}
+
+// lastLineOfYAMLNode returns the line of the last scalar within the node
+func lastLineOfYAMLNode(node *yaml.Node) int {
+	if len(node.Content) == 0 {
+		return node.Line
+	}
+
+	return lastLineOfYAMLNode(node.Content[len(node.Content)-1])
+}
+
+type PodfileLockExtractor struct{}
+
+func (e PodfileLockExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "Podfile.lock"
+}
+
+func (e PodfileLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	var parsedLockfile *PodfileLockfile
+
+	contentBytes, err := io.ReadAll(f)
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	if err := yaml.Unmarshal(contentBytes, &parsedLockfile); err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+	}
+
+	if parsedLockfile == nil {
+		return []PackageDetails{}, nil
+	}
+
+	lines := fileposition.BytesToLines(contentBytes)
+	packages := make(map[string]PackageDetails, len(parsedLockfile.Pods))
+
+	for i := range parsedLockfile.Pods {
+		pod := &parsedLockfile.Pods[i]
+		spec := pod
+
+		// pods with dependencies are a mapping of the pod to a list of them, which
+		// are restated as top-level pods themselves and so can be skipped
+		if pod.Kind == yaml.MappingNode && len(pod.Content) > 0 {
+			spec = pod.Content[0]
+		}
+
+		if spec.Kind != yaml.ScalarNode {
+			continue
+		}
+
+		name, version, ok := parsePodSpec(spec.Value)
+
+		if !ok {
+			continue
+		}
+
+		// subspecs of the same pod share its version, so only the first is kept
+		if _, exists := packages[name+"@"+version]; exists {
+			continue
+		}
+
+		startLine := spec.Line
+		endLine := lastLineOfYAMLNode(pod)
+
+		packages[name+"@"+version] = PackageDetails{
+			Name:           name,
+			Version:        version,
+			PackageManager: models.CocoaPods,
+			Ecosystem:      CocoaPodsEcosystem,
+			CompareAs:      CocoaPodsEcosystem,
+			BlockLocation: models.FilePosition{
+				Line: models.Position{Start: startLine, End: endLine},
+				Column: models.Position{
+					Start: fileposition.GetFirstNonEmptyCharacterIndexInLine(lines[startLine-1]),
+					End:   fileposition.GetLastNonEmptyCharacterIndexInLine(lines[endLine-1]),
+				},
+				Filename: f.Path(),
+			},
+		}
+	}
+
+	return maps.Values(packages), nil
+}
+
+var _ Extractor = PodfileLockExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("Podfile.lock", CocoaPodsEcosystem, PodfileLockExtractor{})
+}
+
+func ParsePodfileLock(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, PodfileLockExtractor{})
+}
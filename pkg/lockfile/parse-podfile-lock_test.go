@@ -0,0 +1,189 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestPodfileLockExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "Podfile.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/Podfile.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/Podfile.lock/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/Podfile.lock.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.Podfile.lock",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.PodfileLockExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePodfileLock_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePodfileLock("fixtures/cocoapods/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePodfileLock_InvalidYaml(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePodfileLock("fixtures/cocoapods/not-yaml.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePodfileLock_NoPods(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePodfileLock("fixtures/cocoapods/empty.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePodfileLock_OnePod(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/cocoapods/one-pod.lock"))
+	packages, err := lockfile.ParsePodfileLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "Alamofire",
+			Version:        "5.9.1",
+			PackageManager: models.CocoaPods,
+			Ecosystem:      lockfile.CocoaPodsEcosystem,
+			CompareAs:      lockfile.CocoaPodsEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 3, End: 22},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParsePodfileLock_Subspecs(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/cocoapods/subspecs.lock"))
+	packages, err := lockfile.ParsePodfileLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "AFNetworking",
+			Version:        "4.0.1",
+			PackageManager: models.CocoaPods,
+			Ecosystem:      lockfile.CocoaPodsEcosystem,
+			CompareAs:      lockfile.CocoaPodsEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 2, End: 7},
+				Column:   models.Position{Start: 3, End: 35},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "GoogleUtilities",
+			Version:        "7.13.3",
+			PackageManager: models.CocoaPods,
+			Ecosystem:      lockfile.CocoaPodsEcosystem,
+			CompareAs:      lockfile.CocoaPodsEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 17, End: 18},
+				Column:   models.Position{Start: 3, End: 35},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "PromisesObjC",
+			Version:        "2.4.0",
+			PackageManager: models.CocoaPods,
+			Ecosystem:      lockfile.CocoaPodsEcosystem,
+			CompareAs:      lockfile.CocoaPodsEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 19, End: 19},
+				Column:   models.Position{Start: 3, End: 25},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "SDWebImage",
+			Version:        "5.19.1",
+			PackageManager: models.CocoaPods,
+			Ecosystem:      lockfile.CocoaPodsEcosystem,
+			CompareAs:      lockfile.CocoaPodsEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 20, End: 21},
+				Column:   models.Position{Start: 3, End: 33},
+				Filename: path,
+			},
+		},
+	})
+}
@@ -36,6 +36,10 @@ func (e PoetryLockExtractor) ShouldExtract(path string) bool {
 	return filepath.Base(path) == "poetry.lock"
 }
 
+func (e PoetryLockExtractor) SupportedDepGroups() []string {
+	return []string{"optional"}
+}
+
 func (e PoetryLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 	var parsedLockfile *PoetryLockFile
 
@@ -71,7 +75,7 @@ var PoetryExtractor = PoetryLockExtractor{
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("poetry.lock", PoetryExtractor)
+	registerExtractor("poetry.lock", PipEcosystem, PoetryExtractor)
 }
 
 func ParsePoetryLock(pathToLockfile string) ([]PackageDetails, error) {
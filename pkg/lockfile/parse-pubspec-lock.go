@@ -73,35 +73,49 @@ func (e PubspecLockExtractor) ShouldExtract(path string) bool {
 	return filepath.Base(path) == "pubspec.lock"
 }
 
+func (e PubspecLockExtractor) SupportedDepGroups() []string {
+	return []string{"dev"}
+}
+
 func (e PubspecLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
-	var parsedLockfile *PubspecLockfile
+	packages := []PackageDetails{}
 
-	err := yaml.NewDecoder(f).Decode(&parsedLockfile)
+	decoder := yaml.NewDecoder(f)
 
-	if err != nil && !errors.Is(err, io.EOF) {
-		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
-	}
-	if parsedLockfile == nil {
-		return []PackageDetails{}, nil
-	}
+	// every document of the lockfile is read, in case it has been split into multiple
+	for {
+		var parsedLockfile *PubspecLockfile
+
+		err := decoder.Decode(&parsedLockfile)
 
-	packages := make([]PackageDetails, 0, len(parsedLockfile.Packages))
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+		}
 
-	for name, pkg := range parsedLockfile.Packages {
-		pkgDetails := PackageDetails{
-			Name:           name,
-			Version:        pkg.Version,
-			Commit:         pkg.Description.Ref,
-			PackageManager: models.Pub,
-			Ecosystem:      PubEcosystem,
+		if parsedLockfile == nil {
+			continue
 		}
-		for _, str := range strings.Split(pkg.Dependency, " ") {
-			if str == "dev" {
-				pkgDetails.DepGroups = append(pkgDetails.DepGroups, "dev")
-				break
+
+		for name, pkg := range parsedLockfile.Packages {
+			pkgDetails := PackageDetails{
+				Name:           name,
+				Version:        pkg.Version,
+				Commit:         pkg.Description.Ref,
+				PackageManager: models.Pub,
+				Ecosystem:      PubEcosystem,
+			}
+			for _, str := range strings.Split(pkg.Dependency, " ") {
+				if str == "dev" {
+					pkgDetails.DepGroups = append(pkgDetails.DepGroups, "dev")
+					break
+				}
 			}
+			packages = append(packages, pkgDetails)
 		}
-		packages = append(packages, pkgDetails)
 	}
 
 	return packages, nil
@@ -111,7 +125,7 @@ var _ Extractor = PubspecLockExtractor{}
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("pubspec.lock", PubspecLockExtractor{})
+	registerExtractor("pubspec.lock", PubEcosystem, PubspecLockExtractor{})
 }
 
 func ParsePubspecLock(pathToLockfile string) ([]PackageDetails, error) {
@@ -205,6 +205,32 @@ func TestParsePubspecLock_MixedPackages(t *testing.T) {
 	})
 }
 
+func TestParsePubspecLock_MultipleDocuments(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePubspecLock("fixtures/pub/multiple-documents.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "back_button_interceptor",
+			Version:        "6.0.1",
+			PackageManager: models.Pub,
+			Ecosystem:      lockfile.PubEcosystem,
+		},
+		{
+			Name:           "build_runner",
+			Version:        "2.2.1",
+			PackageManager: models.Pub,
+			Ecosystem:      lockfile.PubEcosystem,
+			DepGroups:      []string{"dev"},
+		},
+	})
+}
+
 func TestParsePubspecLock_PackageWithGitSource(t *testing.T) {
 	t.Parallel()
 
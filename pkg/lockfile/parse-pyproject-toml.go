@@ -0,0 +1,325 @@
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/cachedregexp"
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+
+	"github.com/BurntSushi/toml"
+)
+
+// pyProjectArrayMatcher matches the start of an array of requirements, like
+// `dependencies = [` in the [project] table, or `test = [` in the
+// [project.optional-dependencies] table
+var pyProjectArrayMatcher = cachedregexp.MustCompile(`^\s*("[^"]+"|'[^']+'|[\w.-]+)\s*=\s*\[`)
+
+// pyProjectString is a string within an array, along with the
+// byte indexes of where it starts and ends including its quotes
+type pyProjectString struct {
+	value string
+	start int
+	end   int
+}
+
+// scanPyProjectArray returns the strings in the given line of an array, starting
+// from the given byte index, along with if the array is closed on the line
+func scanPyProjectArray(line string, i int) ([]pyProjectString, bool) {
+	var values []pyProjectString
+
+	for ; i < len(line); i++ {
+		switch line[i] {
+		case '"', '\'':
+			end := strings.IndexByte(line[i+1:], line[i])
+			if end == -1 {
+				return values, false
+			}
+
+			end += i + 1
+			values = append(values, pyProjectString{value: line[i+1 : end], start: i, end: end + 1})
+			i = end
+		case ']':
+			return values, true
+		case '#':
+			return values, false
+		}
+	}
+
+	return values, false
+}
+
+// parsePyProjectRequirement parses a requirement as defined by PEP 508, like
+// "requests[security] >= 2.8.1 ; python_version < '3.8'", returning the name of
+// the package along with its version and the full version specifier
+func parsePyProjectRequirement(requirement string) (string, string, []string) {
+	requirement, _, _ = strings.Cut(requirement, ";")
+
+	i := strings.IndexAny(requirement, "<>=!~(@[ \t")
+	if i == -1 {
+		return normalizedRequirementName(requirement), "", nil
+	}
+
+	name := requirement[:i]
+	rest := strings.TrimSpace(requirement[i:])
+
+	if strings.HasPrefix(rest, "[") {
+		_, rest, _ = strings.Cut(rest, "]")
+		rest = strings.TrimSpace(rest)
+	}
+
+	// requirements on a url do not have a version
+	if strings.HasPrefix(rest, "@") {
+		return normalizedRequirementName(name), "", nil
+	}
+
+	version, targetVersions := requirementSpecifierVersion(parseRequirementSpecifier(rest))
+
+	return normalizedRequirementName(name), version, targetVersions
+}
+
+// parsePoetryConstraint parses a version constraint of a dependency that is declared
+// for Poetry, which in addition to the operators of PEP 440 can be an exact version
+// like "1.2.3", or a caret or tilde requirement like "^1.2" which are taken to be
+// their minimum version
+func parsePoetryConstraint(constraint string) (string, []string) {
+	constraint = strings.TrimSpace(constraint)
+
+	switch {
+	case constraint == "" || constraint == "*":
+		return "", nil
+	case strings.HasPrefix(constraint, "^"),
+		strings.HasPrefix(constraint, "~") && !strings.HasPrefix(constraint, "~="):
+		return strings.TrimSpace(strings.TrimLeft(constraint, "^~")), []string{constraint}
+	case constraint[0] >= '0' && constraint[0] <= '9':
+		return constraint, nil
+	}
+
+	return requirementSpecifierVersion(parseRequirementSpecifier(constraint))
+}
+
+// parsePoetryDependency returns the version constraint of a dependency that is
+// declared for Poetry, which is either just the constraint or a table that might
+// not have a constraint, such as when the dependency is a path or a git repository,
+// along with whether the dependency is optional
+func parsePoetryDependency(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, false
+	case map[string]any:
+		version, _ := v["version"].(string)
+		optional, _ := v["optional"].(bool)
+
+		return version, optional
+	}
+
+	return "", false
+}
+
+// parsePoetryTableHeader returns the dependency group that the given table
+// header is for, returning false if it is not for a Poetry dependency table
+func parsePoetryTableHeader(header string) (string, bool) {
+	switch header {
+	case "tool.poetry.dependencies":
+		return "", true
+	case "tool.poetry.dev-dependencies":
+		return "dev", true
+	}
+
+	if group, found := strings.CutPrefix(header, "tool.poetry.group."); found {
+		if group, found = strings.CutSuffix(group, ".dependencies"); found {
+			return strings.Trim(group, `"'`), true
+		}
+	}
+
+	return "", false
+}
+
+func newPyProjectPosition(line string, lineNumber int, start, end int, path string) models.FilePosition {
+	return models.FilePosition{
+		Line: models.Position{Start: lineNumber, End: lineNumber},
+		Column: models.Position{
+			Start: fileposition.ColumnOfByteIndex(line, start),
+			End:   fileposition.ColumnOfByteIndex(line, end),
+		},
+		Filename: path,
+	}
+}
+
+// PyProjectExtractor extracts the dependencies declared in a pyproject.toml, keeping their
+// version specifiers as they are written, which is useful for libraries as they
+// typically do not commit a lockfile.
+//
+// Both the dependencies of the [project] table as defined by PEP 621 and those declared
+// in the tables of Poetry are extracted, with dependencies that are optional or that
+// are part of a group having the name of it as their DepGroups.
+//
+// Nothing is extracted if there is a poetry.lock, pdm.lock, or uv.lock next to the pyproject.toml.
+type PyProjectExtractor struct{}
+
+func (e PyProjectExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "pyproject.toml"
+}
+
+func (e PyProjectExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	if hasSiblingLockfile(f, "poetry.lock", "pdm.lock", "uv.lock") {
+		return []PackageDetails{}, nil
+	}
+
+	packages := make([]PackageDetails, 0)
+	scanner := bufio.NewScanner(f)
+
+	table := ""
+	lineNumber := 0
+
+	// the group of the array of requirements that is being read, if any
+	inArray := false
+	arrayGroup := ""
+
+	addRequirements := func(line string, values []pyProjectString) {
+		for _, value := range values {
+			name, version, targetVersions := parsePyProjectRequirement(value.value)
+
+			if name == "" {
+				continue
+			}
+
+			pkg := PackageDetails{
+				Name:           name,
+				Version:        version,
+				TargetVersions: targetVersions,
+				PackageManager: models.Requirements,
+				Ecosystem:      PipEcosystem,
+				CompareAs:      PipEcosystem,
+				BlockLocation:  newPyProjectPosition(line, lineNumber, value.start, value.end, f.Path()),
+				IsDirect:       true,
+			}
+
+			if arrayGroup != "" {
+				pkg.DepGroups = []string{arrayGroup}
+			}
+
+			packages = append(packages, pkg)
+		}
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNumber++
+
+		if inArray {
+			values, closed := scanPyProjectArray(line, 0)
+			addRequirements(line, values)
+			inArray = !closed
+
+			continue
+		}
+
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "[") {
+			header := strings.Trim(trimmed[:strings.LastIndex(trimmed, "]")+1], "[]")
+			table = strings.TrimSpace(header)
+
+			continue
+		}
+
+		switch table {
+		case "project", "project.optional-dependencies":
+			match := pyProjectArrayMatcher.FindStringSubmatchIndex(line)
+			if match == nil {
+				continue
+			}
+
+			key := strings.Trim(line[match[2]:match[3]], `"'`)
+
+			if table == "project" {
+				if key != "dependencies" {
+					continue
+				}
+
+				arrayGroup = ""
+			} else {
+				arrayGroup = key
+			}
+
+			values, closed := scanPyProjectArray(line, match[1])
+			addRequirements(line, values)
+			inArray = !closed
+		default:
+			group, ok := parsePoetryTableHeader(table)
+			if !ok {
+				continue
+			}
+
+			var dependency map[string]any
+
+			// values that continue onto the following lines are not supported,
+			// so they are skipped rather than causing the whole file to fail
+			if _, err := toml.Decode(line, &dependency); err != nil {
+				continue
+			}
+
+			for key, value := range dependency {
+				// this is the version of Python itself that is required
+				if key == "python" {
+					continue
+				}
+
+				constraint, optional := parsePoetryDependency(value)
+				version, targetVersions := parsePoetryConstraint(constraint)
+
+				pkg := PackageDetails{
+					Name:           normalizedRequirementName(key),
+					Version:        version,
+					TargetVersions: targetVersions,
+					PackageManager: models.Poetry,
+					Ecosystem:      PipEcosystem,
+					CompareAs:      PipEcosystem,
+					BlockLocation: newPyProjectPosition(
+						line,
+						lineNumber,
+						len(line)-len(strings.TrimLeft(line, " \t")),
+						len(strings.TrimRight(line, " \t")),
+						f.Path(),
+					),
+					IsDirect: true,
+				}
+
+				if group != "" {
+					pkg.DepGroups = append(pkg.DepGroups, group)
+				}
+
+				if optional {
+					pkg.DepGroups = append(pkg.DepGroups, "optional")
+				}
+
+				packages = append(packages, pkg)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return []PackageDetails{}, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = PyProjectExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("pyproject.toml", PipEcosystem, PyProjectExtractor{})
+}
+
+func ParsePyProjectToml(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, PyProjectExtractor{})
+}
@@ -0,0 +1,356 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestPyProjectExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "pyproject.toml",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/pyproject.toml",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/pyproject.toml/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/pyproject.toml.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.pyproject.toml",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.PyProjectExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePyProjectToml_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePyProjectToml("fixtures/pyproject/does-not-exist/pyproject.toml")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePyProjectToml_NotToml(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePyProjectToml("fixtures/pyproject/not-toml/pyproject.toml")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePyProjectToml_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePyProjectToml("fixtures/pyproject/empty/pyproject.toml")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePyProjectToml_Pep621(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/pyproject/pep621/pyproject.toml"))
+	packages, err := lockfile.ParsePyProjectToml(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "requests",
+			Version:        "2.28.1",
+			TargetVersions: []string{">=2.28.1"},
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 3, End: 33},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "django",
+			Version:        "3.2",
+			TargetVersions: []string{">=3.2,<4"},
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 3, End: 19},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "attrs",
+			Version:        "22.1.0",
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 3, End: 18},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "tomli",
+			Version:        "",
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 3, End: 35},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "pip",
+			Version:        "",
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 11, End: 11},
+				Column:   models.Position{Start: 3, End: 56},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "pytest",
+			Version:        "7.0",
+			TargetVersions: []string{">=7.0"},
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			DepGroups:      []string{"test"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 15, End: 15},
+				Column:   models.Position{Start: 9, End: 22},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "coverage",
+			Version:        "7.2",
+			TargetVersions: []string{"~=7.2"},
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			DepGroups:      []string{"test"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 15, End: 15},
+				Column:   models.Position{Start: 24, End: 45},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "sphinx",
+			Version:        "7.1.2",
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			DepGroups:      []string{"docs"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 17, End: 17},
+				Column:   models.Position{Start: 3, End: 18},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestParsePyProjectToml_Poetry(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/pyproject/poetry/pyproject.toml"))
+	packages, err := lockfile.ParsePyProjectToml(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "numpy",
+			Version:        "1.23.3",
+			PackageManager: models.Poetry,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 9, End: 9},
+				Column:   models.Position{Start: 1, End: 17},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "requests",
+			Version:        "2.28",
+			TargetVersions: []string{"^2.28"},
+			PackageManager: models.Poetry,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 10, End: 10},
+				Column:   models.Position{Start: 1, End: 19},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "flask",
+			Version:        "2.2",
+			TargetVersions: []string{">=2.2,<3"},
+			PackageManager: models.Poetry,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			DepGroups:      []string{"optional"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 11, End: 11},
+				Column:   models.Position{Start: 1, End: 50},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "mylib",
+			Version:        "",
+			PackageManager: models.Poetry,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 12, End: 12},
+				Column:   models.Position{Start: 1, End: 30},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "pytest",
+			Version:        "7.4",
+			TargetVersions: []string{"~7.4"},
+			PackageManager: models.Poetry,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			DepGroups:      []string{"test"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 15, End: 15},
+				Column:   models.Position{Start: 1, End: 16},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+		{
+			Name:           "black",
+			Version:        "",
+			PackageManager: models.Poetry,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			DepGroups:      []string{"dev"},
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 18, End: 18},
+				Column:   models.Position{Start: 1, End: 12},
+				Filename: path,
+			},
+			IsDirect: true,
+		},
+	})
+}
+
+func TestParsePyProjectToml_WithLockfile(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePyProjectToml("fixtures/pyproject/with-lockfile/pyproject.toml")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	// the poetry.lock next to the pyproject.toml is extracted instead
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePyProjectToml_WithUvLock(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePyProjectToml("fixtures/pyproject/with-uv-lock/pyproject.toml")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	// the uv.lock next to the pyproject.toml is extracted instead
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
@@ -59,7 +59,7 @@ var _ Extractor = RenvLockExtractor{}
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("renv.lock", RenvLockExtractor{})
+	registerExtractor("renv.lock", CRANEcosystem, RenvLockExtractor{})
 }
 
 func ParseRenvLock(pathToLockfile string) ([]PackageDetails, error) {
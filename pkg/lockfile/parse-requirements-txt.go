@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/google/osv-scanner/internal/utility/fileposition"
@@ -15,6 +16,72 @@ import (
 
 const PipEcosystem Ecosystem = "PyPI"
 
+// requirementSpecifierClause is a single clause of a version specifier, like ">= 1.0"
+type requirementSpecifierClause struct {
+	operator string
+	version  string
+}
+
+// parseRequirementSpecifier parses the clauses of the version specifier at the
+// start of the given string, like ">=3.2, <4", stopping at the first thing that
+// is not part of the specifier, such as options or comments that come after it
+func parseRequirementSpecifier(specifier string) []requirementSpecifierClause {
+	var clauses []requirementSpecifierClause
+
+	re := cachedregexp.MustCompile(`^\s*(===|==|~=|!=|<=|>=|<|>)\s*([^\s,;)]+)\s*`)
+	specifier = strings.TrimPrefix(strings.TrimSpace(specifier), "(")
+
+	for {
+		match := re.FindStringSubmatch(specifier)
+		if match == nil {
+			break
+		}
+
+		clauses = append(clauses, requirementSpecifierClause{operator: match[1], version: match[2]})
+		specifier = specifier[len(match[0]):]
+
+		if !strings.HasPrefix(specifier, ",") {
+			break
+		}
+
+		specifier = specifier[1:]
+	}
+
+	return clauses
+}
+
+// requirementSpecifierVersion returns the version that a requirement with the given
+// specifier is taken to be, which is the version it is pinned to if there is one,
+// or otherwise its minimum version so that packages with only a lower bound are
+// still checked, along with the full specifier when it is not just a single pin
+func requirementSpecifierVersion(clauses []requirementSpecifierClause) (string, []string) {
+	version := ""
+
+	for _, clause := range clauses {
+		if clause.operator == "==" || clause.operator == "===" {
+			version = clause.version
+
+			break
+		}
+
+		if version == "" && (clause.operator == ">=" || clause.operator == "~=") {
+			version = clause.version
+		}
+	}
+
+	if len(clauses) == 0 || (len(clauses) == 1 && (clauses[0].operator == "==" || clauses[0].operator == "===")) {
+		return version, nil
+	}
+
+	specifiers := make([]string, 0, len(clauses))
+
+	for _, clause := range clauses {
+		specifiers = append(specifiers, clause.operator+clause.version)
+	}
+
+	return version, []string{strings.Join(specifiers, ",")}
+}
+
 // todo: expand this to support more things, e.g.
 //
 //	https://pip.pypa.io/en/stable/reference/requirements-file-format/#example
@@ -23,40 +90,22 @@ func parseLine(path string, line string, lineNumber int, lineOffset int, columnS
 	// pre https://pip.pypa.io/en/stable/reference/requirement-specifiers/#overview
 	line = strings.Split(line, ";")[0]
 
-	var constraint string
 	name := line
-
 	version := ""
 
-	if strings.Contains(line, "==") {
-		constraint = "=="
-	}
+	var targetVersions []string
 
-	if strings.Contains(line, ">=") {
-		constraint = ">="
-	}
-
-	if strings.Contains(line, "~=") {
-		constraint = "~="
-	}
+	// the name is followed by either a version specifier or a url
+	if i := strings.IndexAny(line, "<>=!~(@"); i != -1 {
+		name = strings.TrimSpace(line[:i])
 
-	if strings.Contains(line, "!=") {
-		constraint = "!="
-	}
-
-	if constraint != "" {
-		unprocessedName, unprocessedVersion, _ := strings.Cut(line, constraint)
-		name = strings.TrimSpace(unprocessedName)
-
-		if constraint != "!=" {
-			version, _, _ = strings.Cut(strings.TrimSpace(unprocessedVersion), " ")
-		}
-	} else if strings.Contains(line, "@") {
-		unprocessedName, unprocessedFileLocation, _ := strings.Cut(line, "@")
-		name = strings.TrimSpace(unprocessedName)
-		fileLocation := strings.TrimSpace(unprocessedFileLocation)
-		if strings.HasSuffix(fileLocation, ".whl") {
-			version = extractVersionFromWheelURL(fileLocation)
+		if line[i] == '@' {
+			fileLocation := strings.TrimSpace(line[i+1:])
+			if strings.HasSuffix(fileLocation, ".whl") {
+				version = extractVersionFromWheelURL(fileLocation)
+			}
+		} else {
+			version, targetVersions = requirementSpecifierVersion(parseRequirementSpecifier(line[i:]))
 		}
 	}
 
@@ -80,6 +129,7 @@ func parseLine(path string, line string, lineNumber int, lineOffset int, columnS
 	return PackageDetails{
 		Name:            normalizedRequirementName(name),
 		Version:         version,
+		TargetVersions:  targetVersions,
 		BlockLocation:   blockLocation,
 		NameLocation:    nameLocation,
 		VersionLocation: versionLocation,
@@ -127,6 +177,74 @@ func isNotRequirementLine(line string) bool {
 		strings.HasPrefix(line, "/")
 }
 
+// requirementsIndexURL returns the url of the index that packages are resolved
+// from if the line is an option that sets it, which applies to the whole file
+func requirementsIndexURL(line string) (string, bool) {
+	var re = cachedregexp.MustCompile(`^(?:-i|--index-url)(?:\s*=\s*|\s+)(\S+)$`)
+
+	match := re.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+
+	return match[1], true
+}
+
+// requirementsConstraintsFile returns the path of the constraints file that the
+// line references if it is an option that does so, like "-c constraints.txt"
+func requirementsConstraintsFile(line string) (string, bool) {
+	var re = cachedregexp.MustCompile(`^(?:-c|--constraint)(?:\s*=\s*|\s+)(\S+)$`)
+
+	match := re.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+
+	return match[1], true
+}
+
+// applyRequirementsConstraints gives the packages the version that they are pinned to
+// by the constraints files if there is one, as that is the version pip will install,
+// merging any packages that end up being the same as a result
+func applyRequirementsConstraints(packages []PackageDetails, constraints map[string]PackageDetails) []PackageDetails {
+	if len(constraints) == 0 {
+		return packages
+	}
+
+	constrained := make(map[string]PackageDetails, len(packages))
+	keys := make([]string, 0, len(packages))
+
+	for _, pkg := range packages {
+		if constraint, ok := constraints[pkg.Name]; ok {
+			pkg.Version = constraint.Version
+			pkg.VersionLocation = constraint.VersionLocation
+		}
+
+		existing, ok := constrained[pkg.Key()]
+		if !ok {
+			constrained[pkg.Key()] = pkg
+			keys = append(keys, pkg.Key())
+
+			continue
+		}
+
+		for _, group := range pkg.DepGroups {
+			if !slices.Contains(existing.DepGroups, group) {
+				existing.DepGroups = append(existing.DepGroups, group)
+			}
+		}
+
+		constrained[pkg.Key()] = existing
+	}
+
+	merged := make([]PackageDetails, 0, len(keys))
+	for _, key := range keys {
+		merged = append(merged, constrained[key])
+	}
+
+	return merged
+}
+
 func isLineContinuation(line string) bool {
 	// checks that the line ends with an odd number of back slashes,
 	// meaning the last one isn't escaped
@@ -157,10 +275,20 @@ func (e RequirementsTxtExtractor) ShouldExtract(path string) bool {
 }
 
 func (e RequirementsTxtExtractor) Extract(f DepFile) ([]PackageDetails, error) {
-	return parseRequirementsTxt(f, map[string]struct{}{})
+	constraints := map[string]PackageDetails{}
+
+	packages, err := parseRequirementsTxt(f, map[string]struct{}{}, constraints)
+	if err != nil {
+		return packages, err
+	}
+
+	return applyRequirementsConstraints(packages, constraints), nil
 }
 
-func parseRequirementsTxt(f DepFile, requiredAlready map[string]struct{}) ([]PackageDetails, error) {
+// parseRequirementsTxt parses the packages of the requirements file along with those
+// of the files that it includes, adding the versions that packages are pinned to by
+// constraints files to the given constraints, which apply to every file like in pip
+func parseRequirementsTxt(f DepFile, requiredAlready map[string]struct{}, constraints map[string]PackageDetails) ([]PackageDetails, error) {
 	packages := map[string]PackageDetails{}
 
 	group := strings.TrimSuffix(filepath.Base(f.Path()), filepath.Ext(f.Path()))
@@ -176,6 +304,7 @@ func parseRequirementsTxt(f DepFile, requiredAlready map[string]struct{}) ([]Pac
 
 	scanner := bufio.NewScanner(f)
 	var lineNumber, lineOffset, columnStart, columnEnd int
+	var indexURL string
 
 	for scanner.Scan() {
 		lineNumber += lineOffset + 1
@@ -217,14 +346,14 @@ func parseRequirementsTxt(f DepFile, requiredAlready map[string]struct{}) ([]Pac
 
 				requiredAlready[af.Path()] = struct{}{}
 
-				details, err := parseRequirementsTxt(af, requiredAlready)
+				details, err := parseRequirementsTxt(af, requiredAlready, constraints)
 
 				if err != nil {
 					return fmt.Errorf("failed to include %s: %w", line, err)
 				}
 
 				for _, detail := range details {
-					packages[detail.Name+"@"+detail.Version] = detail
+					packages[detail.Key()] = detail
 				}
 
 				return nil
@@ -237,6 +366,25 @@ func parseRequirementsTxt(f DepFile, requiredAlready map[string]struct{}) ([]Pac
 			continue
 		}
 
+		if url, ok := requirementsIndexURL(line); ok {
+			indexURL = url
+
+			continue
+		}
+
+		if path, ok := requirementsConstraintsFile(line); ok {
+			if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+				// If the constraints file is not locally stored, we skip it
+				continue
+			}
+
+			if err := parseRequirementsConstraints(f, path, requiredAlready, constraints); err != nil {
+				return []PackageDetails{}, fmt.Errorf("failed to include %s: %w", line, err)
+			}
+
+			continue
+		}
+
 		if isNotRequirementLine(line) {
 			continue
 		}
@@ -244,7 +392,7 @@ func parseRequirementsTxt(f DepFile, requiredAlready map[string]struct{}) ([]Pac
 		columnEnd = fileposition.GetLastNonEmptyCharacterIndexInLine(lastLine)
 
 		detail := parseLine(f.Path(), line, lineNumber, lineOffset, columnStart, columnEnd)
-		key := detail.Name + "@" + detail.Version
+		key := detail.Key()
 		if _, ok := packages[key]; !ok {
 			packages[key] = detail
 		}
@@ -259,14 +407,71 @@ func parseRequirementsTxt(f DepFile, requiredAlready map[string]struct{}) ([]Pac
 		return []PackageDetails{}, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
 	}
 
+	// packages from included files keep the index that those files set, if any
+	if registryURL := PipEcosystem.nonDefaultRegistryURL(indexURL); registryURL != "" {
+		for key, detail := range packages {
+			if detail.RegistryURL == "" {
+				detail.RegistryURL = registryURL
+				packages[key] = detail
+			}
+		}
+	}
+
 	return maps.Values(packages), nil
 }
 
+// parseRequirementsConstraints parses the constraints file at the given path relative
+// to the requirements file, adding the versions that it pins packages to to the
+// constraints. Packages that are only in constraints files are not required by
+// them, so they are never extracted themselves
+func parseRequirementsConstraints(f DepFile, path string, requiredAlready map[string]struct{}, constraints map[string]PackageDetails) error {
+	cf, err := f.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer cf.Close()
+
+	// constraints files are tracked separately from the requirements files
+	// that have been included, as a file can be used as both
+	key := "-c " + cf.Path()
+
+	if _, ok := requiredAlready[key]; ok {
+		return nil
+	}
+
+	requiredAlready[key] = struct{}{}
+
+	details, err := parseRequirementsTxt(cf, requiredAlready, constraints)
+	if err != nil {
+		return err
+	}
+
+	// pip refuses to install packages that are pinned to several versions, so which
+	// of them wins does not matter as long as it is always the same one
+	slices.SortFunc(details, func(a, b PackageDetails) int {
+		return strings.Compare(a.Key(), b.Key())
+	})
+
+	for _, detail := range details {
+		// only constraints which pin a package to a version decide what is installed
+		if detail.Version == "" || detail.TargetVersions != nil {
+			continue
+		}
+
+		if _, ok := constraints[detail.Name]; !ok {
+			constraints[detail.Name] = detail
+		}
+	}
+
+	return nil
+}
+
 var _ Extractor = RequirementsTxtExtractor{}
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("requirements.txt", RequirementsTxtExtractor{})
+	registerExtractor("requirements.txt", PipEcosystem, RequirementsTxtExtractor{})
 }
 
 func ParseRequirementsTxt(pathToLockfile string) ([]PackageDetails, error) {
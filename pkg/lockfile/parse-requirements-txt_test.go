@@ -763,6 +763,7 @@ func TestParseRequirementsTxt_FileFormatExample(t *testing.T) {
 		{
 			Name:           "keyring",
 			Version:        "4.1.1",
+			TargetVersions: []string{">=4.1.1"},
 			PackageManager: models.Requirements,
 			Ecosystem:      lockfile.PipEcosystem,
 			CompareAs:      lockfile.PipEcosystem,
@@ -786,6 +787,7 @@ func TestParseRequirementsTxt_FileFormatExample(t *testing.T) {
 		{
 			Name:           "coverage",
 			Version:        "",
+			TargetVersions: []string{"!=3.5"},
 			PackageManager: models.Requirements,
 			Ecosystem:      lockfile.PipEcosystem,
 			CompareAs:      lockfile.PipEcosystem,
@@ -804,6 +806,7 @@ func TestParseRequirementsTxt_FileFormatExample(t *testing.T) {
 		{
 			Name:           "mopidy-dirble",
 			Version:        "1.1",
+			TargetVersions: []string{"~=1.1"},
 			PackageManager: models.Requirements,
 			Ecosystem:      lockfile.PipEcosystem,
 			CompareAs:      lockfile.PipEcosystem,
@@ -1634,6 +1637,7 @@ func TestParseRequirementsTxt_WithPerRequirementOptions(t *testing.T) {
 		{
 			Name:           "barproject",
 			Version:        "1.2",
+			TargetVersions: []string{">=1.2"},
 			PackageManager: models.Requirements,
 			Ecosystem:      lockfile.PipEcosystem,
 			CompareAs:      lockfile.PipEcosystem,
@@ -1924,3 +1928,326 @@ func TestParseRequirementsTxt_WhlUrlPackages(t *testing.T) {
 		},
 	})
 }
+
+func TestParseRequirementsTxt_VersionSpecifiers(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/pip/version-specifiers.txt"))
+	packages, err := lockfile.ParseRequirementsTxt(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "django",
+			Version:        "3.2",
+			TargetVersions: []string{">=3.2,<4"},
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 1, End: 1},
+				Column:   models.Position{Start: 1, End: 15},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 1, End: 1},
+				Column:   models.Position{Start: 1, End: 7},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 1, End: 1},
+				Column:   models.Position{Start: 9, End: 12},
+				Filename: path,
+			},
+			DepGroups: []string{"version-specifiers"},
+		},
+		{
+			Name:           "requests",
+			Version:        "2.31",
+			TargetVersions: []string{"~=2.31"},
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 1, End: 17},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 1, End: 9},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 13, End: 17},
+				Filename: path,
+			},
+			DepGroups: []string{"version-specifiers"},
+		},
+		{
+			Name:           "urllib3",
+			Version:        "1.26",
+			TargetVersions: []string{">=1.26,!=1.26.5,<3"},
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 1, End: 57},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 1, End: 8},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 12, End: 16},
+				Filename: path,
+			},
+			DepGroups: []string{"version-specifiers"},
+		},
+		{
+			Name:           "numpy",
+			Version:        "",
+			TargetVersions: []string{"<2"},
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 1, End: 8},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 1, End: 6},
+				Filename: path,
+			},
+			DepGroups: []string{"version-specifiers"},
+		},
+		{
+			Name:           "attrs",
+			Version:        "22.1",
+			TargetVersions: []string{">=22.1"},
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 1, End: 15},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 1, End: 6},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 10, End: 14},
+				Filename: path,
+			},
+			DepGroups: []string{"version-specifiers"},
+		},
+		{
+			Name:           "flask",
+			Version:        "2.3.3",
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 1, End: 38},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 1, End: 6},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 8, End: 13},
+				Filename: path,
+			},
+			DepGroups: []string{"version-specifiers"},
+		},
+		{
+			Name:           "jinja2",
+			Version:        "3.1.2",
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 1, End: 15},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 1, End: 7},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 7, End: 7},
+				Column:   models.Position{Start: 10, End: 15},
+				Filename: path,
+			},
+			DepGroups: []string{"version-specifiers"},
+		},
+	})
+}
+
+func TestParseRequirementsTxt_WithIndexURL(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseRequirementsTxt("fixtures/pip/with-index-url.txt")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "boto3",
+			Version:        "1.26.121",
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			DepGroups:      []string{"with-index-url"},
+			RegistryURL:    "https://pypi.example.com/simple",
+		},
+		{
+			Name:           "foo",
+			Version:        "1.0.0",
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			DepGroups:      []string{"with-index-url"},
+			RegistryURL:    "https://pypi.example.com/simple",
+		},
+	})
+}
+
+func TestParseRequirementsTxt_WithDefaultIndexURL(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseRequirementsTxt("fixtures/pip/with-default-index-url.txt")
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "boto3",
+			Version:        "1.26.121",
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			DepGroups:      []string{"with-default-index-url"},
+		},
+	})
+}
+
+func TestParseRequirementsTxt_WithCOption(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/pip/with-c-option.txt"))
+	constraintsPath := filepath.FromSlash(filepath.Join(dir, "fixtures/pip/constraints.txt"))
+
+	packages, err := lockfile.ParseRequirementsTxt(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "requests",
+			Version:        "2.31.0",
+			TargetVersions: []string{">=2.0"},
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 1, End: 14},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 1, End: 9},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 1, End: 1},
+				Column:   models.Position{Start: 11, End: 17},
+				Filename: constraintsPath,
+			},
+			DepGroups: []string{"with-c-option"},
+		},
+		{
+			Name:           "urllib3",
+			Version:        "2.0.7",
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 1, End: 8},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 1, End: 8},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 10, End: 15},
+				Filename: constraintsPath,
+			},
+			DepGroups: []string{"with-c-option"},
+		},
+		{
+			Name:           "django",
+			Version:        "",
+			TargetVersions: []string{"<5"},
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 1, End: 9},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 6, End: 6},
+				Column:   models.Position{Start: 1, End: 7},
+				Filename: path,
+			},
+			DepGroups: []string{"with-c-option"},
+		},
+	})
+}
+
+func TestParseRequirementsTxt_WithBadCOption(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseRequirementsTxt("fixtures/pip/with-bad-c-option.txt")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
@@ -0,0 +1,229 @@
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/pkg/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StackLockPackageLocation is where a package comes from, which is either Hackage,
+// with "hackage" being like "name-version@sha256:<hash>,<size>", or a git repository
+// or archive, which are only named when the location of the package is completed
+type StackLockPackageLocation struct {
+	Hackage string `yaml:"hackage"`
+	Git     string `yaml:"git"`
+	Commit  string `yaml:"commit"`
+	URL     string `yaml:"url"`
+	Name    string `yaml:"name"`
+	Version string `yaml:"version"`
+}
+
+type StackLockPackage struct {
+	Completed StackLockPackageLocation `yaml:"completed"`
+	Original  StackLockPackageLocation `yaml:"original"`
+}
+
+type StackLockSnapshotLocation struct {
+	SHA256 string `yaml:"sha256"`
+	Size   int    `yaml:"size"`
+	URL    string `yaml:"url"`
+}
+
+// StackLockSnapshot is a snapshot of Stackage that the project resolves packages from,
+// which is not a package itself and so is not reported as one
+type StackLockSnapshot struct {
+	Completed StackLockSnapshotLocation `yaml:"completed"`
+	// Original is either the name of the snapshot like "lts-22.7"
+	// or the location of a custom snapshot
+	Original yaml.Node `yaml:"original"`
+}
+
+type StackLockfile struct {
+	// the nodes of the packages are kept so that their positions are known
+	Packages  []yaml.Node         `yaml:"packages"`
+	Snapshots []StackLockSnapshot `yaml:"snapshots"`
+}
+
+// parseStackHackage parses the name and version of a package on Hackage from
+// a location like "acme-missiles-0.3@sha256:<hash>,<size>", whose version is
+// after the last hyphen as the names of packages can also have hyphens
+func parseStackHackage(hackage string) (string, string, bool) {
+	nameAndVersion, _, _ := strings.Cut(hackage, "@")
+
+	i := strings.LastIndex(nameAndVersion, "-")
+
+	if i <= 0 || i == len(nameAndVersion)-1 {
+		return "", "", false
+	}
+
+	return nameAndVersion[:i], nameAndVersion[i+1:], true
+}
+
+// stackMappingValue returns the node of the value of the given key
+// of the mapping node, or nil if the mapping does not have that key
+func stackMappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// stackHackageNode returns the node of the Hackage location of the given package,
+// preferring the completed one that is written by newer versions of Stack
+func stackHackageNode(node *yaml.Node) *yaml.Node {
+	for _, key := range []string{"completed", "original"} {
+		location := stackMappingValue(node, key)
+
+		if location == nil {
+			continue
+		}
+
+		if hackage := stackMappingValue(location, "hackage"); hackage != nil {
+			return hackage
+		}
+	}
+
+	return nil
+}
+
+// stackHackagePositions returns the positions of the name and the version of the
+// package within its Hackage location, which are only known if it is on one line
+func stackHackagePositions(node *yaml.Node, name string, version string, path string) (*models.FilePosition, *models.FilePosition) {
+	if node == nil || node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return nil, nil
+	}
+
+	start := node.Column
+
+	if node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) != 0 {
+		start++
+	}
+
+	line := models.Position{Start: node.Line, End: node.Line}
+	versionStart := start + len(name) + 1
+
+	nameLocation := &models.FilePosition{
+		Line:     line,
+		Column:   models.Position{Start: start, End: start + len(name)},
+		Filename: path,
+	}
+	versionLocation := &models.FilePosition{
+		Line:     line,
+		Column:   models.Position{Start: versionStart, End: versionStart + len(version)},
+		Filename: path,
+	}
+
+	return nameLocation, versionLocation
+}
+
+type StackLockExtractor struct{}
+
+func (e StackLockExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "stack.yaml.lock"
+}
+
+func (e StackLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	packages := []PackageDetails{}
+
+	decoder := yaml.NewDecoder(f)
+
+	// every document of the lockfile is read, in case it has been split into multiple
+	for {
+		var parsedLockfile *StackLockfile
+
+		err := decoder.Decode(&parsedLockfile)
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+		}
+
+		if parsedLockfile == nil {
+			continue
+		}
+
+		for i := range parsedLockfile.Packages {
+			node := &parsedLockfile.Packages[i]
+
+			var pkg StackLockPackage
+
+			if err := node.Decode(&pkg); err != nil {
+				return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+			}
+
+			pkgDetails := PackageDetails{
+				PackageManager: models.Stack,
+				Ecosystem:      HackageEcosystem,
+				CompareAs:      HackageEcosystem,
+			}
+
+			hackage := pkg.Completed.Hackage
+			if hackage == "" {
+				hackage = pkg.Original.Hackage
+			}
+
+			if hackage != "" {
+				name, version, ok := parseStackHackage(hackage)
+
+				if !ok {
+					logWarningf("could not parse the Hackage package %s in %s, skipping\n", hackage, f.Path())
+
+					continue
+				}
+
+				pkgDetails.Name = name
+				pkgDetails.Version = version
+				pkgDetails.NameLocation, pkgDetails.VersionLocation = stackHackagePositions(
+					stackHackageNode(node),
+					name,
+					version,
+					f.Path(),
+				)
+			} else {
+				// packages from git repositories and archives are only named once completed
+				if pkg.Completed.Name == "" {
+					continue
+				}
+
+				pkgDetails.Name = pkg.Completed.Name
+				pkgDetails.Version = pkg.Completed.Version
+				pkgDetails.Commit = pkg.Completed.Commit
+			}
+
+			last := helmLastNode(node)
+
+			pkgDetails.BlockLocation = models.FilePosition{
+				Line:     models.Position{Start: node.Line, End: last.Line},
+				Column:   models.Position{Start: node.Column, End: helmNodeEndColumn(last)},
+				Filename: f.Path(),
+			}
+
+			packages = append(packages, pkgDetails)
+		}
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = StackLockExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("stack.yaml.lock", HackageEcosystem, StackLockExtractor{})
+}
+
+func ParseStackLock(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, StackLockExtractor{})
+}
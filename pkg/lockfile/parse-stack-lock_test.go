@@ -0,0 +1,214 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestStackLockExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "stack.yaml.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/stack.yaml.lock",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/stack.yaml.lock/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/stack.yaml.lock.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.stack.yaml.lock",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/stack.yaml",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.StackLockExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStackLock_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseStackLock("fixtures/stack/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseStackLock_InvalidYaml(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseStackLock("fixtures/stack/not-yaml.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseStackLock_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	// snapshots are not packages so should not be reported
+	packages, err := lockfile.ParseStackLock("fixtures/stack/empty.yaml.lock")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseStackLock_OnePackage(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/stack/one-package.yaml.lock"))
+	packages, err := lockfile.ParseStackLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "acme-missiles",
+			Version:        "0.3",
+			PackageManager: models.Stack,
+			Ecosystem:      lockfile.HackageEcosystem,
+			CompareAs:      lockfile.HackageEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 7, End: 13},
+				Column:   models.Position{Start: 3, End: 31},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 14, End: 27},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 28, End: 31},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseStackLock_ManyPackages(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/stack/many-packages.yaml.lock"))
+	packages, err := lockfile.ParseStackLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "aeson",
+			Version:        "2.1.2.1",
+			PackageManager: models.Stack,
+			Ecosystem:      lockfile.HackageEcosystem,
+			CompareAs:      lockfile.HackageEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 7, End: 13},
+				Column:   models.Position{Start: 3, End: 27},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 14, End: 19},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 8, End: 8},
+				Column:   models.Position{Start: 20, End: 27},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "http-client-tls",
+			Version:        "0.3.6.3",
+			PackageManager: models.Stack,
+			Ecosystem:      lockfile.HackageEcosystem,
+			CompareAs:      lockfile.HackageEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 14, End: 20},
+				Column:   models.Position{Start: 3, End: 37},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 15, End: 15},
+				Column:   models.Position{Start: 15, End: 30},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 15, End: 15},
+				Column:   models.Position{Start: 31, End: 38},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "text",
+			Version:        "2.0.2",
+			Commit:         "7f1fd3e9e1c8e6e0fd2ba2b9a5f0e8d2b3a6c4d1",
+			PackageManager: models.Stack,
+			Ecosystem:      lockfile.HackageEcosystem,
+			CompareAs:      lockfile.HackageEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 21, End: 31},
+				Column:   models.Position{Start: 3, End: 45},
+				Filename: path,
+			},
+		},
+	})
+}
@@ -0,0 +1,172 @@
+package lockfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+type SwiftResolvedPinState struct {
+	// Version is null for packages that are pinned to a branch or revision
+	Version  *string `json:"version"`
+	Revision string  `json:"revision"`
+	Branch   *string `json:"branch"`
+}
+
+type SwiftResolvedPin struct {
+	// Identity and Location are used from version 2 of the format,
+	// and Package and RepositoryURL by version 1
+	Identity      string                `json:"identity"`
+	Location      string                `json:"location"`
+	Package       string                `json:"package"`
+	RepositoryURL string                `json:"repositoryURL"`
+	State         SwiftResolvedPinState `json:"state"`
+}
+
+// the pins are kept raw so that where they are in the file can be found
+type SwiftResolvedFile struct {
+	Version int               `json:"version"`
+	Pins    []json.RawMessage `json:"pins"`
+	Object  struct {
+		Pins []json.RawMessage `json:"pins"`
+	} `json:"object"`
+}
+
+const SwiftURLEcosystem Ecosystem = "SwiftURL"
+
+// swiftPackageName returns the name of the pinned package in the SwiftURL ecosystem,
+// which is the URL of its repository without the scheme or ".git" suffix, falling
+// back to the identity of the package if it does not have a location
+func swiftPackageName(pin SwiftResolvedPin) string {
+	location := pin.Location
+	if location == "" {
+		location = pin.RepositoryURL
+	}
+
+	if location == "" {
+		if pin.Identity != "" {
+			return pin.Identity
+		}
+
+		return pin.Package
+	}
+
+	if _, after, found := strings.Cut(location, "://"); found {
+		location = after
+	} else if after, found := strings.CutPrefix(location, "git@"); found {
+		// scp-like ssh locations separate the host and path with a colon
+		location = strings.Replace(after, ":", "/", 1)
+	}
+
+	return strings.TrimSuffix(strings.TrimSuffix(location, "/"), ".git")
+}
+
+// swiftPinPosition returns the position of the given range of bytes in the content
+func swiftPinPosition(content []byte, start int, end int, path string) models.FilePosition {
+	startLine := bytes.LastIndexByte(content[:start], '\n') + 1
+	endLine := bytes.LastIndexByte(content[:end], '\n') + 1
+
+	return models.FilePosition{
+		Line: models.Position{
+			Start: bytes.Count(content[:start], []byte("\n")) + 1,
+			End:   bytes.Count(content[:end], []byte("\n")) + 1,
+		},
+		Column: models.Position{
+			Start: fileposition.ColumnOfByteIndex(string(content[startLine:start]), start-startLine),
+			End:   fileposition.ColumnOfByteIndex(string(content[endLine:end]), end-endLine),
+		},
+		Filename: path,
+	}
+}
+
+type SwiftResolvedExtractor struct{}
+
+func (e SwiftResolvedExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "Package.resolved"
+}
+
+func (e SwiftResolvedExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	content, err := io.ReadAll(f)
+
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+	}
+
+	var parsedLockfile *SwiftResolvedFile
+
+	if err := json.Unmarshal(content, &parsedLockfile); err != nil {
+		return []PackageDetails{}, newJSONParseError(f.Path(), content, err)
+	}
+
+	if parsedLockfile == nil {
+		return []PackageDetails{}, nil
+	}
+
+	var pins []json.RawMessage
+
+	switch parsedLockfile.Version {
+	case 1:
+		pins = parsedLockfile.Object.Pins
+	case 2, 3:
+		pins = parsedLockfile.Pins
+	default:
+		return []PackageDetails{}, fmt.Errorf(
+			"could not extract from %s: unsupported version %d",
+			f.Path(),
+			parsedLockfile.Version,
+		)
+	}
+
+	packages := make([]PackageDetails, 0, len(pins))
+	offset := 0
+
+	for _, raw := range pins {
+		var pin SwiftResolvedPin
+
+		if err := json.Unmarshal(raw, &pin); err != nil {
+			return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+		}
+
+		pkg := PackageDetails{
+			Name:           swiftPackageName(pin),
+			Commit:         pin.State.Revision,
+			PackageManager: models.SwiftPM,
+			Ecosystem:      SwiftURLEcosystem,
+			CompareAs:      SwiftURLEcosystem,
+		}
+
+		if pin.State.Version != nil {
+			pkg.Version = *pin.State.Version
+		}
+
+		// raw messages are the exact bytes of the pin, so they can be found in the
+		// content by searching from the end of the pin before them
+		if start := bytes.Index(content[offset:], raw); start != -1 {
+			start += offset
+			offset = start + len(raw)
+
+			pkg.BlockLocation = swiftPinPosition(content, start, offset, f.Path())
+		}
+
+		packages = append(packages, pkg)
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = SwiftResolvedExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("Package.resolved", SwiftURLEcosystem, SwiftResolvedExtractor{})
+}
+
+func ParseSwiftResolved(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, SwiftResolvedExtractor{})
+}
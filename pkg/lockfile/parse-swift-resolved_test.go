@@ -0,0 +1,231 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestSwiftResolvedExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "Package.resolved",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/Package.resolved",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/Package.resolved/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/Package.resolved.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my.Package.resolved",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.SwiftResolvedExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSwiftResolved_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseSwiftResolved("fixtures/swift/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseSwiftResolved_InvalidJson(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseSwiftResolved("fixtures/swift/not-json.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseSwiftResolved_UnsupportedVersion(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseSwiftResolved("fixtures/swift/unsupported-version.resolved")
+
+	expectErrContaining(t, err, "unsupported version 99")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseSwiftResolved_NoPins(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseSwiftResolved("fixtures/swift/empty.resolved")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseSwiftResolved_Null(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseSwiftResolved("fixtures/swift/null.resolved")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseSwiftResolved_V1(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/swift/v1.resolved"))
+	packages, err := lockfile.ParseSwiftResolved(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/apple/swift-argument-parser",
+			Version:        "1.2.2",
+			Commit:         "fee6933f37fde9a5e12a1e4aeaa93fe60116ff2a",
+			PackageManager: models.SwiftPM,
+			Ecosystem:      lockfile.SwiftURLEcosystem,
+			CompareAs:      lockfile.SwiftURLEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 12},
+				Column:   models.Position{Start: 7, End: 8},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "github.com/realm/SwiftLint",
+			Commit:         "2b7f0a5a2a6f6e4e7d8c6b8d0e7f1a2b3c4d5e6f",
+			PackageManager: models.SwiftPM,
+			Ecosystem:      lockfile.SwiftURLEcosystem,
+			CompareAs:      lockfile.SwiftURLEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 13, End: 21},
+				Column:   models.Position{Start: 7, End: 8},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseSwiftResolved_V2(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/swift/v2.resolved"))
+	packages, err := lockfile.ParseSwiftResolved(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/apple/swift-nio",
+			Version:        "2.54.0",
+			Commit:         "6213ba7a06febe8fef60563a4a7d26a4085783cf",
+			PackageManager: models.SwiftPM,
+			Ecosystem:      lockfile.SwiftURLEcosystem,
+			CompareAs:      lockfile.SwiftURLEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 3, End: 11},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "github.com/vapor/vapor",
+			Commit:         "3f7e5d1a8c2b4e6f9a0b1c2d3e4f5a6b7c8d9e0f",
+			PackageManager: models.SwiftPM,
+			Ecosystem:      lockfile.SwiftURLEcosystem,
+			CompareAs:      lockfile.SwiftURLEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 12, End: 20},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseSwiftResolved_V3(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/swift/v3.resolved"))
+	packages, err := lockfile.ParseSwiftResolved(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/apple/swift-log",
+			Version:        "1.5.4",
+			Commit:         "e97a6fcb1ab07462881ac165fdbb37f067e205d5",
+			PackageManager: models.SwiftPM,
+			Ecosystem:      lockfile.SwiftURLEcosystem,
+			CompareAs:      lockfile.SwiftURLEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 12},
+				Column:   models.Position{Start: 5, End: 6},
+				Filename: path,
+			},
+		},
+	})
+}
@@ -0,0 +1,151 @@
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/cachedregexp"
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+const TerraformEcosystem Ecosystem = "Terraform"
+
+var (
+	terraformProviderBlockMatcher = cachedregexp.MustCompile(`^\s*provider\s+"([^"]+)"\s*\{\s*$`)
+	terraformAttributeMatcher     = cachedregexp.MustCompile(`^\s*(\w+)\s*=\s*(.*)$`)
+	terraformStringMatcher        = cachedregexp.MustCompile(`"([^"]*)"`)
+)
+
+// terraformStrings returns the quoted strings in the given part of a line, such
+// as the hashes of a provider which are listed either inline or one per line
+func terraformStrings(s string) []string {
+	matches := terraformStringMatcher.FindAllStringSubmatch(s, -1)
+	strs := make([]string, 0, len(matches))
+
+	for _, match := range matches {
+		strs = append(strs, match[1])
+	}
+
+	return strs
+}
+
+// terraformLinePosition returns the position of the given byte range of the line
+func terraformLinePosition(line string, lineNumber int, start int, end int, path string) *models.FilePosition {
+	return &models.FilePosition{
+		Line: models.Position{Start: lineNumber, End: lineNumber},
+		Column: models.Position{
+			Start: fileposition.ColumnOfByteIndex(line, start),
+			End:   fileposition.ColumnOfByteIndex(line, end),
+		},
+		Filename: path,
+	}
+}
+
+type TerraformLockExtractor struct{}
+
+func (e TerraformLockExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == ".terraform.lock.hcl"
+}
+
+func (e TerraformLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	packages := make([]PackageDetails, 0)
+	scanner := bufio.NewScanner(f)
+	lineNumber := 0
+
+	// the provider block currently being read, if any
+	var provider *PackageDetails
+	inHashes := false
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNumber++
+
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "//") {
+			continue
+		}
+
+		if provider == nil {
+			match := terraformProviderBlockMatcher.FindStringSubmatchIndex(line)
+			if match == nil {
+				continue
+			}
+
+			provider = &PackageDetails{
+				Name:           line[match[2]:match[3]],
+				PackageManager: models.Terraform,
+				Ecosystem:      TerraformEcosystem,
+				CompareAs:      TerraformEcosystem,
+				BlockLocation: models.FilePosition{
+					Line:     models.Position{Start: lineNumber},
+					Column:   models.Position{Start: fileposition.GetFirstNonEmptyCharacterIndexInLine(line)},
+					Filename: f.Path(),
+				},
+				NameLocation: terraformLinePosition(line, lineNumber, match[2], match[3], f.Path()),
+			}
+
+			continue
+		}
+
+		if inHashes {
+			provider.Hashes = append(provider.Hashes, terraformStrings(line)...)
+			inHashes = !strings.Contains(line, "]")
+
+			continue
+		}
+
+		// the block of the provider is closed by a brace on its own line,
+		// as its attributes never contain nested blocks
+		if trimmed == "}" {
+			provider.BlockLocation.Line.End = lineNumber
+			provider.BlockLocation.Column.End = fileposition.GetLastNonEmptyCharacterIndexInLine(line)
+
+			packages = append(packages, *provider)
+			provider = nil
+
+			continue
+		}
+
+		match := terraformAttributeMatcher.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+
+		switch line[match[2]:match[3]] {
+		case "version":
+			version := terraformStringMatcher.FindStringSubmatchIndex(line[match[4]:])
+			if version == nil {
+				continue
+			}
+
+			start, end := match[4]+version[2], match[4]+version[3]
+
+			provider.Version = line[start:end]
+			provider.VersionLocation = terraformLinePosition(line, lineNumber, start, end, f.Path())
+		case "hashes":
+			provider.Hashes = append(provider.Hashes, terraformStrings(line[match[4]:])...)
+			inHashes = !strings.Contains(line[match[4]:], "]")
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return []PackageDetails{}, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = TerraformLockExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor(".terraform.lock.hcl", TerraformEcosystem, TerraformLockExtractor{})
+}
+
+func ParseTerraformLock(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, TerraformLockExtractor{})
+}
@@ -0,0 +1,219 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestTerraformLockExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: ".terraform.lock.hcl",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/.terraform.lock.hcl",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/.terraform.lock.hcl/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/.terraform.lock.hcl.file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path.to.my..terraform.lock.hcl",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.TerraformLockExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTerraformLock_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseTerraformLock("fixtures/terraform/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseTerraformLock_NoProviders(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseTerraformLock("fixtures/terraform/empty.hcl")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseTerraformLock_OneProvider(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/terraform/one-provider.hcl"))
+	packages, err := lockfile.ParseTerraformLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:    "registry.terraform.io/hashicorp/aws",
+			Version: "5.0.0",
+			Hashes: []string{
+				"h1:6DM4iUK1Zq/7BgLz9bOI+3aDi4L9ZuB1uOdh6mr5JxI=",
+				"zh:0a21bbc0ad8d14ff4b93b95b1f5c7bc1a5d4e6e8a3f28e0c2b0ab2be1e9d5b8c",
+			},
+			PackageManager: models.Terraform,
+			Ecosystem:      lockfile.TerraformEcosystem,
+			CompareAs:      lockfile.TerraformEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 11},
+				Column:   models.Position{Start: 1, End: 2},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 11, End: 46},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 18, End: 23},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseTerraformLock_ManyProviders(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/terraform/many-providers.hcl"))
+	packages, err := lockfile.ParseTerraformLock(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:    "registry.terraform.io/hashicorp/aws",
+			Version: "5.0.0",
+			Hashes: []string{
+				"h1:6DM4iUK1Zq/7BgLz9bOI+3aDi4L9ZuB1uOdh6mr5JxI=",
+				"zh:0a21bbc0ad8d14ff4b93b95b1f5c7bc1a5d4e6e8a3f28e0c2b0ab2be1e9d5b8c",
+			},
+			PackageManager: models.Terraform,
+			Ecosystem:      lockfile.TerraformEcosystem,
+			CompareAs:      lockfile.TerraformEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 11},
+				Column:   models.Position{Start: 1, End: 2},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 4, End: 4},
+				Column:   models.Position{Start: 11, End: 46},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 5, End: 5},
+				Column:   models.Position{Start: 18, End: 23},
+				Filename: path,
+			},
+		},
+		{
+			Name:    "registry.terraform.io/hashicorp/random",
+			Version: "3.5.1",
+			Hashes: []string{
+				"h1:VSnd9ZIPyfKHOObuQCaKfnjIHRtR7qTw19Rz8tJxm+k=",
+			},
+			PackageManager: models.Terraform,
+			Ecosystem:      lockfile.TerraformEcosystem,
+			CompareAs:      lockfile.TerraformEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 13, End: 16},
+				Column:   models.Position{Start: 1, End: 2},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 13, End: 13},
+				Column:   models.Position{Start: 11, End: 49},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 14, End: 14},
+				Column:   models.Position{Start: 14, End: 19},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "registry.opentofu.org/integrations/github",
+			Version:        "6.2.1",
+			PackageManager: models.Terraform,
+			Ecosystem:      lockfile.TerraformEcosystem,
+			CompareAs:      lockfile.TerraformEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 18, End: 21},
+				Column:   models.Position{Start: 1, End: 2},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 18, End: 18},
+				Column:   models.Position{Start: 11, End: 52},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 19, End: 19},
+				Column:   models.Position{Start: 18, End: 23},
+				Filename: path,
+			},
+		},
+	})
+}
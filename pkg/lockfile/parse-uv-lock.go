@@ -0,0 +1,284 @@
+package lockfile
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/osv-scanner/pkg/models"
+
+	"github.com/BurntSushi/toml"
+)
+
+// UvLockPackageSource is where a package is installed from, which is only
+// one of a registry, a git repository, or a local project or archive
+type UvLockPackageSource struct {
+	Registry  string `toml:"registry"`
+	Git       string `toml:"git"`
+	Editable  string `toml:"editable"`
+	Virtual   string `toml:"virtual"`
+	Path      string `toml:"path"`
+	Directory string `toml:"directory"`
+	URL       string `toml:"url"`
+}
+
+// UvLockDependency is a dependency of a package, which only has a version when
+// there are several versions of the package in the lockfile, and which can
+// require extras of the package that pull in its optional dependencies
+type UvLockDependency struct {
+	Name    string   `toml:"name"`
+	Version string   `toml:"version"`
+	Extra   []string `toml:"extra"`
+}
+
+type UvLockPackage struct {
+	Name                 string                        `toml:"name"`
+	Version              string                        `toml:"version"`
+	Source               UvLockPackageSource           `toml:"source"`
+	Dependencies         []UvLockDependency            `toml:"dependencies"`
+	OptionalDependencies map[string][]UvLockDependency `toml:"optional-dependencies"`
+	DevDependencies      map[string][]UvLockDependency `toml:"dev-dependencies"`
+}
+
+type UvLockFile struct {
+	Version  int             `toml:"version"`
+	Packages []UvLockPackage `toml:"package"`
+}
+
+const UvEcosystem = PipEcosystem
+
+// uvEditableGroup is the group of the projects that the lockfile is for, which are
+// installed in editable mode and so are not expected to be matched against vulnerabilities
+const uvEditableGroup = "editable"
+
+// commit returns the commit that a package installed from git is pinned to, which
+// uv puts in the fragment of the url of the repository, like "...?rev=v1.0#<commit>"
+func (source UvLockPackageSource) commit() string {
+	if _, commit, found := strings.Cut(source.Git, "#"); found {
+		return commit
+	}
+
+	return ""
+}
+
+// isProject checks if the package is one of the projects that the lockfile is for,
+// which are the root of the project and the members of its workspace
+func (source UvLockPackageSource) isProject() bool {
+	return source.Editable != "" || source.Virtual != ""
+}
+
+// reachable returns the indexes of the packages that are required by the given
+// dependencies, both directly and through the dependencies of those packages,
+// including the optional dependencies that are pulled in by extras
+func (file UvLockFile) reachable(deps []UvLockDependency) map[int]bool {
+	seen := make(map[int]bool)
+	seenExtras := make(map[int]map[string]bool)
+	queue := slices.Clone(deps)
+
+	for len(queue) > 0 {
+		dep := queue[0]
+		queue = queue[1:]
+
+		for i, pkg := range file.Packages {
+			if pkg.Name != dep.Name || (dep.Version != "" && pkg.Version != dep.Version) {
+				continue
+			}
+
+			if !seen[i] {
+				seen[i] = true
+				seenExtras[i] = make(map[string]bool)
+				queue = append(queue, pkg.Dependencies...)
+			}
+
+			for _, extra := range dep.Extra {
+				if !seenExtras[i][extra] {
+					seenExtras[i][extra] = true
+					queue = append(queue, pkg.OptionalDependencies[extra]...)
+				}
+			}
+		}
+	}
+
+	return seen
+}
+
+// depGroups returns the groups of each of the packages, which are worked out from
+// how they are required by the projects that the lockfile is for: packages that are
+// only required by the dev dependencies of the projects are in the "dev" group, and
+// those only required by their optional dependencies are in the "optional" group
+func (file UvLockFile) depGroups() map[int][]string {
+	var deps, devDeps, optionalDeps []UvLockDependency
+
+	for _, pkg := range file.Packages {
+		if !pkg.Source.isProject() {
+			continue
+		}
+
+		deps = append(deps, UvLockDependency{Name: pkg.Name, Version: pkg.Version})
+
+		for _, group := range pkg.DevDependencies {
+			devDeps = append(devDeps, group...)
+		}
+
+		for _, extra := range pkg.OptionalDependencies {
+			optionalDeps = append(optionalDeps, extra...)
+		}
+	}
+
+	groups := make(map[int][]string)
+
+	// without any projects, which lockfiles from older versions of uv might not
+	// have, the groups cannot be worked out so every package is treated as required
+	if len(deps) == 0 {
+		return groups
+	}
+
+	required := file.reachable(deps)
+	dev := file.reachable(devDeps)
+	optional := file.reachable(optionalDeps)
+
+	for i := range file.Packages {
+		switch {
+		case required[i]:
+		case dev[i]:
+			groups[i] = []string{"dev"}
+		case optional[i]:
+			groups[i] = []string{"optional"}
+		}
+	}
+
+	return groups
+}
+
+// tomlPackageBlocks returns the locations of each of the packages in a TOML lockfile
+// like those of uv and PDM, which start at their "[[package]]" header and include the
+// tables of the package that follow it, such as "[package.optional-dependencies]"
+func tomlPackageBlocks(path string, content []byte) []models.FilePosition {
+	var blocks []models.FilePosition
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+
+	inPackage := false
+	lineNumber := 0
+	startLine, firstLine := 0, ""
+	endLine, lastLine := 0, ""
+
+	finishPackage := func() {
+		if inPackage {
+			blocks = append(blocks, cargoTomlDependencyBlock(path, startLine, firstLine, endLine, lastLine))
+		}
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNumber++
+
+		trimmed := strings.TrimSpace(line)
+
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if trimmed == "[[package]]" {
+			finishPackage()
+
+			inPackage = true
+			startLine, firstLine = lineNumber, line
+			endLine, lastLine = lineNumber, line
+
+			continue
+		}
+
+		// any other table that is not one of the package ends it
+		if strings.HasPrefix(trimmed, "[") && !strings.HasPrefix(trimmed, "[package.") && !strings.HasPrefix(trimmed, "[[package.") {
+			finishPackage()
+
+			inPackage = false
+
+			continue
+		}
+
+		endLine, lastLine = lineNumber, line
+	}
+
+	finishPackage()
+
+	return blocks
+}
+
+type UvLockExtractor struct{}
+
+func (e UvLockExtractor) ShouldExtract(path string) bool {
+	return filepath.Base(path) == "uv.lock"
+}
+
+func (e UvLockExtractor) SupportedDepGroups() []string {
+	return []string{"dev", "optional", uvEditableGroup}
+}
+
+func (e UvLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	var parsedLockfile *UvLockFile
+
+	content, err := io.ReadAll(f)
+	if err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not read from %s: %w", f.Path(), err)
+	}
+
+	if _, err := toml.Decode(string(content), &parsedLockfile); err != nil {
+		return []PackageDetails{}, fmt.Errorf("could not extract from %s: %w", f.Path(), err)
+	}
+
+	if parsedLockfile == nil {
+		return []PackageDetails{}, nil
+	}
+
+	groups := parsedLockfile.depGroups()
+	blocks := tomlPackageBlocks(f.Path(), content)
+
+	// the blocks can only be matched up with the packages if every one was found
+	if len(blocks) != len(parsedLockfile.Packages) {
+		blocks = nil
+	}
+
+	packages := make([]PackageDetails, 0, len(parsedLockfile.Packages))
+
+	for i, lockPackage := range parsedLockfile.Packages {
+		pkgDetails := PackageDetails{
+			Name:           lockPackage.Name,
+			Version:        lockPackage.Version,
+			Commit:         lockPackage.Source.commit(),
+			RegistryURL:    UvEcosystem.nonDefaultRegistryURL(lockPackage.Source.Registry),
+			PackageManager: models.Uv,
+			Ecosystem:      UvEcosystem,
+			CompareAs:      UvEcosystem,
+			DepGroups:      groups[i],
+		}
+
+		if lockPackage.Source.isProject() {
+			pkgDetails.DepGroups = append(pkgDetails.DepGroups, uvEditableGroup)
+		}
+
+		if blocks != nil {
+			pkgDetails.BlockLocation = blocks[i]
+		}
+
+		packages = append(packages, pkgDetails)
+	}
+
+	return packages, nil
+}
+
+var _ Extractor = UvLockExtractor{}
+
+//nolint:gochecknoinits
+func init() {
+	registerExtractor("uv.lock", PipEcosystem, UvLockExtractor{})
+}
+
+func ParseUvLock(pathToLockfile string) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, UvLockExtractor{})
+}
@@ -0,0 +1,327 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+)
+
+func TestUvLockExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "empty",
+			path: "",
+			want: false,
+		},
+		{
+			name: "plain",
+			path: "uv.lock",
+			want: true,
+		},
+		{
+			name: "absolute",
+			path: "/path/to/uv.lock",
+			want: true,
+		},
+		{
+			name: "relative",
+			path: "../../uv.lock",
+			want: true,
+		},
+		{
+			name: "in-path",
+			path: "/path/with/uv.lock/in/middle",
+			want: false,
+		},
+		{
+			name: "invalid-suffix",
+			path: "uv.lock.file",
+			want: false,
+		},
+		{
+			name: "invalid-prefix",
+			path: "project.name.uv.lock",
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		tst := test
+		t.Run(tst.name, func(t *testing.T) {
+			t.Parallel()
+			ext := lockfile.UvLockExtractor{}
+			should := ext.ShouldExtract(tst.path)
+			if should != tst.want {
+				t.Errorf("ShouldExtract() - got %v, expected %v", should, tst.want)
+			}
+		})
+	}
+}
+
+func TestParseUvLock_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseUvLock("fixtures/uv/does-not-exist")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseUvLock_InvalidToml(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseUvLock("fixtures/uv/not-toml.txt")
+
+	expectErrContaining(t, err, "could not extract from")
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseUvLock_NoPackages(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseUvLock("fixtures/uv/empty.lock")
+
+	expectNilErr(t, err)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParseUvLock_OnePackage(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/uv/one-package.lock"))
+	packages, err := lockfile.ParseUvLock(path)
+
+	expectNilErr(t, err)
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "idna",
+			Version:        "3.7",
+			PackageManager: models.Uv,
+			Ecosystem:      lockfile.UvEcosystem,
+			CompareAs:      lockfile.UvEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 11},
+				Column:   models.Position{Start: 1, End: 2},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseUvLock_TwoPackages(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/uv/two-packages.lock"))
+	packages, err := lockfile.ParseUvLock(path)
+
+	expectNilErr(t, err)
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "idna",
+			Version:        "3.7",
+			PackageManager: models.Uv,
+			Ecosystem:      lockfile.UvEcosystem,
+			CompareAs:      lockfile.UvEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 11},
+				Column:   models.Position{Start: 1, End: 2},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "sniffio",
+			Version:        "1.3.1",
+			PackageManager: models.Uv,
+			Ecosystem:      lockfile.UvEcosystem,
+			CompareAs:      lockfile.UvEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 13, End: 20},
+				Column:   models.Position{Start: 1, End: 2},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseUvLock_SourceGit(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/uv/source-git.lock"))
+	packages, err := lockfile.ParseUvLock(path)
+
+	expectNilErr(t, err)
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "toml",
+			Version:        "0.10.2",
+			Commit:         "3f637dba5f68db63d4b30967fedda51c82459471",
+			PackageManager: models.Uv,
+			Ecosystem:      lockfile.UvEcosystem,
+			CompareAs:      lockfile.UvEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 7},
+				Column:   models.Position{Start: 1, End: 106},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseUvLock_SourceRegistry(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/uv/source-registry.lock"))
+	packages, err := lockfile.ParseUvLock(path)
+
+	expectNilErr(t, err)
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "idna",
+			Version:        "3.7",
+			RegistryURL:    "https://pypi.example.com/simple",
+			PackageManager: models.Uv,
+			Ecosystem:      lockfile.UvEcosystem,
+			CompareAs:      lockfile.UvEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 8},
+				Column:   models.Position{Start: 1, End: 167},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParseUvLock_Project(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/uv/project.lock"))
+	packages, err := lockfile.ParseUvLock(path)
+
+	expectNilErr(t, err)
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "anyio",
+			Version:        "4.4.0",
+			PackageManager: models.Uv,
+			Ecosystem:      lockfile.UvEcosystem,
+			CompareAs:      lockfile.UvEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 4, End: 17},
+				Column:   models.Position{Start: 1, End: 2},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "idna",
+			Version:        "3.7",
+			PackageManager: models.Uv,
+			Ecosystem:      lockfile.UvEcosystem,
+			CompareAs:      lockfile.UvEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 19, End: 23},
+				Column:   models.Position{Start: 1, End: 240},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "iniconfig",
+			Version:        "2.0.0",
+			DepGroups:      []string{"dev"},
+			PackageManager: models.Uv,
+			Ecosystem:      lockfile.UvEcosystem,
+			CompareAs:      lockfile.UvEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 25, End: 29},
+				Column:   models.Position{Start: 1, End: 245},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "my-project",
+			Version:        "0.1.0",
+			DepGroups:      []string{"editable"},
+			PackageManager: models.Uv,
+			Ecosystem:      lockfile.UvEcosystem,
+			CompareAs:      lockfile.UvEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 31, End: 56},
+				Column:   models.Position{Start: 1, End: 51},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "pytest",
+			Version:        "8.2.2",
+			DepGroups:      []string{"dev"},
+			PackageManager: models.Uv,
+			Ecosystem:      lockfile.UvEcosystem,
+			CompareAs:      lockfile.UvEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 58, End: 65},
+				Column:   models.Position{Start: 1, End: 245},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "sniffio",
+			Version:        "1.3.1",
+			PackageManager: models.Uv,
+			Ecosystem:      lockfile.UvEcosystem,
+			CompareAs:      lockfile.UvEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 67, End: 71},
+				Column:   models.Position{Start: 1, End: 244},
+				Filename: path,
+			},
+		},
+		{
+			Name:           "trio",
+			Version:        "0.25.1",
+			DepGroups:      []string{"optional"},
+			PackageManager: models.Uv,
+			Ecosystem:      lockfile.UvEcosystem,
+			CompareAs:      lockfile.UvEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 73, End: 81},
+				Column:   models.Position{Start: 1, End: 243},
+				Filename: path,
+			},
+		},
+	})
+}
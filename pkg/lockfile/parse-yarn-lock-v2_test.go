@@ -289,14 +289,6 @@ func TestParseYarnLock_v2_WithPrerelease(t *testing.T) {
 			Ecosystem:      lockfile.YarnEcosystem,
 			CompareAs:      lockfile.YarnEcosystem,
 		},
-		{
-			Name:           "eslint-plugin-jest",
-			Version:        "0.0.0-use.local",
-			PackageManager: models.Yarn,
-			TargetVersions: []string{"workspace:."},
-			Ecosystem:      lockfile.YarnEcosystem,
-			CompareAs:      lockfile.YarnEcosystem,
-		},
 	})
 }
 
@@ -331,14 +323,6 @@ func TestParseYarnLock_v2_WithBuildString(t *testing.T) {
 			Ecosystem:      lockfile.YarnEcosystem,
 			CompareAs:      lockfile.YarnEcosystem,
 		},
-		{
-			Name:           "zone.js",
-			Version:        "0.0.0-use.local",
-			PackageManager: models.Yarn,
-			TargetVersions: []string{"workspace:."},
-			Ecosystem:      lockfile.YarnEcosystem,
-			CompareAs:      lockfile.YarnEcosystem,
-		},
 	})
 }
 
@@ -486,11 +470,44 @@ func TestParseYarnLock_v2_WithAliases(t *testing.T) {
 			Ecosystem:      lockfile.YarnEcosystem,
 			CompareAs:      lockfile.YarnEcosystem,
 		},
+	})
+}
+
+func TestParseYarnLock_v2_Protocols(t *testing.T) {
+	t.Parallel()
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/yarn/protocols.v2.lock"))
+	packages, err := lockfile.ParseYarnLock(path)
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "is-core-module",
+			Version:        "2.11.0",
+			PackageManager: models.Yarn,
+			TargetVersions: []string{"^2.9.0"},
+			Ecosystem:      lockfile.YarnEcosystem,
+			CompareAs:      lockfile.YarnEcosystem,
+		},
+		{
+			Name:           "resolve",
+			Version:        "1.22.1",
+			PackageManager: models.Yarn,
+			TargetVersions: []string{"^1.20.0"},
+			Ecosystem:      lockfile.YarnEcosystem,
+			CompareAs:      lockfile.YarnEcosystem,
+		},
 		{
-			Name:           "mine",
-			Version:        "0.0.0-use.local",
+			Name:           "@types/node",
+			Version:        "18.11.9",
 			PackageManager: models.Yarn,
-			TargetVersions: []string{"workspace:."},
+			TargetVersions: []string{"18.11.9"},
 			Ecosystem:      lockfile.YarnEcosystem,
 			CompareAs:      lockfile.YarnEcosystem,
 		},
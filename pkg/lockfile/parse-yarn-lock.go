@@ -4,8 +4,8 @@ import (
 	"bufio"
 	"fmt"
 	"net/url"
-	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/google/osv-scanner/pkg/models"
@@ -90,6 +90,20 @@ func extractYarnPackageNameAndTargetVersions(str string) (string, []string) {
 		}
 		right = _right
 
+		// for yarn v2 - "patch:resolve@npm%3A^1.20.0#~builtin<compat/resolve>" patches the
+		// package it wraps, so the target versions are those of the underlying package
+		if strings.HasPrefix(right, "patch:") {
+			patched, _, _ := strings.Cut(strings.TrimPrefix(right, "patch:"), "#")
+			if unescaped, err := url.PathUnescape(patched); err == nil {
+				patched = unescaped
+			}
+
+			_, patchedTargetVersions := extractYarnPackageNameAndTargetVersions(patched)
+			targetVersions = append(targetVersions, patchedTargetVersions...)
+
+			continue
+		}
+
 		if strings.HasPrefix(right, "npm:") {
 			right = strings.TrimPrefix(right, "npm:")
 			if strings.Contains(right, "@") {
@@ -152,6 +166,23 @@ func determineYarnPackageResolution(group []string) string {
 	return ""
 }
 
+// yarnLocalProtocols are the protocols of yarn v2 resolutions that point to
+// packages within the project rather than ones that have been published
+var yarnLocalProtocols = []string{"workspace", "portal", "link"}
+
+// determineYarnResolutionProtocol returns the protocol of a yarn v2 resolution
+// like "lodash@npm:4.17.21", or an empty string if it does not have one
+func determineYarnResolutionProtocol(resolution string) string {
+	_, version := splitNpmPackageSpecifier(resolution)
+	protocol, _, found := strings.Cut(version, ":")
+
+	if !found || !cachedregexp.MustCompile(`^[a-z]+$`).MatchString(protocol) {
+		return ""
+	}
+
+	return protocol
+}
+
 func tryExtractCommit(resolution string) string {
 	// language=GoRegExp
 	matchers := []string{
@@ -210,8 +241,7 @@ func tryExtractCommit(resolution string) string {
 
 func parseYarnPackage(dependency YarnPackage) PackageDetails {
 	if dependency.Version == "" {
-		_, _ = fmt.Fprintf(
-			os.Stderr,
+		logWarningf(
 			"Failed to determine version of %s while parsing a yarn.lock - please report this!\n",
 			dependency.Name,
 		)
@@ -252,6 +282,10 @@ func (e YarnLockExtractor) Extract(f DepFile) ([]PackageDetails, error) {
 			continue
 		}
 
+		if slices.Contains(yarnLocalProtocols, determineYarnResolutionProtocol(yarnPackage.Resolution)) {
+			continue
+		}
+
 		packages = append(packages, parseYarnPackage(yarnPackage))
 	}
 
@@ -264,7 +298,7 @@ var YarnExtractor = YarnLockExtractor{
 
 //nolint:gochecknoinits
 func init() {
-	registerExtractor("yarn.lock", YarnExtractor)
+	registerExtractor("yarn.lock", NpmEcosystem, YarnExtractor)
 }
 
 func ParseYarnLock(pathToLockfile string) ([]PackageDetails, error) {
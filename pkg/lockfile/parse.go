@@ -3,6 +3,8 @@ package lockfile
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -15,6 +17,11 @@ import (
 func FindParser(pathToLockfile string, parseAs string) (PackageDetailsParser, string) {
 	if parseAs == "" {
 		parseAs = filepath.Base(pathToLockfile)
+
+		// project files are named after their project, so they are parsed based on their extension
+		if ext := strings.TrimPrefix(filepath.Ext(parseAs), "."); parsers[parseAs] == nil && parsers[ext] != nil {
+			parseAs = ext
+		}
 	}
 
 	return parsers[parseAs], parseAs
@@ -22,25 +29,47 @@ func FindParser(pathToLockfile string, parseAs string) (PackageDetailsParser, st
 
 // this is an optimisation and read-only
 var parsers = map[string]PackageDetailsParser{
-	"buildscript-gradle.lockfile": ParseGradleLock,
+	".terraform.lock.hcl":         ParseTerraformLock,
+	"buildscript-gradle.lockfile": ParseBuildscriptGradleLock,
+	"bun.lock":                    ParseBunLock,
+	"build.gradle":                ParseGradleBuild,
+	"build.gradle.kts":            ParseGradleBuild,
+	"cabal.project.freeze":        ParseCabalFreeze,
 	"Cargo.lock":                  ParseCargoLock,
+	"Cargo.toml":                  ParseCargoToml,
+	"Chart.lock":                  ParseHelmChartLock,
+	"composer.json":               ParseComposerJSON,
 	"composer.lock":               ParseComposerLock,
 	"conan.lock":                  ParseConanLock,
+	"csproj":                      ParseDotNetProj,
+	"deno.lock":                   ParseDenoLock,
+	"flake.lock":                  ParseFlakeLock,
+	"fsproj":                      ParseDotNetProj,
 	"Gemfile.lock":                ParseGemfileLock,
 	"go.mod":                      ParseGoLock,
+	"go.work":                     ParseGoWork,
 	"verification-metadata.xml":   ParseGradleVerificationMetadata,
 	"gradle.lockfile":             ParseGradleLock,
+	"ivy.xml":                     ParseIvy,
+	"maven_install.json":          ParseMavenInstall,
 	"mix.lock":                    ParseMixLock,
 	"Pipfile.lock":                ParsePipenvLock,
 	"package-lock.json":           ParseNpmLock,
+	"Package.resolved":            ParseSwiftResolved,
+	"packages.config":             ParseNuGetPackagesConfig,
 	"packages.lock.json":          ParseNuGetLock,
 	"pdm.lock":                    ParsePdmLock,
 	"pnpm-lock.yaml":              ParsePnpmLock,
+	"Podfile.lock":                ParsePodfileLock,
 	"poetry.lock":                 ParsePoetryLock,
 	"pom.xml":                     ParseMavenLock,
 	"pubspec.lock":                ParsePubspecLock,
+	"pyproject.toml":              ParsePyProjectToml,
 	"renv.lock":                   ParseRenvLock,
 	"requirements.txt":            ParseRequirementsTxt,
+	"stack.yaml.lock":             ParseStackLock,
+	"uv.lock":                     ParseUvLock,
+	"vbproj":                      ParseDotNetProj,
 	"yarn.lock":                   ParseYarnLock,
 }
 
@@ -60,6 +89,80 @@ func ListParsers() []string {
 
 var ErrParserNotFound = errors.New("could not determine parser")
 
+// ecosystemExtractorNames is the name of the registered extractor that ParseAs
+// uses for each ecosystem, which is its most common lockfile where there are several
+var ecosystemExtractorNames = map[Ecosystem]string{
+	BundlerEcosystem:   "Gemfile.lock",
+	CargoEcosystem:     "Cargo.lock",
+	CocoaPodsEcosystem: "Podfile.lock",
+	ComposerEcosystem:  "composer.lock",
+	ConanEcosystem:     "conan.lock",
+	CRANEcosystem:      "renv.lock",
+	GoEcosystem:        "go.mod",
+	HackageEcosystem:   "cabal.project.freeze",
+	HelmEcosystem:      "Chart.lock",
+	MavenEcosystem:     "pom.xml",
+	MixEcosystem:       "mix.lock",
+	NixEcosystem:       "flake.lock",
+	NpmEcosystem:       "package-lock.json",
+	NuGetEcosystem:     "packages.lock.json",
+	PipEcosystem:       "requirements.txt",
+	PubEcosystem:       "pubspec.lock",
+	SwiftURLEcosystem:  "Package.resolved",
+	TerraformEcosystem: ".terraform.lock.hcl",
+}
+
+// ParseWithExtractor extracts the packages from the given lockfile using the
+// given Extractor, without checking if the extractor supports the file's name.
+func ParseWithExtractor(pathToLockfile string, extractor Extractor) ([]PackageDetails, error) {
+	return extractFromFile(pathToLockfile, extractor)
+}
+
+// ParseAs extracts the packages from the given lockfile using the extractor of
+// the given ecosystem, without checking if the extractor supports the file's name.
+//
+// Ecosystems with several lockfile formats are parsed as their most common one,
+// such as "package-lock.json" for npm; use ParseWithExtractor to pick another.
+func ParseAs(pathToLockfile string, ecosystem Ecosystem) ([]PackageDetails, error) {
+	extractor, err := findEcosystemExtractor(ecosystem)
+	if err != nil {
+		return []PackageDetails{}, err
+	}
+
+	return ParseWithExtractor(pathToLockfile, extractor)
+}
+
+// ParseReaderAs extracts the packages from the contents of the reader using the
+// extractor of the given ecosystem, in the same way as ParseAs, with StdinPath
+// being used as the path of the file as it does not have a name.
+func ParseReaderAs(r io.Reader, ecosystem Ecosystem) ([]PackageDetails, error) {
+	extractor, err := findEcosystemExtractor(ecosystem)
+	if err != nil {
+		return []PackageDetails{}, err
+	}
+
+	return ExtractFromReader(StdinPath, r, extractor)
+}
+
+// ParseStdin extracts the packages from the contents of stdin using the extractor of
+// the given ecosystem, which is required as there is no filename to pick one from.
+func ParseStdin(ecosystem Ecosystem) ([]PackageDetails, error) {
+	return ParseReaderAs(os.Stdin, ecosystem)
+}
+
+// findEcosystemExtractor returns the registered extractor that ParseAs uses for the ecosystem
+func findEcosystemExtractor(ecosystem Ecosystem) (Extractor, error) {
+	lockfileExtractorsMu.RLock()
+	extractor, ok := lockfileExtractors[ecosystemExtractorNames[ecosystem]]
+	lockfileExtractorsMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w for ecosystem %s", ErrParserNotFound, ecosystem)
+	}
+
+	return extractor, nil
+}
+
 type Packages []PackageDetails
 
 func (ps Packages) Ecosystems() []Ecosystem {
@@ -45,23 +45,42 @@ func TestFindParser(t *testing.T) {
 	t.Parallel()
 
 	lockfiles := []string{
+		".terraform.lock.hcl",
 		"buildscript-gradle.lockfile",
+		"bun.lock",
+		"build.gradle",
+		"build.gradle.kts",
+		"cabal.project.freeze",
 		"Cargo.lock",
+		"Cargo.toml",
+		"Chart.lock",
+		"composer.json",
 		"composer.lock",
+		"deno.lock",
+		"flake.lock",
 		"Gemfile.lock",
 		"go.mod",
+		"go.work",
 		"gradle.lockfile",
+		"ivy.xml",
+		"maven_install.json",
 		"mix.lock",
 		"pdm.lock",
 		"Pipfile.lock",
 		"package-lock.json",
+		"Package.resolved",
+		"packages.config",
 		"packages.lock.json",
 		"pnpm-lock.yaml",
+		"Podfile.lock",
 		"poetry.lock",
 		"pom.xml",
 		"pubspec.lock",
+		"pyproject.toml",
 		"renv.lock",
 		"requirements.txt",
+		"stack.yaml.lock",
+		"uv.lock",
 		"yarn.lock",
 	}
 
@@ -78,6 +97,22 @@ func TestFindParser(t *testing.T) {
 	}
 }
 
+func TestFindParser_ProjectFiles(t *testing.T) {
+	t.Parallel()
+
+	for _, ext := range []string{"csproj", "fsproj", "vbproj"} {
+		parser, parsedAs := lockfile.FindParser("/path/to/my/MyApp."+ext, "")
+
+		if parser == nil {
+			t.Errorf("Expected a parser to be found for MyApp.%s but did not", ext)
+		}
+
+		if parsedAs != ext {
+			t.Errorf("Expected parsedAs to be %s but got %s instead", ext, parsedAs)
+		}
+	}
+}
+
 func TestFindParser_ExplicitParseAs(t *testing.T) {
 	t.Parallel()
 
@@ -96,25 +131,46 @@ func TestParse_FindsExpectedParsers(t *testing.T) {
 	t.Parallel()
 
 	lockfiles := []string{
+		".terraform.lock.hcl",
 		"buildscript-gradle.lockfile",
+		"bun.lock",
+		"build.gradle",
+		"cabal.project.freeze",
 		"Cargo.lock",
+		"Cargo.toml",
+		"Chart.lock",
+		"composer.json",
 		"composer.lock",
 		"conan.lock",
+		"deno.lock",
+		"flake.lock",
 		"Gemfile.lock",
 		"go.mod",
+		"go.work",
 		"gradle/verification-metadata.xml",
 		"gradle.lockfile",
+		"ivy.xml",
+		"maven_install.json",
 		"mix.lock",
+		"MyApp.csproj",
+		"MyApp.fsproj",
+		"MyApp.vbproj",
 		"Pipfile.lock",
 		"pdm.lock",
 		"package-lock.json",
+		"Package.resolved",
+		"packages.config",
 		"packages.lock.json",
 		"pnpm-lock.yaml",
+		"Podfile.lock",
 		"poetry.lock",
 		"pom.xml",
 		"pubspec.lock",
+		"pyproject.toml",
 		"renv.lock",
 		"requirements.txt",
+		"stack.yaml.lock",
+		"uv.lock",
 		"yarn.lock",
 	}
 
@@ -130,9 +186,12 @@ func TestParse_FindsExpectedParsers(t *testing.T) {
 		count++
 	}
 
-	// gradle.lockfile and buildscript-gradle.lockfile use the same parser
+	// gradle.lockfile and buildscript-gradle.lockfile are both parsed in parse-gradle-lock.go
 	count -= 1
 
+	// and all of the project files are parsed in parse-dotnet-proj.go
+	count -= 2
+
 	expectNumberOfParsersCalled(t, count)
 }
 
@@ -158,12 +217,83 @@ func TestParse_ParserNotFound_WithExplicitParseAs(t *testing.T) {
 	}
 }
 
+func TestParseWithExtractor(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseWithExtractor("fixtures/go/one-package.mod", lockfile.GoLockExtractor{})
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "github.com/BurntSushi/toml",
+			Version:        "1.0.0",
+			PackageManager: models.Golang,
+			Ecosystem:      lockfile.GoEcosystem,
+			CompareAs:      lockfile.GoEcosystem,
+			Origin:         "require",
+			IsDirect:       true,
+		},
+	})
+}
+
+func TestParseWithExtractor_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	_, err := lockfile.ParseWithExtractor("fixtures/go/does-not-exist", lockfile.GoLockExtractor{})
+
+	expectErrIs(t, err, os.ErrNotExist)
+}
+
+func TestParseAs(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParseAs("fixtures/pip/one-package-constrained.txt", lockfile.PipEcosystem)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackagesWithoutLocations(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "django",
+			Version:        "2.2.24",
+			PackageManager: models.Requirements,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			DepGroups:      []string{"one-package-constrained"},
+		},
+	})
+}
+
+func TestParseAs_KnownEcosystems(t *testing.T) {
+	t.Parallel()
+
+	for _, ecosystem := range lockfile.KnownEcosystems() {
+		_, err := lockfile.ParseAs("fixtures/does-not-exist", ecosystem)
+
+		if errors.Is(err, lockfile.ErrParserNotFound) {
+			t.Errorf("Expected to be able to parse as %s, but could not", ecosystem)
+		}
+	}
+}
+
+func TestParseAs_UnknownEcosystem(t *testing.T) {
+	t.Parallel()
+
+	_, err := lockfile.ParseAs("fixtures/go/one-package.mod", "unsupported")
+
+	expectErrIs(t, err, lockfile.ErrParserNotFound)
+}
+
 func TestListParsers(t *testing.T) {
 	t.Parallel()
 
 	parsers := lockfile.ListParsers()
 
-	firstExpected := "buildscript-gradle.lockfile"
+	firstExpected := ".terraform.lock.hcl"
 	//nolint:ifshort
 	lastExpected := "yarn.lock"
 
@@ -0,0 +1,121 @@
+package lockfile
+
+import (
+	"bufio"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// parsePythonMetadataHeader returns the location of the value of the header on
+// the given line if it has the given name, which is matched case-insensitively
+func parsePythonMetadataHeader(line string, lineNumber int, name string, path string) (string, *models.FilePosition) {
+	key, value, found := strings.Cut(line, ":")
+
+	if !found || !strings.EqualFold(strings.TrimSpace(key), name) {
+		return "", nil
+	}
+
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return "", nil
+	}
+
+	start := len(key) + 1 + strings.Index(line[len(key)+1:], value)
+
+	return value, &models.FilePosition{
+		Line: models.Position{Start: lineNumber, End: lineNumber},
+		Column: models.Position{
+			Start: fileposition.ColumnOfByteIndex(line, start),
+			End:   fileposition.ColumnOfByteIndex(line, start+len(value)),
+		},
+		Filename: path,
+	}
+}
+
+// PythonMetadataExtractor extracts the package described by the metadata of an
+// installed Python distribution, such as those found in a site-packages directory
+type PythonMetadataExtractor struct{}
+
+func (e PythonMetadataExtractor) ShouldExtract(path string) bool {
+	dir := filepath.Base(filepath.Dir(path))
+
+	switch filepath.Base(path) {
+	case "METADATA":
+		return strings.HasSuffix(dir, ".dist-info")
+	case "PKG-INFO":
+		return strings.HasSuffix(dir, ".egg-info")
+	}
+
+	return false
+}
+
+func (e PythonMetadataExtractor) Extract(f DepFile) ([]PackageDetails, error) {
+	scanner := bufio.NewScanner(f)
+	pkg := PackageDetails{
+		PackageManager: models.Unknown,
+		Ecosystem:      PipEcosystem,
+		CompareAs:      PipEcosystem,
+	}
+
+	lineNumber := 0
+	lastLine := ""
+	lastLineNumber := 0
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		lineNumber++
+
+		// the headers end at the first empty line, after which is the description
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+
+		lastLine = line
+		lastLineNumber = lineNumber
+
+		// values can be continued on the lines after them by indenting those lines
+		if strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			continue
+		}
+
+		if name, location := parsePythonMetadataHeader(line, lineNumber, "Name", f.Path()); location != nil {
+			pkg.Name = normalizedRequirementName(name)
+			pkg.NameLocation = location
+		}
+
+		if version, location := parsePythonMetadataHeader(line, lineNumber, "Version", f.Path()); location != nil {
+			pkg.Version = version
+			pkg.VersionLocation = location
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return []PackageDetails{}, fmt.Errorf("error while scanning %s: %w", f.Path(), err)
+	}
+
+	if pkg.Name == "" || pkg.Version == "" {
+		return []PackageDetails{}, nil
+	}
+
+	pkg.BlockLocation = models.FilePosition{
+		Line: models.Position{Start: 1, End: lastLineNumber},
+		Column: models.Position{
+			Start: 1,
+			End:   fileposition.ColumnOfByteIndex(lastLine, len(lastLine)),
+		},
+		Filename: f.Path(),
+	}
+
+	return []PackageDetails{pkg}, nil
+}
+
+var _ Extractor = PythonMetadataExtractor{}
+
+func ParsePythonMetadata(pathToMetadata string) ([]PackageDetails, error) {
+	return extractFromFile(pathToMetadata, PythonMetadataExtractor{})
+}
@@ -0,0 +1,205 @@
+package lockfile_test
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestPythonMetadataExtractor_ShouldExtract(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{
+			name: "",
+			path: "",
+			want: false,
+		},
+		{
+			name: "",
+			path: "METADATA",
+			want: false,
+		},
+		{
+			name: "",
+			path: "PKG-INFO",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/site-packages/requests-2.25.1.dist-info/METADATA",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/site-packages/requests-2.25.1.egg-info/PKG-INFO",
+			want: true,
+		},
+		{
+			name: "",
+			path: "path/to/my/site-packages/requests-2.25.1.dist-info/PKG-INFO",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/site-packages/requests-2.25.1.egg-info/METADATA",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/site-packages/requests-2.25.1.dist-info/METADATA/file",
+			want: false,
+		},
+		{
+			name: "",
+			path: "path/to/my/site-packages/requests-2.25.1.dist-info/RECORD",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			e := lockfile.PythonMetadataExtractor{}
+			got := e.ShouldExtract(tt.path)
+			if got != tt.want {
+				t.Errorf("Extract() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParsePythonMetadata_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePythonMetadata("fixtures/python-metadata/does-not-exist.dist-info/METADATA")
+
+	expectErrIs(t, err, fs.ErrNotExist)
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePythonMetadata_Empty(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePythonMetadata("fixtures/python-metadata/empty.dist-info/METADATA")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePythonMetadata_NotMetadata(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePythonMetadata("fixtures/python-metadata/not-metadata.dist-info/METADATA")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePythonMetadata_NoVersion(t *testing.T) {
+	t.Parallel()
+
+	packages, err := lockfile.ParsePythonMetadata("fixtures/python-metadata/no-version.dist-info/METADATA")
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{})
+}
+
+func TestParsePythonMetadata_DistInfo(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/python-metadata/markupsafe.dist-info/METADATA"))
+	packages, err := lockfile.ParsePythonMetadata(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "markupsafe",
+			Version:        "2.1.1",
+			PackageManager: models.Unknown,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 1, End: 13},
+				Column:   models.Position{Start: 1, End: 26},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 7, End: 17},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 10, End: 15},
+				Filename: path,
+			},
+		},
+	})
+}
+
+func TestParsePythonMetadata_EggInfo(t *testing.T) {
+	t.Parallel()
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/python-metadata/requests.egg-info/PKG-INFO"))
+	packages, err := lockfile.ParsePythonMetadata(path)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, packages, []lockfile.PackageDetails{
+		{
+			Name:           "requests",
+			Version:        "2.25.1",
+			PackageManager: models.Unknown,
+			Ecosystem:      lockfile.PipEcosystem,
+			CompareAs:      lockfile.PipEcosystem,
+			BlockLocation: models.FilePosition{
+				Line:     models.Position{Start: 1, End: 11},
+				Column:   models.Position{Start: 1, End: 18},
+				Filename: path,
+			},
+			NameLocation: &models.FilePosition{
+				Line:     models.Position{Start: 2, End: 2},
+				Column:   models.Position{Start: 7, End: 15},
+				Filename: path,
+			},
+			VersionLocation: &models.FilePosition{
+				Line:     models.Position{Start: 3, End: 3},
+				Column:   models.Position{Start: 10, End: 16},
+				Filename: path,
+			},
+		},
+	})
+}
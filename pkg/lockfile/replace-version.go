@@ -0,0 +1,102 @@
+package lockfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"unicode/utf8"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+var ErrInvalidLocation = errors.New("location is not valid for the file")
+var ErrVersionMismatch = errors.New("text at location does not match the expected version")
+
+// ReplaceVersionAt rewrites the version at the given location of the file at the
+// given path, leaving the rest of the file untouched.
+//
+// The location is expected to span a single line, with columns counted in runes
+// starting from 1 and the end column being exclusive, like the VersionLocation of
+// a PackageDetails. Nothing is written if the text currently at the location is
+// not the given old version, as that means the file has changed since it was parsed.
+func ReplaceVersionAt(path string, loc models.FilePosition, oldVersion, newVersion string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	start, end, err := locationToByteOffsets(content, loc)
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, path)
+	}
+
+	if current := string(content[start:end]); current != oldVersion {
+		return fmt.Errorf("%w: expected %q but found %q", ErrVersionMismatch, oldVersion, current)
+	}
+
+	patched := make([]byte, 0, len(content)-(end-start)+len(newVersion))
+	patched = append(patched, content[:start]...)
+	patched = append(patched, newVersion...)
+	patched = append(patched, content[end:]...)
+
+	return os.WriteFile(path, patched, info.Mode().Perm())
+}
+
+// locationToByteOffsets returns the offsets in the content of the first and
+// last (exclusive) bytes covered by the given single-line location
+func locationToByteOffsets(content []byte, loc models.FilePosition) (int, int, error) {
+	if loc.Line.Start < 1 || loc.Line.Start != loc.Line.End {
+		return 0, 0, ErrInvalidLocation
+	}
+
+	if loc.Column.Start < 1 || loc.Column.End < loc.Column.Start {
+		return 0, 0, ErrInvalidLocation
+	}
+
+	lineOffset := 0
+	for i := 1; i < loc.Line.Start; i++ {
+		next := bytes.IndexByte(content[lineOffset:], '\n')
+		if next == -1 {
+			return 0, 0, ErrInvalidLocation
+		}
+		lineOffset += next + 1
+	}
+
+	line := content[lineOffset:]
+	if next := bytes.IndexByte(line, '\n'); next != -1 {
+		line = line[:next]
+	}
+	line = bytes.TrimSuffix(line, []byte("\r"))
+
+	start, end := -1, -1
+	column := 1
+	for offset := 0; offset <= len(line); column++ {
+		if column == loc.Column.Start {
+			start = offset
+		}
+		if column == loc.Column.End {
+			end = offset
+
+			break
+		}
+
+		if offset == len(line) {
+			break
+		}
+
+		_, size := utf8.DecodeRune(line[offset:])
+		offset += size
+	}
+
+	if start == -1 || end == -1 {
+		return 0, 0, ErrInvalidLocation
+	}
+
+	return lineOffset + start, lineOffset + end, nil
+}
@@ -0,0 +1,123 @@
+package lockfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func copyToTempFile(t *testing.T, content []byte, name string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("could not write file: %v", err)
+	}
+
+	return path
+}
+
+func expectFileContent(t *testing.T, path string, expected string) {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read file: %v", err)
+	}
+
+	if string(content) != expected {
+		t.Errorf("Expected file to contain:\n%s\nbut got:\n%s", expected, content)
+	}
+}
+
+func TestReplaceVersionAt(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile("fixtures/go/one-package.mod")
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	path := copyToTempFile(t, content, "go.mod")
+
+	packages, err := lockfile.ParseGoLock(path)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	err = lockfile.ReplaceVersionAt(path, *packages[0].VersionLocation, "1.0.0", "1.2.3")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	expectFileContent(t, path, "module my-library\n\nrequire (\n\tgithub.com/BurntSushi/toml v1.2.3\n)\n")
+}
+
+func TestReplaceVersionAt_MultiByteCharacters(t *testing.T) {
+	t.Parallel()
+
+	path := copyToTempFile(t, []byte("{\r\n  \"ünïcödé\": \"1.0.0\"\r\n}\r\n"), "file.json")
+
+	err := lockfile.ReplaceVersionAt(path, models.FilePosition{
+		Line:   models.Position{Start: 2, End: 2},
+		Column: models.Position{Start: 15, End: 20},
+	}, "1.0.0", "10.0.0")
+
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	expectFileContent(t, path, "{\r\n  \"ünïcödé\": \"10.0.0\"\r\n}\r\n")
+}
+
+func TestReplaceVersionAt_VersionMismatch(t *testing.T) {
+	t.Parallel()
+
+	original := "{\n  \"my-package\": \"1.0.0\"\n}\n"
+	path := copyToTempFile(t, []byte(original), "file.json")
+
+	err := lockfile.ReplaceVersionAt(path, models.FilePosition{
+		Line:   models.Position{Start: 2, End: 2},
+		Column: models.Position{Start: 18, End: 23},
+	}, "2.0.0", "3.0.0")
+
+	expectErrIs(t, err, lockfile.ErrVersionMismatch)
+	expectFileContent(t, path, original)
+}
+
+func TestReplaceVersionAt_InvalidLocation(t *testing.T) {
+	t.Parallel()
+
+	original := "{\n  \"my-package\": \"1.0.0\"\n}\n"
+	path := copyToTempFile(t, []byte(original), "file.json")
+
+	locations := []models.FilePosition{
+		{Line: models.Position{Start: 2, End: 3}, Column: models.Position{Start: 18, End: 23}},
+		{Line: models.Position{Start: 10, End: 10}, Column: models.Position{Start: 18, End: 23}},
+		{Line: models.Position{Start: 2, End: 2}, Column: models.Position{Start: 18, End: 40}},
+		{Line: models.Position{Start: 2, End: 2}, Column: models.Position{Start: 0, End: 5}},
+	}
+
+	for _, location := range locations {
+		err := lockfile.ReplaceVersionAt(path, location, "1.0.0", "3.0.0")
+
+		expectErrIs(t, err, lockfile.ErrInvalidLocation)
+	}
+
+	expectFileContent(t, path, original)
+}
+
+func TestReplaceVersionAt_FileDoesNotExist(t *testing.T) {
+	t.Parallel()
+
+	err := lockfile.ReplaceVersionAt("fixtures/does-not-exist", models.FilePosition{
+		Line:   models.Position{Start: 1, End: 1},
+		Column: models.Position{Start: 1, End: 2},
+	}, "1", "2")
+
+	expectErrIs(t, err, os.ErrNotExist)
+}
@@ -1,12 +1,48 @@
 package lockfile
 
-import "github.com/google/osv-scanner/pkg/models"
+import (
+	"encoding/json"
 
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// PackageDetails describes a package that has been extracted from a lockfile.
+//
+// The Ecosystem of a package is the registry that it comes from, which is what
+// identifies the package, such as when matching it against the affected packages
+// of advisories or building its package-url. The CompareAs of a package is the
+// ecosystem whose versioning scheme is used to compare its version against the
+// ranges of advisories, which is usually the same as the Ecosystem but can differ
+// when the registry uses the versioning scheme of another ecosystem.
+//
+// The Origin of a package is the directive of the lockfile that resulted in it,
+// for lockfiles where that can differ from how the package was declared, such as
+// Go modules being "require"d and then "replace"d with another module or version,
+// so that it is clear why the version of a package is not the one that was written.
+//
+// A package is Transitive when the lockfile marks it as only being required by other
+// packages, such as by the "// indirect" comments of a go.mod, which unlike IsDirect
+// being false means it is known to not be a direct dependency.
+//
+// The Architecture of a package is the architecture that it was built for,
+// which is only known for the packages of operating systems.
+//
+// The CompareVersion of a package is the version that is compared against the ranges
+// of advisories when that differs from the Version that is shown, such as Go modules
+// having their "+incompatible" suffix collapsed, which VersionToCompare accounts for.
+//
+// The RegistryURL of a package is the registry that the lockfile pins it to being
+// resolved from, which is only set when that is not the registry that packages of
+// its ecosystem come from by default as given by Ecosystem.RegistryURL, such as
+// when the package comes from a private registry.
 type PackageDetails struct {
 	Name            string                `json:"name"`
 	Version         string                `json:"version"`
+	CompareVersion  string                `json:"compareVersion,omitempty"`
 	TargetVersions  []string              `json:"targetVersions,omitempty"`
 	Commit          string                `json:"commit,omitempty"`
+	License         string                `json:"license,omitempty"`
+	Hashes          []string              `json:"hashes,omitempty"`
 	Ecosystem       Ecosystem             `json:"ecosystem,omitempty"`
 	CompareAs       Ecosystem             `json:"compareAs,omitempty"`
 	DepGroups       []string              `json:"-"`
@@ -15,6 +51,31 @@ type PackageDetails struct {
 	NameLocation    *models.FilePosition  `json:"nameLocation,omitempty"`
 	PackageManager  models.PackageManager `json:"packageManager,omitempty"`
 	IsDirect        bool                  `json:"isDirect,omitempty"`
+	Transitive      bool                  `json:"transitive,omitempty"`
+	Origin          string                `json:"origin,omitempty"`
+	Architecture    string                `json:"architecture,omitempty"`
+	RegistryURL     string                `json:"registryURL,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// This method ensures BlockLocation is only present if it is not equal to the zero value.
+// This is achieved by embedding the PackageDetails struct with a pointer to BlockLocation
+// used to populate the "blockLocation" key in the JSON object.
+func (pkg PackageDetails) MarshalJSON() ([]byte, error) {
+	type rawPackageDetails PackageDetails // alias PackageDetails to avoid recursion during Marshal
+	type wrapper struct {
+		BlockLocation *models.FilePosition `json:"blockLocation,omitempty"`
+		rawPackageDetails
+	}
+	raw := wrapper{rawPackageDetails: rawPackageDetails(pkg)}
+	if pkg.BlockLocation == (models.FilePosition{}) {
+		raw.BlockLocation = nil
+	} else {
+		raw.BlockLocation = &(pkg.BlockLocation)
+	}
+
+	return json.Marshal(raw)
 }
 
 type Ecosystem string
@@ -25,14 +86,14 @@ type PackageDetailsParser = func(pathToLockfile string) ([]PackageDetails, error
 func (sys Ecosystem) IsDevGroup(groups []string) bool {
 	dev := ""
 	switch sys {
-	case ComposerEcosystem, NpmEcosystem, PipEcosystem, PubEcosystem:
+	case CargoEcosystem, ComposerEcosystem, NpmEcosystem, PipEcosystem, PubEcosystem:
 		// Also PnpmEcosystem(=NpmEcosystem) and PipenvEcosystem(=PipEcosystem).
 		dev = "dev"
 	case ConanEcosystem:
 		dev = "build-requires"
 	case MavenEcosystem:
 		dev = "test"
-	case AlpineEcosystem, BundlerEcosystem, CargoEcosystem, CRANEcosystem,
+	case AlpineEcosystem, BundlerEcosystem, CRANEcosystem,
 		DebianEcosystem, GoEcosystem, MixEcosystem, NuGetEcosystem:
 		// We are not able to report development dependencies for these ecosystems.
 		return false
@@ -50,3 +111,36 @@ func (sys Ecosystem) IsDevGroup(groups []string) bool {
 func (pkg PackageDetails) IsVersionEmpty() bool {
 	return pkg.Version == ""
 }
+
+// VersionToCompare returns the version that the package should be compared against
+// the ranges of advisories with, which is its CompareVersion if it has one
+func (pkg PackageDetails) VersionToCompare() string {
+	if pkg.CompareVersion != "" {
+		return pkg.CompareVersion
+	}
+
+	return pkg.Version
+}
+
+// Key returns the key that identifies the package by its ecosystem, name, version,
+// and commit, which is the same for packages regardless of where they are in a file,
+// so that it can be used for deduplicating packages.
+func (pkg PackageDetails) Key() string {
+	key := string(pkg.Ecosystem) + "/" + pkg.Name + "@" + pkg.Version
+
+	if pkg.Commit != "" {
+		key += "#" + pkg.Commit
+	}
+
+	return key
+}
+
+// SameIdentity checks if the package is the same as the other package, which is when
+// they have the same Key, ignoring where they are and the other details of how they
+// were found, such as their groups.
+//
+// This is deliberately not named Equal, as go-cmp would otherwise use it in place of
+// comparing every field of the packages.
+func (pkg PackageDetails) SameIdentity(other PackageDetails) bool {
+	return pkg.Key() == other.Key()
+}
@@ -0,0 +1,260 @@
+package lockfile_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestPackageDetails_MarshalJSON_WithoutLocations(t *testing.T) {
+	t.Parallel()
+
+	pkg := lockfile.PackageDetails{
+		Name:           "wrappy",
+		Version:        "1.0.2",
+		Ecosystem:      lockfile.NpmEcosystem,
+		CompareAs:      lockfile.NpmEcosystem,
+		PackageManager: models.NPM,
+	}
+
+	b, err := json.Marshal(pkg)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	expected := `{"name":"wrappy","version":"1.0.2","ecosystem":"npm","compareAs":"npm","packageManager":"NPM"}`
+
+	if string(b) != expected {
+		t.Errorf("Expected %s, but got %s", expected, string(b))
+	}
+
+	var roundTripped lockfile.PackageDetails
+	if err = json.Unmarshal(b, &roundTripped); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, []lockfile.PackageDetails{roundTripped}, []lockfile.PackageDetails{pkg})
+}
+
+func TestPackageDetails_MarshalJSON_WithLocations(t *testing.T) {
+	t.Parallel()
+
+	pkg := lockfile.PackageDetails{
+		Name:      "wrappy",
+		Version:   "1.0.2",
+		Ecosystem: lockfile.NpmEcosystem,
+		CompareAs: lockfile.NpmEcosystem,
+		BlockLocation: models.FilePosition{
+			Line:     models.Position{Start: 9, End: 13},
+			Column:   models.Position{Start: 5, End: 6},
+			Filename: "package-lock.json",
+		},
+		NameLocation: &models.FilePosition{
+			Line:     models.Position{Start: 9, End: 9},
+			Column:   models.Position{Start: 20, End: 26},
+			Filename: "package-lock.json",
+		},
+		PackageManager: models.NPM,
+	}
+
+	b, err := json.Marshal(pkg)
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	var roundTripped lockfile.PackageDetails
+	if err = json.Unmarshal(b, &roundTripped); err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	expectPackages(t, []lockfile.PackageDetails{roundTripped}, []lockfile.PackageDetails{pkg})
+}
+
+func TestPackageDetails_Key(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		pkg  lockfile.PackageDetails
+		want string
+	}{
+		{
+			name: "without a commit",
+			pkg: lockfile.PackageDetails{
+				Name:      "wrappy",
+				Version:   "1.0.2",
+				Ecosystem: lockfile.NpmEcosystem,
+			},
+			want: "npm/wrappy@1.0.2",
+		},
+		{
+			name: "with a commit",
+			pkg: lockfile.PackageDetails{
+				Name:      "github.com/Alamofire/Alamofire",
+				Version:   "",
+				Commit:    "f455c2975872ccd2d9c81594c658af65716e9b9a",
+				Ecosystem: lockfile.SwiftURLEcosystem,
+			},
+			want: "SwiftURL/github.com/Alamofire/Alamofire@#f455c2975872ccd2d9c81594c658af65716e9b9a",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.pkg.Key(); got != tt.want {
+				t.Errorf("Key() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageDetails_SameIdentity(t *testing.T) {
+	t.Parallel()
+
+	pkg := lockfile.PackageDetails{
+		Name:      "wrappy",
+		Version:   "1.0.2",
+		Ecosystem: lockfile.NpmEcosystem,
+		CompareAs: lockfile.NpmEcosystem,
+		BlockLocation: models.FilePosition{
+			Line:     models.Position{Start: 9, End: 13},
+			Column:   models.Position{Start: 5, End: 6},
+			Filename: "package-lock.json",
+		},
+		PackageManager: models.NPM,
+	}
+
+	tests := []struct {
+		name  string
+		other lockfile.PackageDetails
+		want  bool
+	}{
+		{
+			name: "only the locations are different",
+			other: lockfile.PackageDetails{
+				Name:      "wrappy",
+				Version:   "1.0.2",
+				Ecosystem: lockfile.NpmEcosystem,
+				CompareAs: lockfile.NpmEcosystem,
+				BlockLocation: models.FilePosition{
+					Line:     models.Position{Start: 20, End: 24},
+					Column:   models.Position{Start: 5, End: 6},
+					Filename: "other/package-lock.json",
+				},
+				NameLocation: &models.FilePosition{
+					Line:     models.Position{Start: 20, End: 20},
+					Column:   models.Position{Start: 20, End: 26},
+					Filename: "other/package-lock.json",
+				},
+				PackageManager: models.NPM,
+			},
+			want: true,
+		},
+		{
+			name: "only the groups are different",
+			other: lockfile.PackageDetails{
+				Name:           "wrappy",
+				Version:        "1.0.2",
+				Ecosystem:      lockfile.NpmEcosystem,
+				CompareAs:      lockfile.NpmEcosystem,
+				DepGroups:      []string{"dev"},
+				PackageManager: models.NPM,
+			},
+			want: true,
+		},
+		{
+			name: "the versions are different",
+			other: lockfile.PackageDetails{
+				Name:      "wrappy",
+				Version:   "1.0.1",
+				Ecosystem: lockfile.NpmEcosystem,
+				CompareAs: lockfile.NpmEcosystem,
+			},
+			want: false,
+		},
+		{
+			name: "the ecosystems are different",
+			other: lockfile.PackageDetails{
+				Name:      "wrappy",
+				Version:   "1.0.2",
+				Ecosystem: lockfile.PipEcosystem,
+				CompareAs: lockfile.PipEcosystem,
+			},
+			want: false,
+		},
+		{
+			name: "the other has a commit",
+			other: lockfile.PackageDetails{
+				Name:      "wrappy",
+				Version:   "1.0.2",
+				Commit:    "71d2ac8b1f1e0ac0a2f4e3e4a7d2c5f4ea41c5a0",
+				Ecosystem: lockfile.NpmEcosystem,
+				CompareAs: lockfile.NpmEcosystem,
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := pkg.SameIdentity(tt.other); got != tt.want {
+				t.Errorf("SameIdentity() = %t, want %t", got, tt.want)
+			}
+
+			if got := tt.other.SameIdentity(pkg); got != tt.want {
+				t.Errorf("SameIdentity() = %t, want %t (when reversed)", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageDetails_VersionToCompare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		pkg  lockfile.PackageDetails
+		want string
+	}{
+		{
+			name: "without a compare version",
+			pkg: lockfile.PackageDetails{
+				Name:      "github.com/docker/docker",
+				Version:   "20.10.24+incompatible",
+				Ecosystem: lockfile.GoEcosystem,
+				CompareAs: lockfile.GoEcosystem,
+			},
+			want: "20.10.24+incompatible",
+		},
+		{
+			name: "with a compare version",
+			pkg: lockfile.PackageDetails{
+				Name:           "github.com/docker/docker",
+				Version:        "20.10.24+incompatible",
+				CompareVersion: "20.10.24",
+				Ecosystem:      lockfile.GoEcosystem,
+				CompareAs:      lockfile.GoEcosystem,
+			},
+			want: "20.10.24",
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.pkg.VersionToCompare(); got != tt.want {
+				t.Errorf("VersionToCompare() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package lockfile
+
+import (
+	"os"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/fileposition"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// locationText returns the text that the given location spans in the lines of its
+// file, and whether it could be read, which it cannot be if it is outside of them
+func locationText(lines []string, location models.FilePosition) (string, bool) {
+	if location.Line.Start < 1 || location.Line.Start > len(lines) {
+		return "", false
+	}
+
+	// columns are counted in characters, starting from 1 and ending after the location
+	line := []rune(lines[location.Line.Start-1])
+	start, end := location.Column.Start-1, location.Column.End-1
+
+	if start < 0 || end > len(line) || start >= end {
+		return "", false
+	}
+
+	return string(line[start:end]), true
+}
+
+// normalizeLocationText normalizes the text of a location or the value that it is of,
+// so that they can be compared regardless of the quotes around the text and of how
+// extractors normalize names, such as Python packages ignoring case and separators
+func normalizeLocationText(text string) string {
+	if len(text) >= 2 && (text[0] == '"' || text[0] == '\'') && text[len(text)-1] == text[0] {
+		text = text[1 : len(text)-1]
+	}
+
+	return strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(text))
+}
+
+// locationMatches checks if the text of a location is of the given value, which it is
+// when they are the same after being normalized, or when the text is the last part of
+// the value as some extractors only point to that, like the artifact of a Maven
+// package whose group is elsewhere in the file
+func locationMatches(text string, value string) bool {
+	text = normalizeLocationText(text)
+	value = normalizeLocationText(value)
+
+	return text == value || strings.HasSuffix(value, ":"+text) || strings.HasSuffix(value, "/"+text)
+}
+
+// validateLocations checks that the name and version locations of the packages
+// are of their name and version, logging a warning for each that is not so that
+// mistakes in how extractors work out where things are can be noticed.
+//
+// Files are read again to do this, so locations in files that cannot be, such as
+// those that were read from stdin, are not checked.
+func validateLocations(packages []PackageDetails) {
+	files := make(map[string][]string)
+
+	linesOf := func(path string) []string {
+		if lines, ok := files[path]; ok {
+			return lines
+		}
+
+		var lines []string
+		if content, err := os.ReadFile(path); err == nil {
+			lines = fileposition.BytesToLines(content)
+		}
+
+		files[path] = lines
+
+		return lines
+	}
+
+	validate := func(pkg PackageDetails, kind string, location *models.FilePosition, value string) {
+		// names and versions are only ever on one line, so other locations are not checked
+		if location == nil || value == "" || location.Line.Start != location.Line.End {
+			return
+		}
+
+		lines := linesOf(location.Filename)
+		if lines == nil {
+			return
+		}
+
+		text, ok := locationText(lines, *location)
+
+		if !ok {
+			logWarningf(
+				"the %s location of %s@%s is not within %s at %d:%d-%d\n",
+				kind,
+				pkg.Name,
+				pkg.Version,
+				location.Filename,
+				location.Line.Start,
+				location.Column.Start,
+				location.Column.End,
+			)
+
+			return
+		}
+
+		if !locationMatches(text, value) {
+			logWarningf(
+				"the %s location of %s@%s in %s at %d:%d-%d is of %q rather than %q\n",
+				kind,
+				pkg.Name,
+				pkg.Version,
+				location.Filename,
+				location.Line.Start,
+				location.Column.Start,
+				location.Column.End,
+				text,
+				value,
+			)
+		}
+	}
+
+	for _, pkg := range packages {
+		validate(pkg, "name", pkg.NameLocation, pkg.Name)
+		validate(pkg, "version", pkg.VersionLocation, pkg.Version)
+	}
+}
@@ -0,0 +1,148 @@
+package lockfile_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+//nolint:paralleltest
+func TestExtractWithOptions_ValidateLocations_Valid(t *testing.T) {
+	var buffer bytes.Buffer
+
+	lockfile.SetLogWriter(&buffer)
+	t.Cleanup(func() { lockfile.SetLogWriter(nil) })
+
+	for _, tt := range []struct {
+		path      string
+		extractor lockfile.Extractor
+	}{
+		{path: "fixtures/nuget-packages-config/multiple-packages.config", extractor: lockfile.NuGetPackagesConfigExtractor{}},
+		{path: "fixtures/ivy/many-dependencies.xml", extractor: lockfile.IvyExtractor{}},
+		{path: "fixtures/helm/many-dependencies.lock", extractor: lockfile.HelmChartLockExtractor{}},
+		{path: "fixtures/pip/with-per-requirement-options.txt", extractor: lockfile.RequirementsTxtExtractor{}},
+	} {
+		f, err := lockfile.OpenLocalDepFile(tt.path)
+		if err != nil {
+			t.Fatalf("could not open file %v", err)
+		}
+
+		packages, err := lockfile.ExtractWithOptions(tt.extractor, f, lockfile.ExtractOptions{ValidateLocations: true})
+		f.Close()
+
+		if err != nil {
+			t.Errorf("Got unexpected error: %v", err)
+		}
+
+		if len(packages) == 0 {
+			t.Errorf("Expected packages to be extracted from %s", tt.path)
+		}
+	}
+
+	if buffer.String() != "" {
+		t.Errorf("Expected no warnings to be logged, but got %q", buffer.String())
+	}
+}
+
+//nolint:paralleltest
+func TestExtractWithOptions_ValidateLocations_Invalid(t *testing.T) {
+	var buffer bytes.Buffer
+
+	lockfile.SetLogWriter(&buffer)
+	t.Cleanup(func() { lockfile.SetLogWriter(nil) })
+
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	path := filepath.FromSlash(filepath.Join(dir, "fixtures/nuget-packages-config/one-package.config"))
+
+	extractor := customExtractor{
+		filename: "one-package.config",
+		packages: []lockfile.PackageDetails{
+			{
+				Name:    "Newtonsoft.Json",
+				Version: "13.0.1",
+				NameLocation: &models.FilePosition{
+					Line:     models.Position{Start: 3, End: 3},
+					Column:   models.Position{Start: 16, End: 31},
+					Filename: path,
+				},
+				// the version is one column further along than it is in the file
+				VersionLocation: &models.FilePosition{
+					Line:     models.Position{Start: 3, End: 3},
+					Column:   models.Position{Start: 43, End: 49},
+					Filename: path,
+				},
+			},
+			{
+				Name:    "NUnit",
+				Version: "3.13.3",
+				NameLocation: &models.FilePosition{
+					Line:     models.Position{Start: 3, End: 3},
+					Column:   models.Position{Start: 70, End: 100},
+					Filename: path,
+				},
+			},
+		},
+	}
+
+	_, err = lockfile.ExtractWithOptions(
+		extractor,
+		openTestDepFile(path),
+		lockfile.ExtractOptions{ValidateLocations: true},
+	)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	expected := "the version location of Newtonsoft.Json@13.0.1 in " + path + " at 3:43-49 is of \"3.0.1\\\"\" rather than \"13.0.1\"\n" +
+		"the name location of NUnit@3.13.3 is not within " + path + " at 3:70-100\n"
+
+	if buffer.String() != expected {
+		t.Errorf("Expected warnings %q to be logged, but got %q", expected, buffer.String())
+	}
+}
+
+//nolint:paralleltest
+func TestExtractWithOptions_ValidateLocations_Disabled(t *testing.T) {
+	var buffer bytes.Buffer
+
+	lockfile.SetLogWriter(&buffer)
+	t.Cleanup(func() { lockfile.SetLogWriter(nil) })
+
+	extractor := customExtractor{
+		filename: "one-package.config",
+		packages: []lockfile.PackageDetails{
+			{
+				Name:    "NUnit",
+				Version: "3.13.3",
+				NameLocation: &models.FilePosition{
+					Line:     models.Position{Start: 3, End: 3},
+					Column:   models.Position{Start: 70, End: 100},
+					Filename: "fixtures/nuget-packages-config/one-package.config",
+				},
+			},
+		},
+	}
+
+	_, err := lockfile.ExtractWithOptions(
+		extractor,
+		openTestDepFile("fixtures/nuget-packages-config/one-package.config"),
+		lockfile.ExtractOptions{},
+	)
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+
+	if buffer.String() != "" {
+		t.Errorf("Expected no warnings to be logged, but got %q", buffer.String())
+	}
+}
@@ -0,0 +1,86 @@
+package lockfile
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+var ErrInconsistentEcosystem = errors.New("package is not from the ecosystem of its source")
+var ErrMissingVersion = errors.New("package does not have a version")
+
+type ValidateSourceOptions struct {
+	// AllowEmptyVersions is whether packages without a version or a commit are
+	// allowed, such as when sources have been extracted with ExtractOptions.Verbose
+	// or include local packages like Go modules which are replaced with a directory
+	AllowEmptyVersions bool
+}
+
+// expectedEcosystemFor returns the ecosystem that the packages of the given path
+// should be from, which is only known if it is extracted by a built-in extractor
+func expectedEcosystemFor(path string) (Ecosystem, bool) {
+	lockfileExtractorsMu.RLock()
+	defer lockfileExtractorsMu.RUnlock()
+
+	_, name := findExtractorForPath(path, func(string) bool { return true })
+	ecosystem, ok := lockfileExtractorEcosystems[name]
+
+	return ecosystem, ok
+}
+
+// ecosystemName returns the name of the ecosystem without the release that
+// some ecosystems can be suffixed with, like the "v3.20" of "Alpine:v3.20"
+func ecosystemName(ecosystem string) string {
+	name, _, _ := strings.Cut(ecosystem, ":")
+
+	return name
+}
+
+// ValidateSource checks that the packages of the given source are consistent with
+// being extracted from a single lockfile, returning an error naming each package
+// that is not, which is useful for catching mistakes in how extractors work.
+//
+// Every package must be from the same ecosystem, which is that of the extractor for
+// the source if it is a built-in one, except for the "stdlib" package of Go that can
+// be part of other sources, and must have a version or commit.
+func ValidateSource(src models.PackageSource) error {
+	return ValidateSourceWithOptions(src, ValidateSourceOptions{})
+}
+
+// ValidateSourceWithOptions is like ValidateSource, but allows
+// configuring what packages are considered to be valid.
+func ValidateSourceWithOptions(src models.PackageSource, opts ValidateSourceOptions) error {
+	var errs []error
+
+	expected, known := expectedEcosystemFor(src.Source.Path)
+
+	for _, pkg := range src.Packages {
+		info := pkg.Package
+
+		if !(info.Name == "stdlib" && info.Ecosystem == string(GoEcosystem)) {
+			if !known {
+				expected, known = Ecosystem(ecosystemName(info.Ecosystem)), true
+			}
+
+			if ecosystemName(info.Ecosystem) != string(expected) {
+				errs = append(errs, fmt.Errorf(
+					"%s: %w: %s@%s is from %q rather than %q",
+					src.Source.Path,
+					ErrInconsistentEcosystem,
+					info.Name,
+					info.Version,
+					info.Ecosystem,
+					expected,
+				))
+			}
+		}
+
+		if info.Version == "" && info.Commit == "" && !opts.AllowEmptyVersions {
+			errs = append(errs, fmt.Errorf("%s: %w: %s", src.Source.Path, ErrMissingVersion, info.Name))
+		}
+	}
+
+	return errors.Join(errs...)
+}
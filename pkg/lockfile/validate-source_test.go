@@ -0,0 +1,104 @@
+package lockfile_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func newValidateSource(path string, packages ...models.PackageInfo) models.PackageSource {
+	vulns := make([]models.PackageVulns, 0, len(packages))
+
+	for _, pkg := range packages {
+		vulns = append(vulns, models.PackageVulns{Package: pkg})
+	}
+
+	return models.PackageSource{
+		Source:   models.SourceInfo{Path: path, Type: "lockfile"},
+		Packages: vulns,
+	}
+}
+
+func TestValidateSource_Valid(t *testing.T) {
+	t.Parallel()
+
+	sources, _ := lockfile.ExtractAllFromDirWithOptions(
+		context.Background(),
+		"fixtures/extract-dir",
+		lockfile.ExtractDirOptions{},
+	)
+
+	if len(sources) == 0 {
+		t.Fatalf("Expected sources to be extracted")
+	}
+
+	for _, source := range sources {
+		if err := lockfile.ValidateSource(source); err != nil {
+			t.Errorf("Got unexpected error: %v", err)
+		}
+	}
+}
+
+func TestValidateSource_Stdlib(t *testing.T) {
+	t.Parallel()
+
+	err := lockfile.ValidateSource(newValidateSource(
+		"/path/to/my/go.mod",
+		models.PackageInfo{Name: "stdlib", Version: "1.22.5", Ecosystem: string(lockfile.GoEcosystem)},
+		models.PackageInfo{Name: "github.com/BurntSushi/toml", Version: "1.0.0", Ecosystem: string(lockfile.GoEcosystem)},
+	))
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+}
+
+func TestValidateSource_InconsistentEcosystem(t *testing.T) {
+	t.Parallel()
+
+	err := lockfile.ValidateSource(newValidateSource(
+		"/path/to/my/package-lock.json",
+		models.PackageInfo{Name: "left-pad", Version: "1.3.0", Ecosystem: string(lockfile.NpmEcosystem)},
+		models.PackageInfo{Name: "requests", Version: "2.32.3", Ecosystem: string(lockfile.PipEcosystem)},
+	))
+
+	expectErrIs(t, err, lockfile.ErrInconsistentEcosystem)
+	expectErrContaining(t, err, `requests@2.32.3 is from "PyPI" rather than "npm"`)
+}
+
+func TestValidateSource_InconsistentEcosystem_UnknownSource(t *testing.T) {
+	t.Parallel()
+
+	// the first package decides the ecosystem when the source is not known
+	err := lockfile.ValidateSource(newValidateSource(
+		"/path/to/my/custom.lock",
+		models.PackageInfo{Name: "left-pad", Version: "1.3.0", Ecosystem: string(lockfile.NpmEcosystem)},
+		models.PackageInfo{Name: "requests", Version: "2.32.3", Ecosystem: string(lockfile.PipEcosystem)},
+	))
+
+	expectErrIs(t, err, lockfile.ErrInconsistentEcosystem)
+	expectErrContaining(t, err, `requests@2.32.3 is from "PyPI" rather than "npm"`)
+}
+
+func TestValidateSource_MissingVersion(t *testing.T) {
+	t.Parallel()
+
+	source := newValidateSource(
+		"/path/to/my/go.mod",
+		models.PackageInfo{Name: "github.com/my/local", Ecosystem: string(lockfile.GoEcosystem)},
+		models.PackageInfo{Name: "github.com/my/fork", Commit: "4c115873", Ecosystem: string(lockfile.GoEcosystem)},
+	)
+
+	err := lockfile.ValidateSource(source)
+
+	expectErrIs(t, err, lockfile.ErrMissingVersion)
+	expectErrContaining(t, err, "does not have a version: github.com/my/local")
+
+	err = lockfile.ValidateSourceWithOptions(source, lockfile.ValidateSourceOptions{AllowEmptyVersions: true})
+
+	if err != nil {
+		t.Errorf("Got unexpected error: %v", err)
+	}
+}
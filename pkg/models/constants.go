@@ -27,6 +27,11 @@ const (
 	EcosystemCRAN          Ecosystem = "CRAN"
 	EcosystemBioconductor  Ecosystem = "Bioconductor"
 	EcosystemSwiftURL      Ecosystem = "SwiftURL"
+	EcosystemCocoaPods     Ecosystem = "CocoaPods"
+	EcosystemHackage       Ecosystem = "Hackage"
+	EcosystemTerraform     Ecosystem = "Terraform"
+	EcosystemHelm          Ecosystem = "Helm"
+	EcosystemNix           Ecosystem = "Nix"
 )
 
 var Ecosystems = []Ecosystem{
@@ -54,6 +59,11 @@ var Ecosystems = []Ecosystem{
 	EcosystemCRAN,
 	EcosystemBioconductor,
 	EcosystemSwiftURL,
+	EcosystemCocoaPods,
+	EcosystemHackage,
+	EcosystemTerraform,
+	EcosystemHelm,
+	EcosystemNix,
 }
 
 type SeverityType string
@@ -1,5 +1,10 @@
 package models
 
+import (
+	"strconv"
+	"strings"
+)
+
 type Position struct {
 	Start int `json:"start"`
 	End   int `json:"end"`
@@ -11,6 +16,18 @@ type FilePosition struct {
 	Filename string   `json:"file_name"`
 }
 
+// Hash returns a string which uniquely identifies the position, which is the
+// same as the hash of the PackageLocation that the position is converted to
+func (p FilePosition) Hash() string {
+	return strings.Join([]string{
+		p.Filename,
+		strconv.Itoa(p.Line.Start),
+		strconv.Itoa(p.Line.End),
+		strconv.Itoa(p.Column.Start),
+		strconv.Itoa(p.Column.End),
+	}, "#")
+}
+
 type IFilePosition interface {
 	SetLineStart(position int)
 	SetColumnStart(position int)
@@ -0,0 +1,40 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+func TestFilePosition_Hash(t *testing.T) {
+	t.Parallel()
+
+	position := models.FilePosition{
+		Line:     models.Position{Start: 2, End: 4},
+		Column:   models.Position{Start: 3, End: 18},
+		Filename: "path/to/my/package-lock.json",
+	}
+
+	if hash, expected := position.Hash(), "path/to/my/package-lock.json#2#4#3#18"; hash != expected {
+		t.Errorf("Expected hash to be %s, but got %s", expected, hash)
+	}
+
+	location := models.PackageLocation{
+		Filename:    position.Filename,
+		LineStart:   position.Line.Start,
+		LineEnd:     position.Line.End,
+		ColumnStart: position.Column.Start,
+		ColumnEnd:   position.Column.End,
+	}
+
+	if position.Hash() != location.Hash() {
+		t.Errorf("Expected hash %s to be the same as the hash of the location, %s", position.Hash(), location.Hash())
+	}
+
+	moved := position
+	moved.Column.End = 19
+
+	if position.Hash() == moved.Hash() {
+		t.Errorf("Expected positions with different columns to have different hashes")
+	}
+}
@@ -12,6 +12,7 @@ const (
 	Pipfile      PackageManager = "Pipfile"
 	Pdm          PackageManager = "Pdm"
 	Poetry       PackageManager = "Poetry"
+	Uv           PackageManager = "Uv"
 	NuGet        PackageManager = "NuGet"
 	Bundler      PackageManager = "Bundler"
 	Golang       PackageManager = "Golang"
@@ -21,5 +22,16 @@ const (
 	Hex          PackageManager = "Hex"
 	Pub          PackageManager = "Pub"
 	Renv         PackageManager = "Renv"
+	Deno         PackageManager = "Deno"
+	Bun          PackageManager = "Bun"
+	CocoaPods    PackageManager = "CocoaPods"
+	Cabal        PackageManager = "Cabal"
+	Terraform    PackageManager = "Terraform"
+	Helm         PackageManager = "Helm"
+	Stack        PackageManager = "Stack"
+	SwiftPM      PackageManager = "SwiftPM"
+	Ivy          PackageManager = "Ivy"
+	Bazel        PackageManager = "Bazel"
+	Nix          PackageManager = "Nix"
 	Unknown      PackageManager = "Unknown"
 )
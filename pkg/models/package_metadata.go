@@ -5,6 +5,7 @@ type PackageMetadataType string
 const (
 	PackageManagerMetadata     PackageMetadataType = "package-manager"
 	IsDirectDependencyMetadata PackageMetadataType = "is-direct"
+	LicenseMetadata            PackageMetadataType = "license"
 )
 
 type PackageMetadata map[PackageMetadataType]string
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"path/filepath"
 	"slices"
 	"strings"
 )
@@ -98,6 +99,21 @@ func (s SourceInfo) String() string {
 	return s.Type + ":" + s.Path
 }
 
+// AbsolutePath returns the path of the source, resolving it against the
+// ScanPath if it has been made relative to the directory that was scanned
+func (s SourceInfo) AbsolutePath() string {
+	if s.ScanPath == "" || filepath.IsAbs(s.Path) {
+		return s.Path
+	}
+
+	scanPath, err := filepath.Abs(s.ScanPath)
+	if err != nil {
+		return s.Path
+	}
+
+	return filepath.Join(scanPath, filepath.FromSlash(s.Path))
+}
+
 // Vulnerabilities grouped by sources
 type PackageSource struct {
 	Source   SourceInfo     `json:"source"`
@@ -112,7 +128,10 @@ type License string
 type PackageVulns struct {
 	Package           PackageInfo        `json:"package"`
 	DepGroups         []string           `json:"dependency_groups,omitempty"`
+	Transitive        bool               `json:"transitive,omitempty"`
 	Locations         []PackageLocations `json:"locations,omitempty"`
+	Hashes            []string           `json:"hashes,omitempty"`
+	RegistryURL       string             `json:"registry_url,omitempty"`
 	Vulnerabilities   []Vulnerability    `json:"vulnerabilities,omitempty"`
 	Groups            []GroupInfo        `json:"groups,omitempty"`
 	Licenses          []License          `json:"licenses,omitempty"`
@@ -185,8 +204,9 @@ type AnalysisInfo struct {
 
 // Specific package information
 type PackageInfo struct {
-	Name      string `json:"name"`
-	Version   string `json:"version"`
-	Ecosystem string `json:"ecosystem"`
-	Commit    string `json:"commit,omitempty"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	Ecosystem    string `json:"ecosystem"`
+	Commit       string `json:"commit,omitempty"`
+	Architecture string `json:"architecture,omitempty"`
 }
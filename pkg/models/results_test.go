@@ -1,12 +1,54 @@
 package models_test
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/osv-scanner/pkg/models"
 )
 
+func TestSourceInfo_AbsolutePath(t *testing.T) {
+	t.Parallel()
+
+	scanPath, err := filepath.Abs("testdata")
+	if err != nil {
+		t.Fatalf("Got unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		source models.SourceInfo
+		want   string
+	}{
+		{
+			name:   "without a scan path",
+			source: models.SourceInfo{Path: "go.mod"},
+			want:   "go.mod",
+		},
+		{
+			name:   "relative to the scan path",
+			source: models.SourceInfo{ScanPath: "testdata", Path: "nested/go.mod"},
+			want:   filepath.Join(scanPath, "nested", "go.mod"),
+		},
+		{
+			name:   "already absolute",
+			source: models.SourceInfo{ScanPath: "testdata", Path: filepath.Join(scanPath, "go.mod")},
+			want:   filepath.Join(scanPath, "go.mod"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.source.AbsolutePath(); got != tt.want {
+				t.Errorf("AbsolutePath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestFlatten(t *testing.T) {
 	t.Parallel()
 	// Test case 1: When there are no vulnerabilities
@@ -135,7 +135,7 @@ func MakePkgRequest(pkgDetails lockfile.PackageDetails) *Query {
 	}
 
 	return &Query{
-		Version: pkgDetails.Version,
+		Version: pkgDetails.VersionToCompare(),
 		Package: Package{
 			Name:      pkgDetails.Name,
 			Ecosystem: string(pkgDetails.Ecosystem),
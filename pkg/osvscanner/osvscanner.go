@@ -380,7 +380,10 @@ func scanLockfile(r reporter.Reporter, path string, parseAs string, _ bool, enab
 		}
 	}
 
-	if err != nil {
+	// the packages of the entries that could be parsed are still scanned
+	if lockfile.OnlyEntryErrors(err) {
+		r.Warnf("%s\n", err)
+	} else if err != nil {
 		return nil, nil, err
 	}
 
@@ -407,6 +410,10 @@ func scanLockfile(r reporter.Reporter, path string, parseAs string, _ bool, enab
 			Ecosystem:      pkgDetail.Ecosystem,
 			PackageManager: pkgDetail.PackageManager,
 			IsDirect:       pkgDetail.IsDirect,
+			Transitive:     pkgDetail.Transitive,
+			License:        pkgDetail.License,
+			Hashes:         pkgDetail.Hashes,
+			RegistryURL:    pkgDetail.RegistryURL,
 			DepGroups:      pkgDetail.DepGroups,
 			Source: models.SourceInfo{
 				Path: path,
@@ -802,7 +809,11 @@ type scannedPackage struct {
 	Ecosystem       lockfile.Ecosystem
 	PackageManager  models.PackageManager
 	IsDirect        bool
+	Transitive      bool
 	Commit          string
+	License         string
+	Hashes          []string
+	RegistryURL     string
 	Version         string
 	Source          models.SourceInfo
 	DepGroups       []string
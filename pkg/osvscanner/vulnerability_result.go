@@ -27,6 +27,9 @@ func exportMetadata(rawPkg scannedPackage) map[models.PackageMetadataType]string
 	if rawPkg.IsDirect {
 		metadata[models.IsDirectDependencyMetadata] = strconv.FormatBool(rawPkg.IsDirect)
 	}
+	if rawPkg.License != "" {
+		metadata[models.LicenseMetadata] = rawPkg.License
+	}
 
 	return metadata
 }
@@ -73,11 +76,14 @@ func buildVulnerabilityResults(
 					Version:   rawPkg.Version,
 					Ecosystem: string(rawPkg.Ecosystem),
 				},
+				Hashes:   rawPkg.Hashes,
 				Metadata: exportMetadata(rawPkg),
 			}
 		}
 
 		pkg.DepGroups = rawPkg.DepGroups
+		pkg.Transitive = rawPkg.Transitive
+		pkg.RegistryURL = rawPkg.RegistryURL
 
 		if len(vulnsResp.Results[i].Vulns) > 0 {
 			includePackage = true
@@ -174,7 +180,9 @@ func groupBySource(r reporter.Reporter, packages []scannedPackage, artifacts []m
 					Version:   p.Version,
 					Ecosystem: string(p.Ecosystem),
 				},
-				Metadata: exportMetadata(p),
+				Hashes:      p.Hashes,
+				RegistryURL: p.RegistryURL,
+				Metadata:    exportMetadata(p),
 			}
 		case p.Commit != "":
 			pkg.Package.Version = p.Commit
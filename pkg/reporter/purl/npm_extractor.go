@@ -0,0 +1,21 @@
+package purl
+
+import (
+	"log"
+
+	"github.com/google/osv-scanner/internal/utility/purl"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// ExtractPURLFromNpm returns the scope of scoped packages like "@angular/core" as
+// the namespace, so that the "/" after it is not encoded as part of the name
+func ExtractPURLFromNpm(packageInfo models.PackageInfo) (namespace string, name string, ok bool) {
+	namespace, name, err := purl.FromNpm(packageInfo)
+	if err != nil {
+		log.Println(err)
+
+		return "", "", false
+	}
+
+	return namespace, name, true
+}
@@ -0,0 +1,82 @@
+package purl_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter/purl"
+)
+
+func TestNpmExtraction_shouldExtractPackages(t *testing.T) {
+	t.Parallel()
+	testCase := struct {
+		packageInfo       models.PackageInfo
+		expectedNamespace string
+		expectedName      string
+		expectedPURL      string
+	}{
+		packageInfo: models.PackageInfo{
+			Name:      "@angular/core",
+			Version:   "17.3.0",
+			Ecosystem: string(models.EcosystemNPM),
+			Commit:    "",
+		},
+		expectedNamespace: "@angular",
+		expectedName:      "core",
+		expectedPURL:      "pkg:npm/%40angular/core@17.3.0",
+	}
+
+	namespace, name, ok := purl.ExtractPURLFromNpm(testCase.packageInfo)
+
+	if !ok {
+		t.Errorf("Extraction didn't succeed, package has been wrongfully filtered")
+	}
+	if namespace != testCase.expectedNamespace {
+		t.Errorf("got %s; want %s", namespace, testCase.expectedNamespace)
+	}
+	if name != testCase.expectedName {
+		t.Errorf("got %s; want %s", name, testCase.expectedName)
+	}
+	if got := purl.From(testCase.packageInfo).ToString(); got != testCase.expectedPURL {
+		t.Errorf("got %s; want %s", got, testCase.expectedPURL)
+	}
+}
+
+func TestNpmExtraction_shouldFilterPackages(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		packageInfo models.PackageInfo
+	}{
+		{
+			name: "when_scoped_package_has_no_name",
+			packageInfo: models.PackageInfo{
+				Name:      "@angular",
+				Version:   "17.3.0",
+				Ecosystem: string(models.EcosystemNPM),
+				Commit:    "",
+			},
+		},
+		{
+			name: "when_scoped_package_has_no_scope",
+			packageInfo: models.PackageInfo{
+				Name:      "@/core",
+				Version:   "17.3.0",
+				Ecosystem: string(models.EcosystemNPM),
+				Commit:    "",
+			},
+		},
+	}
+
+	for _, test := range testCases {
+		testCase := test
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, ok := purl.ExtractPURLFromNpm(testCase.packageInfo)
+
+			if ok {
+				t.Errorf("Package %v should have been filtered\n", testCase.packageInfo)
+			}
+		})
+	}
+}
@@ -1,54 +1,32 @@
 package purl
 
 import (
+	"log"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/utility/purl"
+	"github.com/google/osv-scanner/pkg/lockfile"
 	"github.com/google/osv-scanner/pkg/models"
 	"github.com/package-url/packageurl-go"
 )
 
 type ParameterExtractor func(packageInfo models.PackageInfo) (namespace string, name string, ok bool)
 
-var ecosystemToPURLMapper = map[models.Ecosystem]string{
-	models.EcosystemMaven:       packageurl.TypeMaven,
-	models.EcosystemGo:          packageurl.TypeGolang,
-	models.EcosystemPackagist:   packageurl.TypeComposer,
-	models.EcosystemPyPI:        packageurl.TypePyPi,
-	models.EcosystemRubyGems:    packageurl.TypeGem,
-	models.EcosystemNuGet:       packageurl.TypeNuget,
-	models.EcosystemNPM:         packageurl.TypeNPM,
-	models.EcosystemConanCenter: packageurl.TypeConan,
-	models.EcosystemCratesIO:    packageurl.TypeCargo,
-	models.EcosystemPub:         "pub",
-	models.EcosystemHex:         packageurl.TypeHex,
-	models.EcosystemCRAN:        packageurl.TypeCran,
-}
-
-var ecosystemPURLExtractor = map[models.Ecosystem]ParameterExtractor{
-	models.EcosystemMaven:     ExtractPURLFromMaven,
-	models.EcosystemGo:        ExtractPURLFromGolang,
-	models.EcosystemPackagist: ExtractPURLFromComposer,
-}
-
+// From builds the package-url of the given package in the same way as the rest of
+// the scanner, with the type being from lockfile.Ecosystem.PURLType, returning nil
+// if the ecosystem does not have a type or the package cannot have a package-url
 func From(packageInfo models.PackageInfo) *packageurl.PackageURL {
-	var namespace string
-	var name string
-	version := packageInfo.Version
-	ecosystem := models.Ecosystem(packageInfo.Ecosystem)
-	purlType, typeExists := ecosystemToPURLMapper[ecosystem]
-	parameterExtractor, extractorExists := ecosystemPURLExtractor[ecosystem]
-
-	if !typeExists {
+	// packages from ecosystems without a type are expected, so are not logged
+	if base, _, _ := strings.Cut(packageInfo.Ecosystem, ":"); lockfile.Ecosystem(base).PURLType() == "" {
 		return nil
 	}
 
-	if extractorExists {
-		var ok bool
-		namespace, name, ok = parameterExtractor(packageInfo)
-		if !ok {
-			return nil
-		}
-	} else {
-		name = packageInfo.Name
+	packageURL, err := purl.From(packageInfo)
+	if err != nil {
+		log.Println(err)
+
+		return nil
 	}
 
-	return packageurl.NewPackageURL(purlType, namespace, name, version, nil, "")
+	return packageURL
 }
@@ -0,0 +1,54 @@
+package purl_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/models"
+	"github.com/google/osv-scanner/pkg/reporter/purl"
+)
+
+func TestFrom(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		packageInfo models.PackageInfo
+		want        string
+	}{
+		{
+			packageInfo: models.PackageInfo{Name: "@angular/core", Version: "1.0.0", Ecosystem: string(models.EcosystemNPM)},
+			want:        "pkg:npm/%40angular/core@1.0.0",
+		},
+		{
+			packageInfo: models.PackageInfo{Name: "curl", Version: "7.88.1", Ecosystem: "Debian:12"},
+			want:        "pkg:deb/debian/curl@7.88.1?distro=debian-12",
+		},
+		{
+			packageInfo: models.PackageInfo{Name: "busybox", Version: "1.36.1-r5", Ecosystem: string(models.EcosystemAlpine)},
+			want:        "pkg:apk/alpine/busybox@1.36.1-r5",
+		},
+	}
+
+	for _, testCase := range testCases {
+		packageURL := purl.From(testCase.packageInfo)
+
+		if packageURL == nil {
+			t.Errorf("Expected a package-url for %v but got nil", testCase.packageInfo)
+
+			continue
+		}
+
+		if got := packageURL.String(); got != testCase.want {
+			t.Errorf("got %s; want %s", got, testCase.want)
+		}
+	}
+}
+
+func TestFrom_UnknownEcosystem(t *testing.T) {
+	t.Parallel()
+
+	packageURL := purl.From(models.PackageInfo{Name: "something", Version: "1.0.0", Ecosystem: "Unknown"})
+
+	if packageURL != nil {
+		t.Errorf("Expected no package-url but got %s", packageURL)
+	}
+}
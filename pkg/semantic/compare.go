@@ -0,0 +1,44 @@
+// Package semantic provides the ecosystem-aware version comparison that is used
+// when matching packages against advisories, so that other tools can order
+// versions in the same way.
+package semantic
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/osv-scanner/internal/semantic"
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/models"
+)
+
+// toModelsEcosystem returns the ecosystem that the versions of the given one are
+// compared as, which is without the release of ecosystems like "Debian:12"
+func toModelsEcosystem(ecosystem lockfile.Ecosystem) models.Ecosystem {
+	name, _, _ := strings.Cut(string(ecosystem), ":")
+
+	return models.Ecosystem(name)
+}
+
+// Supports checks if versions of the given ecosystem are compared using the rules
+// of that ecosystem, rather than being compared as if they are semantic versions
+func Supports(ecosystem lockfile.Ecosystem) bool {
+	_, err := semantic.Parse("0", toModelsEcosystem(ecosystem))
+
+	return !errors.Is(err, semantic.ErrUnsupportedEcosystem)
+}
+
+// Compare returns an integer comparing the two versions using the rules of the given
+// ecosystem, such as Python epochs and Debian tildes, which is 0 if a == b, -1 if a < b,
+// and +1 if a > b.
+//
+// Versions of ecosystems that are not supported are compared as semantic versions.
+func Compare(ecosystem lockfile.Ecosystem, a, b string) int {
+	v, err := semantic.Parse(a, toModelsEcosystem(ecosystem))
+	if err != nil {
+		// npm versions are semantic versions
+		v = semantic.MustParse(a, models.EcosystemNPM)
+	}
+
+	return v.CompareStr(b)
+}
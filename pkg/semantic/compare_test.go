@@ -0,0 +1,72 @@
+package semantic_test
+
+import (
+	"testing"
+
+	"github.com/google/osv-scanner/pkg/lockfile"
+	"github.com/google/osv-scanner/pkg/semantic"
+)
+
+func TestCompare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ecosystem lockfile.Ecosystem
+		a         string
+		b         string
+		want      int
+	}{
+		{ecosystem: lockfile.NpmEcosystem, a: "1.2.3", b: "1.2.3", want: 0},
+		{ecosystem: lockfile.NpmEcosystem, a: "1.2.3", b: "1.10.0", want: -1},
+		{ecosystem: lockfile.NpmEcosystem, a: "1.0.0-alpha", b: "1.0.0", want: -1},
+		{ecosystem: lockfile.GoEcosystem, a: "v2.0.0+incompatible", b: "v2.0.0", want: 0},
+		{ecosystem: lockfile.GoEcosystem, a: "v2.1.0+incompatible", b: "v2.0.0", want: 1},
+		{ecosystem: lockfile.GoEcosystem, a: "v0.0.0-20220722155237-a158d28d115b", b: "v0.1.0", want: -1},
+		{ecosystem: lockfile.PipEcosystem, a: "1!1.0", b: "2.0", want: 1},
+		{ecosystem: lockfile.PipEcosystem, a: "1.0rc1", b: "1.0", want: -1},
+		{ecosystem: lockfile.PipEcosystem, a: "1.0.post1", b: "1.0", want: 1},
+		{ecosystem: lockfile.DebianEcosystem, a: "1.0~rc1", b: "1.0", want: -1},
+		{ecosystem: lockfile.DebianEcosystem, a: "1:1.0", b: "2.0", want: 1},
+		{ecosystem: "Debian:12", a: "1.0~rc1", b: "1.0", want: -1},
+		{ecosystem: lockfile.AlpineEcosystem, a: "1.2.3-r1", b: "1.2.3-r0", want: 1},
+		{ecosystem: lockfile.MavenEcosystem, a: "1.0-SNAPSHOT", b: "1.0", want: -1},
+		{ecosystem: lockfile.MavenEcosystem, a: "1.0.RELEASE", b: "1.0", want: 0},
+		{ecosystem: lockfile.BundlerEcosystem, a: "1.0.0.pre", b: "1.0.0", want: -1},
+		{ecosystem: lockfile.NuGetEcosystem, a: "1.0.0.1", b: "1.0.0", want: 1},
+		{ecosystem: lockfile.ComposerEcosystem, a: "1.0.0-beta", b: "1.0.0-RC1", want: -1},
+		{ecosystem: lockfile.CRANEcosystem, a: "1.2-10", b: "1.2-9", want: 1},
+		{ecosystem: lockfile.CargoEcosystem, a: "0.9.0", b: "0.10.0", want: -1},
+		{ecosystem: "unknown", a: "1.10.0", b: "1.9.0", want: 1},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.ecosystem)+"/"+tt.a+"/"+tt.b, func(t *testing.T) {
+			t.Parallel()
+
+			if got := semantic.Compare(tt.ecosystem, tt.a, tt.b); got != tt.want {
+				t.Errorf("Compare(%s, %s, %s) = %d, want %d", tt.ecosystem, tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSupports(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		ecosystem lockfile.Ecosystem
+		want      bool
+	}{
+		{ecosystem: lockfile.NpmEcosystem, want: true},
+		{ecosystem: lockfile.PipEcosystem, want: true},
+		{ecosystem: "Debian:12", want: true},
+		{ecosystem: "unknown", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := semantic.Supports(tt.ecosystem); got != tt.want {
+			t.Errorf("Supports(%s) = %v, want %v", tt.ecosystem, got, tt.want)
+		}
+	}
+}